@@ -3,10 +3,10 @@ package main
 import (
 	"fmt"
 	"strings"
-	"time"
 
+	"github.com/jacksmith/tk/internal/cli"
+	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -17,11 +17,15 @@ var addCmd = &cobra.Command{
 
 If --project is not specified, uses the default_project from .tkconfig.yaml.
 
+--after blocks the new task on the most recently created task in the
+project, so a sequence of dependent steps can be typed one after another.
+
 Examples:
   tk add "Dig test hole"
   tk add "Dig test hole" --project=backyard
   tk add "Dig test hole" -p BY --priority=1 --tag=weekend
-  tk add "Dig test hole" -p BY --blocked-by=BY-05,BY-03W`,
+  tk add "Dig test hole" -p BY --blocked-by=BY-05,BY-03W
+  tk add "Fill test hole" -p BY --after`,
 	Args: cobra.ExactArgs(1),
 	RunE: runAdd,
 }
@@ -37,8 +41,10 @@ var (
 	addNotes        string
 	addAssignee     string
 	addDueDate      string
+	addRemindBefore int
 	addAutoComplete bool
 	addBlockedBy    string
+	addAfter        bool
 )
 
 func init() {
@@ -52,19 +58,22 @@ func init() {
 	addCmd.Flags().StringVar(&addNotes, "notes", "", "task notes")
 	addCmd.Flags().StringVar(&addAssignee, "assignee", "", "task assignee")
 	addCmd.Flags().StringVar(&addDueDate, "due-date", "", "due date (YYYY-MM-DD)")
+	addCmd.Flags().IntVar(&addRemindBefore, "remind-before", 0, "days before the due date to show on the agenda")
 	addCmd.Flags().BoolVar(&addAutoComplete, "auto-complete", false, "auto-complete when blockers done")
 	addCmd.Flags().StringVar(&addBlockedBy, "blocked-by", "", "comma-separated blocker IDs")
+	addCmd.Flags().BoolVar(&addAfter, "after", false, "block on the most recently created task in the project")
 
 	addCmd.RegisterFlagCompletionFunc("project", completeProjectIDs)
 	addCmd.RegisterFlagCompletionFunc("tag", completeTags)
 
+	allowInactiveFlag(addCmd)
 	rootCmd.AddCommand(addCmd)
 }
 
 func runAdd(cmd *cobra.Command, args []string) error {
 	title := args[0]
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -88,11 +97,14 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		Tags:         addTags,
 		Notes:        addNotes,
 		Assignee:     addAssignee,
+		RemindBefore: addRemindBefore,
 		AutoComplete: addAutoComplete,
+		After:        addAfter,
+		Source:       model.TaskSourceCLI,
 	}
 
 	if addDueDate != "" {
-		t, err := time.Parse("2006-01-02", addDueDate)
+		t, err := model.ParseDate(addDueDate)
 		if err != nil {
 			return fmt.Errorf("invalid due date format (expected YYYY-MM-DD): %v", err)
 		}
@@ -120,5 +132,22 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("%s %s\n", task.ID, task.Title)
+	warnAutoCompleteManualWaits(s, task.ID)
 	return nil
 }
+
+// warnAutoCompleteManualWaits warns when an auto-complete task is blocked by
+// open manual waits, since completing its other blockers won't finish it
+// until someone resolves the waits too.
+func warnAutoCompleteManualWaits(s ops.Store, taskID string) {
+	waits, err := ops.AutoCompleteManualWaits(s, taskID)
+	if err != nil || len(waits) == 0 {
+		return
+	}
+	verb := "is"
+	if len(waits) > 1 {
+		verb = "are"
+	}
+	fmt.Printf("Warning: %s won't auto-complete while manual %s %s %s open; resolve with 'tk wait resolve'.\n",
+		taskID, cli.Plural(len(waits), "wait"), strings.Join(waits, ", "), verb)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/jacksmith/tk/internal/cli"
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var agendaCmd = &cobra.Command{
+	Use:   "agenda",
+	Short: "List tasks that are due or coming due",
+	Long: `List open tasks that need attention because of their due date.
+
+A task appears on the agenda when it is overdue, due today, or inside its
+reminder window. The window is set per task with --remind-before=N, which
+surfaces the task N days before its due date.
+
+Tasks are sorted by due date.
+
+Examples:
+  tk agenda
+  tk agenda -p BY`,
+	RunE: runAgenda,
+}
+
+var agendaProject string
+
+func init() {
+	agendaCmd.Flags().StringVarP(&agendaProject, "project", "p", "", "filter by project (prefix or ID)")
+	agendaCmd.RegisterFlagCompletionFunc("project", completeProjectIDs)
+	rootCmd.AddCommand(agendaCmd)
+}
+
+func runAgenda(cmd *cobra.Command, args []string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	ops.AutoCheck(s)
+
+	results, err := ops.ListTasks(s, ops.TaskFilter{Project: agendaProject, Agenda: true})
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		fmt.Println("Nothing on the agenda.")
+		return nil
+	}
+
+	printDueTable(results)
+	return nil
+}
+
+// printDueTable prints tasks that all have a due date, sorted by it, with
+// overdue dates in red and today's in yellow.
+func printDueTable(results []ops.TaskResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Task.DueDate.Before(*results[j].Task.DueDate)
+	})
+
+	today := model.Day(time.Now())
+	table := cli.NewTable()
+	if width := cli.TerminalWidth(); width > 0 {
+		table.FitColumn(4, width)
+	} else {
+		table.SetMaxWidth(4, cli.DefaultMaxTitleWidth)
+	}
+	for _, r := range results {
+		day := model.DueDay(*r.Task.DueDate)
+		due := model.FormatDate(*r.Task.DueDate)
+		switch {
+		case day < today:
+			due = cli.Red(due)
+		case day == today:
+			due = cli.Yellow(due)
+		}
+		table.AddRow(
+			r.Task.ID,
+			formatTaskState(r.State),
+			formatPriority(r.Task.Priority),
+			due,
+			r.Task.Title,
+		)
+	}
+	table.Render(os.Stdout)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jacksmith/tk/internal/cli"
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive <project>",
+	Short: "Move done and dropped items into the project's archive file",
+	Long: `Move a project's done and dropped tasks and waits out of its project
+file into an archive file next to it (for example BY.archive.yaml), so the
+project file only holds what is still in play. Running it again adds to
+the same archive.
+
+Open items stay in the project file, and so does any closed item that an
+item left there is still blocked by. IDs are not reused after archiving.
+
+Archived items no longer appear in tk list, tk show, or any other
+command; use --list to see what the archive holds instead of archiving.
+
+Examples:
+  tk archive BY
+  tk archive backyard
+  tk archive BY --list`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runArchive,
+	ValidArgsFunction: completeProjectIDs,
+}
+
+var archiveList bool
+
+func init() {
+	archiveCmd.Flags().BoolVar(&archiveList, "list", false, "list archived items instead of archiving")
+	rootCmd.AddCommand(archiveCmd)
+}
+
+func runArchive(cmd *cobra.Command, args []string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	if archiveList {
+		return listArchive(s, args[0])
+	}
+
+	result, err := ops.ArchiveProject(s, args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(result.Tasks) == 0 && len(result.Waits) == 0 {
+		fmt.Printf("Nothing to archive in %s.\n", result.Prefix)
+	} else {
+		fmt.Printf("Archived %s and %s from %s.\n",
+			cli.Count(len(result.Tasks), "task"), cli.Count(len(result.Waits), "wait"), result.Prefix)
+	}
+	if len(result.Kept) > 0 {
+		fmt.Printf("Kept %s still listed as blockers: %s\n", cli.Count(len(result.Kept), "closed item"), strings.Join(result.Kept, ", "))
+	}
+	return nil
+}
+
+// listArchive prints the tasks and waits in a project's archive, with the
+// date each was closed.
+func listArchive(s ops.Store, projectRef string) error {
+	archive, err := ops.GetArchive(s, projectRef)
+	if err != nil {
+		return err
+	}
+
+	if len(archive.Tasks) == 0 && len(archive.Waits) == 0 {
+		fmt.Printf("No archived items in %s.\n", archive.Prefix)
+		return nil
+	}
+
+	closed := func(doneAt, droppedAt *time.Time) string {
+		if doneAt != nil {
+			return model.FormatDate(*doneAt)
+		}
+		if droppedAt != nil {
+			return model.FormatDate(*droppedAt)
+		}
+		return ""
+	}
+
+	table := cli.NewTable()
+	if width := cli.TerminalWidth(); width > 0 {
+		table.FitColumn(3, width)
+	} else {
+		table.SetMaxWidth(3, cli.DefaultMaxTitleWidth)
+	}
+	for _, t := range archive.Tasks {
+		table.AddRow(t.ID, string(t.Status), closed(t.DoneAt, t.DroppedAt), t.Title)
+	}
+	for i := range archive.Waits {
+		w := &archive.Waits[i]
+		table.AddRow(w.ID, string(w.Status), closed(w.DoneAt, w.DroppedAt), w.DisplayText())
+	}
+	table.Render(os.Stdout)
+	return nil
+}
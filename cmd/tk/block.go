@@ -7,7 +7,6 @@ import (
 	"github.com/jacksmith/tk/internal/cli"
 	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -17,11 +16,19 @@ var blockCmd = &cobra.Command{
 	Long: `Add a blocker (task or wait) to a task.
 
 The blocker must be in the same project. Adding a blocker that
-would create a dependency cycle is not allowed.
+would create a dependency cycle is not allowed. Use --reason to
+record why the task depends on the blocker; it is shown by tk show.
+
+--by-project blocks the task on a whole other project instead: it
+stays blocked until every task and wait in that project is done or
+dropped. The blocker is shown as @PREFIX, e.g. @HM, and removed with
+tk unblock --from=@HM.
 
 Examples:
   tk block BY-07 --by=BY-05
-  tk block BY-07 --by=BY-03W`,
+  tk block BY-07 --by=BY-03W
+  tk block BY-07 --by=BY-05 --reason="needs their output"
+  tk block BY-07 --by-project=HM`,
 	Args: cobra.ExactArgs(1),
 	RunE: runBlock,
 }
@@ -33,7 +40,8 @@ var unblockCmd = &cobra.Command{
 
 Examples:
   tk unblock BY-07 --from=BY-05
-  tk unblock BY-07 --from=BY-03W`,
+  tk unblock BY-07 --from=BY-03W
+  tk unblock BY-07 --from=@HM`,
 	Args: cobra.ExactArgs(1),
 	RunE: runUnblock,
 }
@@ -44,7 +52,8 @@ var blockedByCmd = &cobra.Command{
 	Long: `Show all direct blockers of a task or wait.
 
 Examples:
-  tk blocked-by BY-07`,
+  tk blocked-by BY-07
+  tk blocked-by BY-03W`,
 	Args: cobra.ExactArgs(1),
 	RunE: runBlockedBy,
 }
@@ -52,30 +61,40 @@ Examples:
 var blockingCmd = &cobra.Command{
 	Use:   "blocking <id>",
 	Short: "Show what an item is blocking",
-	Long: `Show all items directly blocked by a task or wait.
+	Long: `Show all items directly blocked by a task or wait. For a wait,
+these are the tasks (and waits) waiting on it.
 
 Examples:
-  tk blocking BY-07`,
+  tk blocking BY-07
+  tk blocking BY-03W`,
 	Args: cobra.ExactArgs(1),
 	RunE: runBlocking,
 }
 
 var (
-	blockBy     string
-	unblockFrom string
+	blockBy        string
+	blockByProject string
+	blockReason    string
+	unblockFrom    string
 )
 
 func init() {
 	blockCmd.Flags().StringVar(&blockBy, "by", "", "blocker ID (task or wait)")
-	blockCmd.MarkFlagRequired("by")
+	blockCmd.Flags().StringVar(&blockByProject, "by-project", "", "block on a whole project finishing (prefix or ID)")
+	blockCmd.MarkFlagsOneRequired("by", "by-project")
+	blockCmd.MarkFlagsMutuallyExclusive("by", "by-project")
+	blockCmd.Flags().StringVar(&blockReason, "reason", "", "why the task depends on the blocker")
 	blockCmd.ValidArgsFunction = completeAnyIDs
 	blockCmd.RegisterFlagCompletionFunc("by", completeAnyIDs)
+	blockCmd.RegisterFlagCompletionFunc("by-project", completeProjectIDs)
+	allowInactiveFlag(blockCmd)
 	rootCmd.AddCommand(blockCmd)
 
 	unblockCmd.Flags().StringVar(&unblockFrom, "from", "", "blocker ID to remove")
 	unblockCmd.MarkFlagRequired("from")
 	unblockCmd.ValidArgsFunction = completeAnyIDs
 	unblockCmd.RegisterFlagCompletionFunc("from", completeAnyIDs)
+	allowInactiveFlag(unblockCmd)
 	rootCmd.AddCommand(unblockCmd)
 
 	blockedByCmd.ValidArgsFunction = completeAnyIDs
@@ -88,23 +107,33 @@ func init() {
 func runBlock(cmd *cobra.Command, args []string) error {
 	taskID := args[0]
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
 
-	if err := ops.AddBlocker(s, taskID, blockBy); err != nil {
+	if blockByProject != "" {
+		prefix, err := ops.AddProjectBlocker(s, taskID, blockByProject, blockReason)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s is now blocked until project %s is finished.\n", taskID, prefix)
+		return nil
+	}
+
+	if err := ops.AddBlocker(s, taskID, blockBy, blockReason); err != nil {
 		return err
 	}
 
 	fmt.Printf("%s is now blocked by %s.\n", taskID, blockBy)
+	warnAutoCompleteManualWaits(s, taskID)
 	return nil
 }
 
 func runUnblock(cmd *cobra.Command, args []string) error {
 	taskID := args[0]
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -120,7 +149,7 @@ func runUnblock(cmd *cobra.Command, args []string) error {
 func runBlockedBy(cmd *cobra.Command, args []string) error {
 	id := args[0]
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -145,10 +174,11 @@ func runBlockedBy(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	reasons := blockReasons(pf, id)
 	table := cli.NewTable()
 	for _, blockerID := range blockers {
 		info := ops.GetBlockerInfo(pf, blockerID)
-		table.AddRow(info.ID, formatStatusBracket(info.Status), info.DisplayText)
+		table.AddRow(info.ID, formatStatusBracket(info.Status), withBlockReason(info.DisplayText, reasons[blockerID]))
 	}
 	table.Render(os.Stdout)
 	return nil
@@ -157,7 +187,7 @@ func runBlockedBy(cmd *cobra.Command, args []string) error {
 func runBlocking(cmd *cobra.Command, args []string) error {
 	id := args[0]
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/jacksmith/tk/internal/cli"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -22,27 +24,69 @@ This command:
 This is automatically run by 'tk waits' and optionally by other read commands
 when autocheck is enabled in .tkconfig.yaml.
 
+Projects listed in ignored_projects are skipped; use -p to check one of
+them explicitly.
+
+Use --json for machine-readable output. The object always includes a
+"changed" field, which is false when nothing was resolved.
+
+Use --notify for a single summary line suited to a desktop notification,
+e.g. "Resolved 2 waits, unblocked TP-04, auto-completed TP-07". Nothing is
+printed when nothing changed, so a cron job only notifies on real changes.
+
 Examples:
-  tk check`,
+  tk check
+  tk check -p BY
+  tk check --json
+  tk check --notify | xargs -r notify-send tk`,
 	RunE: runCheck,
 }
 
+var (
+	checkJSON    bool
+	checkNotify  bool
+	checkProject string
+)
+
 func init() {
+	checkCmd.Flags().StringVarP(&checkProject, "project", "p", "", "check a single project (prefix or ID)")
+	checkCmd.RegisterFlagCompletionFunc("project", completeProjectIDs)
+	checkCmd.Flags().BoolVar(&checkJSON, "json", false, "output results as JSON")
+	checkCmd.Flags().BoolVar(&checkNotify, "notify", false, "print a one-line summary, or nothing if nothing changed")
 	rootCmd.AddCommand(checkCmd)
 }
 
 func runCheck(cmd *cobra.Command, args []string) error {
-	s, err := storage.Open(".")
+	if checkJSON && checkNotify {
+		return fmt.Errorf("--json and --notify cannot be used together")
+	}
+
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
 
-	result, err := ops.RunCheck(s)
+	var result *ops.CheckResult
+	if checkProject != "" {
+		result, err = ops.RunCheckProject(s, checkProject)
+	} else {
+		result, err = ops.RunCheck(s)
+	}
 	if err != nil {
 		return err
 	}
 
-	if len(result.ResolvedWaits) == 0 && len(result.Unblocked) == 0 && len(result.AutoCompleted) == 0 {
+	if checkJSON {
+		return printCheckJSON(result)
+	}
+	if checkNotify {
+		if result.Changed() {
+			fmt.Println(formatCheckSummary(result))
+		}
+		return nil
+	}
+
+	if !result.Changed() {
 		fmt.Println("No time waits ready to resolve.")
 		return nil
 	}
@@ -59,5 +103,50 @@ func runCheck(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Auto-completed: %s\n", strings.Join(result.AutoCompleted, ", "))
 	}
 
+	for _, id := range result.NowReady {
+		fmt.Printf("%s is now ready to start.\n", id)
+	}
+
 	return nil
 }
+
+// formatCheckSummary renders a check result as one line, e.g. "Resolved 2
+// waits, unblocked TP-04, auto-completed TP-07". Empty parts are left out.
+func formatCheckSummary(result *ops.CheckResult) string {
+	var parts []string
+	if len(result.ResolvedWaits) > 0 {
+		parts = append(parts, "resolved "+cli.Count(len(result.ResolvedWaits), "wait"))
+	}
+	if len(result.Unblocked) > 0 {
+		parts = append(parts, "unblocked "+strings.Join(result.Unblocked, ", "))
+	}
+	if len(result.AutoCompleted) > 0 {
+		parts = append(parts, "auto-completed "+strings.Join(result.AutoCompleted, ", "))
+	}
+	line := strings.Join(parts, ", ")
+	if line == "" {
+		return ""
+	}
+	return strings.ToUpper(line[:1]) + line[1:]
+}
+
+// printCheckJSON writes the check result as a JSON object. Empty lists are
+// written as [] rather than null so consumers can iterate without checks.
+func printCheckJSON(result *ops.CheckResult) error {
+	out := struct {
+		Changed bool `json:"changed"`
+		*ops.CheckResult
+	}{
+		Changed:     result.Changed(),
+		CheckResult: result,
+	}
+	for _, list := range []*[]string{&result.ResolvedWaits, &result.Unblocked, &result.NowReady, &result.AutoCompleted} {
+		if *list == nil {
+			*list = []string{}
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
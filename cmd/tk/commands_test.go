@@ -2,6 +2,9 @@ package main
 
 import (
 	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jacksmith/tk/internal/cli"
 	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/ops"
 	"github.com/jacksmith/tk/internal/storage"
@@ -184,6 +188,24 @@ func TestListCommand(t *testing.T) {
 			contains: []string{"TP-01"},
 			excludes: []string{"TP-02", "TP-03", "TP-05"},
 		},
+		{
+			name:     "no-waiting exclusion",
+			flags:    func() { listNoWaiting = true },
+			contains: []string{"TP-01", "TP-02", "TP-05"},
+			excludes: []string{"TP-03", "TP-04"},
+		},
+		{
+			name:     "no-waiting and no-blocked combined",
+			flags:    func() { listNoWaiting = true; listNoBlocked = true },
+			contains: []string{"TP-01", "TP-05"},
+			excludes: []string{"TP-02", "TP-03", "TP-04"},
+		},
+		{
+			name:     "exclusion with all",
+			flags:    func() { listAll = true; listNoBlocked = true },
+			contains: []string{"TP-01", "TP-03", "TP-04", "TP-05"},
+			excludes: []string{"TP-02"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -203,6 +225,8 @@ func TestListCommand(t *testing.T) {
 			listP4 = false
 			listTags = nil
 			listOverdue = false
+			listNoWaiting = false
+			listNoBlocked = false
 
 			// Apply test-specific flags
 			tt.flags()
@@ -232,6 +256,20 @@ func TestListCommand(t *testing.T) {
 	}
 }
 
+func TestListExclusionConflicts(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+	defer resetListFlags()
+
+	resetListFlags()
+	listWaiting, listNoWaiting = true, true
+	assert.ErrorContains(t, runList(nil, nil), "conflicting status filters: --waiting, --no-waiting")
+
+	resetListFlags()
+	listBlocked, listNoBlocked = true, true
+	assert.ErrorContains(t, runList(nil, nil), "conflicting status filters: --blocked, --no-blocked")
+}
+
 func TestWaitsCommand(t *testing.T) {
 	_, _, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
@@ -264,6 +302,60 @@ func TestWaitsCommand(t *testing.T) {
 	assert.Contains(t, output, "TP-02W")
 }
 
+func TestWaitsTiming(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	waitsProject = ""
+	waitsActionable = false
+	waitsDormant = false
+	waitsDone = false
+	waitsDropped = false
+	waitsAll = false
+
+	// A manual wait whose check date has passed, created two weeks ago
+	pf, _ := s.LoadProject("TP")
+	checkAfter := time.Now().AddDate(0, 0, -2)
+	pf.Waits = append(pf.Waits, model.Wait{
+		ID:      "TP-07W",
+		Status:  model.WaitStatusOpen,
+		Created: time.Now().AddDate(0, 0, -14),
+		ResolutionCriteria: model.ResolutionCriteria{
+			Type:       model.ResolutionTypeManual,
+			Question:   "Did the quote come back?",
+			CheckAfter: &checkAfter,
+		},
+	})
+	pf.NextID = 8
+	s.SaveProject(pf)
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runWaits(nil, nil)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	assert.NoError(t, err)
+	lines := strings.Split(buf.String(), "\n")
+	assertLine := func(id, want string) {
+		for _, line := range lines {
+			if strings.HasPrefix(line, id) {
+				assert.Contains(t, line, want, id)
+				return
+			}
+		}
+		t.Errorf("%s not listed", id)
+	}
+	assertLine("TP-01W", "0m old")
+	assertLine("TP-02W", "in 7d")
+	assertLine("TP-07W", "2d overdue")
+}
+
 func TestWaitsActionableFilter(t *testing.T) {
 	_, _, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
@@ -347,6 +439,203 @@ func TestShowTaskCommand(t *testing.T) {
 	assert.Contains(t, output, "blocked")
 	assert.Contains(t, output, "Blocked by:")
 	assert.Contains(t, output, "TP-01")
+	assert.Contains(t, output, "0 of 1 blocker resolved; waiting on TP-01")
+}
+
+func TestShowDepsOnly(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	show := func(id string) string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		showDepsOnly = true
+		defer func() { showDepsOnly = false }()
+		err := runShow(nil, []string{id})
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	output := show("tp-01")
+	assert.Contains(t, output, "TP-01: Ready task")
+	assert.Contains(t, output, "State: ready")
+	assert.Contains(t, output, "Blocking:")
+	assert.Contains(t, output, "TP-02")
+	assert.NotContains(t, output, "Priority:")
+	assert.NotContains(t, output, "Created:")
+
+	output = show("TP-02")
+	assert.Contains(t, output, "State: blocked")
+	assert.Contains(t, output, "0 of 1 blocker resolved; waiting on TP-01")
+	assert.NotContains(t, output, "Blocking:")
+
+	output = show("TP-01W")
+	assert.Contains(t, output, "Blocking:")
+	assert.Contains(t, output, "TP-03")
+	assert.NotContains(t, output, "Type:")
+
+	output = show("TP-05")
+	assert.Contains(t, output, "No blockers or dependents.")
+}
+
+func TestShowTaskBlockerSummary(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	pf, _ := s.LoadProject("TP")
+	pf.Tasks = append(pf.Tasks, model.Task{
+		ID:        "TP-06",
+		Title:     "Mixed blockers",
+		Status:    model.TaskStatusOpen,
+		Priority:  2,
+		BlockedBy: []string{"TP-01", "TP-04", "TP-01W"},
+		Created:   time.Now(),
+		Updated:   time.Now(),
+	})
+	pf.NextID = 7
+	require.NoError(t, s.SaveProject(pf))
+
+	// Capture output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runShow(nil, []string{"TP-06"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "1 of 3 blockers resolved; waiting on TP-01, TP-01W")
+	assert.Contains(t, buf.String(), "dependencies 1/3 resolved (33%)")
+}
+
+func TestShowDependentsLive(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	show := func(id string) string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runShow(nil, []string{id})
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	output := show("TP-01")
+	assert.Contains(t, output, "Blocking:")
+	assert.Contains(t, output, "TP-02")
+	assert.NotContains(t, output, "TP-05")
+
+	// The blocker's view changes as soon as the dependent's blocked_by does
+	require.NoError(t, ops.AddBlocker(s, "TP-05", "TP-01", ""))
+	assert.Regexp(t, `Blocking:\n\s+TP-02 .*\n\s+TP-05 `, show("TP-01"))
+
+	require.NoError(t, ops.RemoveBlocker(s, "TP-05", "TP-01"))
+	assert.NotContains(t, show("TP-01"), "TP-05")
+
+	// Waits list their dependents too
+	assert.Regexp(t, `Blocking:\n\s+TP-03 `, show("TP-01W"))
+}
+
+func TestBlockWithReason(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	blockBy, blockReason = "TP-01", "needs their output"
+	defer func() {
+		blockBy, blockReason = "", ""
+	}()
+	require.NoError(t, runBlock(nil, []string{"TP-05"}))
+
+	show := func(id string) string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runShow(nil, []string{id})
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	// The reason appears on both ends of the relationship
+	assert.Regexp(t, `Blocked by:\n\s+TP-01 .*\(reason: needs their output\)`, show("TP-05"))
+	assert.Regexp(t, `TP-05 .*\(reason: needs their output\)`, show("TP-01"))
+}
+
+func TestBlockByProject(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	require.NoError(t, ops.CreateProject(s, "house", "HM", "House", ""))
+	_, err := ops.AddTask(s, "HM", "Paint", ops.TaskOptions{})
+	require.NoError(t, err)
+
+	blockByProject = "house"
+	defer func() { blockByProject = "" }()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = runBlock(nil, []string{"TP-05"})
+	if err == nil {
+		err = runShow(nil, []string{"TP-05"})
+	}
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	output := buf.String()
+	// The resolved prefix is printed, not the reference as typed
+	assert.Contains(t, output, "TP-05 is now blocked until project HM is finished.")
+	assert.Contains(t, output, "blocked")
+	assert.Regexp(t, `@HM .*all of project HM`, output)
+	assert.Contains(t, output, "waiting on @HM")
+}
+
+func TestShowProjectRefHint(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	for _, ref := range []string{"default", "TP"} {
+		err := runShow(nil, []string{ref})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is a project, not a task or wait")
+		assert.Contains(t, err.Error(), "tk project default")
+	}
+
+	// Unknown refs still get the usual error
+	err := runShow(nil, []string{"nonsense"})
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "is a project")
 }
 
 func TestShowWaitCommand(t *testing.T) {
@@ -401,6 +690,111 @@ func TestProjectCommand(t *testing.T) {
 	assert.Contains(t, output, "done")
 }
 
+func TestProjectCommandCountPhrasing(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	project := func(ref string) string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runProject(nil, []string{ref})
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	output := project("TP")
+	assert.Contains(t, output, "4 open tasks (2 ready, 1 blocked, 1 waiting), 1 done\n")
+	assert.Contains(t, output, "2 open waits\n")
+
+	require.NoError(t, ops.CreateProject(s, "solo", "SL", "Solo", ""))
+	_, err := ops.AddTask(s, "SL", "Only task", ops.TaskOptions{})
+	require.NoError(t, err)
+	_, err = ops.AddWait(s, "SL", ops.WaitOptions{Type: model.ResolutionTypeManual, Question: "Ready?"})
+	require.NoError(t, err)
+
+	output = project("SL")
+	assert.Contains(t, output, "1 open task (1 ready), 0 done\n")
+	assert.Contains(t, output, "1 open wait\n")
+
+	_, err = ops.CompleteTask(s, "SL-01", ops.CompleteOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, project("SL"), "no open tasks, 1 done\n")
+}
+
+func TestProjectCommandNotes(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	notes := "Waiting on permits\nDesign approved"
+	require.NoError(t, ops.EditProject(s, "TP", ops.ProjectChanges{Notes: &notes}))
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runProject(nil, []string{"TP"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Notes:\n  Waiting on permits\n  Design approved\n")
+}
+
+func TestProjectHistory(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	projectHistory = true
+	defer func() { projectHistory = false }()
+
+	jan := time.Date(2026, time.January, 10, 12, 0, 0, 0, time.Local)
+	mar := time.Date(2026, time.March, 3, 12, 0, 0, 0, time.Local)
+	pf, _ := s.LoadProject("TP")
+	for i := range pf.Tasks {
+		switch pf.Tasks[i].ID {
+		case "TP-04":
+			pf.Tasks[i].DoneAt = &jan
+		case "TP-05":
+			pf.Tasks[i].Status = model.TaskStatusDone
+			pf.Tasks[i].DoneAt = &mar
+		case "TP-01":
+			pf.Tasks[i].Status = model.TaskStatusDone
+			pf.Tasks[i].DoneAt = &mar
+		}
+	}
+	require.NoError(t, s.SaveProject(pf))
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runProject(nil, []string{"TP"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	require.NoError(t, err)
+	assert.Contains(t, output, "Completed per month:")
+	assert.Regexp(t, `2026-01\s+1\s+#\n`, output)
+	assert.Regexp(t, `2026-02\s+0`, output)
+	assert.Regexp(t, `2026-03\s+2\s+##\n`, output)
+}
+
 func TestProjectsCommand(t *testing.T) {
 	_, _, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
@@ -427,6 +821,45 @@ func TestProjectsCommand(t *testing.T) {
 	assert.Contains(t, output, "Test Project")
 }
 
+func TestProjectsTree(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	require.NoError(t, ops.CreateProject(s, "garden", "GD", "Garden", ""))
+	require.NoError(t, ops.CreateProject(s, "house", "HM", "House", ""))
+	_, err := ops.AddTask(s, "GD", "Plant beds", ops.TaskOptions{})
+	require.NoError(t, err)
+	_, err = ops.AddTask(s, "HM", "Paint fence", ops.TaskOptions{})
+	require.NoError(t, err)
+
+	// Cross-project references can only be written into the file directly
+	pf, err := s.LoadProject("GD")
+	require.NoError(t, err)
+	pf.Tasks[0].BlockedBy = []string{"TP-01", "TP-02"}
+	require.NoError(t, s.SaveProject(pf))
+
+	projectsAll = false
+	projectsTree = true
+	defer func() { projectsTree = false }()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = runProjects(nil, nil)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	output := buf.String()
+	assert.Contains(t, output, "TP  Test Project\n  GD  Garden (2 items blocked)\n")
+	assert.Contains(t, output, "HM  House\n")
+	assert.NotContains(t, output, "\nGD")
+}
+
 func TestGraphCommand(t *testing.T) {
 	_, _, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
@@ -482,6 +915,48 @@ func TestValidateCommand(t *testing.T) {
 	assert.Contains(t, output, "No issues found")
 }
 
+func TestDoctorCommand(t *testing.T) {
+	dir, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	run := func() (string, error) {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runDoctor(nil, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		return buf.String(), err
+	}
+
+	output, err := run()
+	require.NoError(t, err)
+	assert.Contains(t, output, "Checked 1 project and config.")
+	assert.Contains(t, output, "No issues found")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".tkconfig.yaml"), []byte("default_project: gone\ndefault_priority: 7\n"), 0644))
+
+	output, err = run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 issues left unrepaired")
+	assert.Contains(t, output, "default_project")
+	assert.Contains(t, output, "Summary: config 2")
+	assert.Contains(t, output, "tk doctor --fix")
+
+	doctorFix = true
+	defer func() { doctorFix = false }()
+
+	output, err = run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 issue left unrepaired")
+	assert.Contains(t, output, `cleared default_project "gone"`)
+	assert.Contains(t, output, "default_priority")
+}
+
 func TestInitCommand(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "tk-init-test-*")
 	require.NoError(t, err)
@@ -557,22 +1032,135 @@ func TestInitWithCustomOptions(t *testing.T) {
 	assert.Contains(t, output, "MT")
 }
 
-func TestReadyCommand(t *testing.T) {
-	_, _, cleanup := setupTestStorageWithData(t)
+func TestInitFrom(t *testing.T) {
+	srcDir, src, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
-	// Reset list flags
-	listProject = ""
-	listReady = false
-	listBlocked = false
-	listWaiting = false
-	listDone = false
-	listDropped = false
-	listAll = false
-	listPriority = 0
-	listTags = nil
+	// A second project whose ID is not "default", exported to a file
+	require.NoError(t, ops.CreateProject(src, "client", "CL", "Client Work", ""))
+	_, err := ops.AddTask(src, "CL", "Kickoff", ops.TaskOptions{})
+	require.NoError(t, err)
+	pf, err := ops.ExportProject(src, "CL", true)
+	require.NoError(t, err)
+	data, err := model.MarshalProject(pf)
+	require.NoError(t, err)
+	bundle := filepath.Join(srcDir, "client.yaml")
+	require.NoError(t, os.WriteFile(bundle, data, 0644))
+
+	initFromDir := func(from string) (*storage.Storage, string) {
+		dir := t.TempDir()
+		origDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(dir))
+		defer os.Chdir(origDir)
+
+		initName = ""
+		initPrefix = ""
+		initFrom = from
+		defer func() { initFrom = "" }()
+
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err = runInit(nil, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		s, err := storage.Open(dir)
+		require.NoError(t, err)
+		return s, buf.String()
+	}
 
-	// Capture output
+	// From a whole tk directory
+	s, output := initFromDir(srcDir)
+	assert.Contains(t, output, `Copied project "Test Project" with prefix TP (5 tasks, 2 waits)`)
+	assert.NotContains(t, output, "default_project")
+	prefixes, err := s.ListProjects()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"CL", "TP"}, prefixes)
+
+	// From a single exported file: its project becomes the default
+	s, output = initFromDir(bundle)
+	assert.Contains(t, output, `Set default_project to "client"`)
+	cfg, err := s.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "client", cfg.DefaultProject)
+
+	initFrom = bundle
+	initPrefix = "XX"
+	defer func() { initFrom = ""; initPrefix = "" }()
+	assert.Error(t, runInit(nil, nil))
+}
+
+func TestInitFromCleanup(t *testing.T) {
+	srcDir, src, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// CL copies first; TP then fails validation
+	require.NoError(t, ops.CreateProject(src, "client", "CL", "Client Work", ""))
+	pf, err := src.LoadProject("TP")
+	require.NoError(t, err)
+	pf.Tasks[0].Title = ""
+	require.NoError(t, src.SaveProject(pf))
+
+	initFromDir := func(dir string) error {
+		origDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(dir))
+		defer os.Chdir(origDir)
+
+		initName = ""
+		initPrefix = ""
+		initFrom = srcDir
+		defer func() { initFrom = "" }()
+
+		old := os.Stdout
+		_, w, _ := os.Pipe()
+		os.Stdout = w
+		defer func() { w.Close(); os.Stdout = old }()
+		return runInit(nil, nil)
+	}
+
+	// A projects_dir this run creates is removed along with .tk/
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".tkconfig.yaml"), []byte("projects_dir: shared/projects\n"), 0644))
+	assert.ErrorContains(t, initFromDir(dir), "failed to copy project TP")
+	assert.NoDirExists(t, filepath.Join(dir, ".tk"))
+	assert.NoDirExists(t, filepath.Join(dir, "shared"))
+
+	// In an existing one, only the files this run wrote are removed
+	dir = t.TempDir()
+	shared := filepath.Join(dir, "shared")
+	require.NoError(t, os.MkdirAll(shared, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(shared, "OT.yaml"), []byte("keep"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".tkconfig.yaml"), []byte("projects_dir: shared\n"), 0644))
+	assert.ErrorContains(t, initFromDir(dir), "failed to copy project TP")
+	assert.NoDirExists(t, filepath.Join(dir, ".tk"))
+	assert.FileExists(t, filepath.Join(shared, "OT.yaml"))
+	assert.NoFileExists(t, filepath.Join(shared, "CL.yaml"))
+}
+
+func TestReadyCommand(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// Reset list flags
+	listProject = ""
+	listReady = false
+	listBlocked = false
+	listWaiting = false
+	listDone = false
+	listDropped = false
+	listAll = false
+	listPriority = 0
+	listTags = nil
+
+	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
@@ -750,31 +1338,26 @@ func TestFindWaitQuestion(t *testing.T) {
 	assert.Contains(t, output, "TP-01W")
 }
 
-func TestListByPriorityShorthand(t *testing.T) {
-	_, _, cleanup := setupTestStorageWithData(t)
+func TestFindMultipleProjects(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
-	// Reset flags
-	listProject = ""
-	listReady = false
-	listBlocked = false
-	listWaiting = false
-	listDone = false
-	listDropped = false
-	listAll = false
-	listPriority = 0
-	listP1 = true
-	listP2 = false
-	listP3 = false
-	listP4 = false
-	listTags = nil
+	require.NoError(t, ops.CreateProject(s, "garden", "GD", "Garden", ""))
+	require.NoError(t, ops.CreateProject(s, "house", "HS", "House", ""))
+	_, err := ops.AddTask(s, "GD", "Spread gravel on path", ops.TaskOptions{})
+	require.NoError(t, err)
+	_, err = ops.AddTask(s, "HS", "Gravel for driveway", ops.TaskOptions{})
+	require.NoError(t, err)
+
+	findProject = "TP, garden"
+	defer func() { findProject = "" }()
 
 	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runList(nil, nil)
+	err = runFind(nil, []string{"gravel"})
 
 	w.Close()
 	var buf bytes.Buffer
@@ -783,47 +1366,44 @@ func TestListByPriorityShorthand(t *testing.T) {
 
 	output := buf.String()
 
-	assert.NoError(t, err)
-	// Only TP-01 has priority 1
-	assert.Contains(t, output, "TP-01")
-	assert.NotContains(t, output, "TP-02")
-	assert.NotContains(t, output, "TP-03")
-	assert.NotContains(t, output, "TP-05")
+	require.NoError(t, err)
+	assert.Contains(t, output, "TP-05")
+	assert.Contains(t, output, "GD-01")
+	assert.NotContains(t, output, "HS-01")
 }
 
-func TestCaseInsensitiveIDLookup(t *testing.T) {
+func TestFindMultipleProjectsUnknown(t *testing.T) {
 	_, _, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
-	// Test lowercase ID
-	old := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
-	err := runShow(nil, []string{"tp-01"})
-
-	w.Close()
-	var buf bytes.Buffer
-	buf.ReadFrom(r)
-	os.Stdout = old
-
-	output := buf.String()
+	findProject = "TP,nope"
+	defer func() { findProject = "" }()
 
-	assert.NoError(t, err)
-	assert.Contains(t, output, "Ready task")
+	err := runFind(nil, []string{"gravel"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
 }
 
-func TestGraphDOTFormat(t *testing.T) {
-	_, _, cleanup := setupTestStorageWithData(t)
+func TestFindLimit(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
-	graphProject = ""
+	require.NoError(t, ops.CreateProject(s, "garden", "GD", "Garden", ""))
+	for _, title := range []string{"Rake leaves", "Bag leaves", "Compost leaves"} {
+		_, err := ops.AddTask(s, "GD", title, ops.TaskOptions{})
+		require.NoError(t, err)
+	}
+	_, err := ops.AddTask(s, "TP", "Blow leaves", ops.TaskOptions{})
+	require.NoError(t, err)
+
+	findLimit = 2
+	defer func() { findLimit = 0 }()
 
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runGraph(nil, nil)
+	err = runFind(nil, []string{"leaves"})
 
 	w.Close()
 	var buf bytes.Buffer
@@ -832,77 +1412,89 @@ func TestGraphDOTFormat(t *testing.T) {
 
 	output := buf.String()
 
-	assert.NoError(t, err)
-
-	// Verify DOT structure
-	assert.True(t, strings.HasPrefix(output, "digraph tk {"))
-	assert.Contains(t, output, "rankdir=LR")
-	assert.Contains(t, output, "node [shape=box]")
-	assert.True(t, strings.HasSuffix(strings.TrimSpace(output), "}"))
+	// Ordered by project then ID: GD-01, GD-02 shown; GD-03 and TP-06 hidden
+	require.NoError(t, err)
+	assert.Contains(t, output, "GD-01")
+	assert.Contains(t, output, "GD-02")
+	assert.NotContains(t, output, "GD-03")
+	assert.NotContains(t, output, "TP-06")
+	assert.Contains(t, output, "+2 more")
+}
 
-	// Verify edges are correct direction (blocker -> blocked)
-	assert.Contains(t, output, `"TP-01" -> "TP-02"`)
-	assert.Contains(t, output, `"TP-01W" -> "TP-03"`)
+// TestFindIDsOnly tests that --ids-only prints bare IDs, tasks before waits
+func TestFindIDsOnly(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
 
-	// Verify wait edges are dashed
-	assert.Contains(t, output, "[style=dashed]")
-}
+	_, err := ops.AddWait(s, "TP", ops.WaitOptions{
+		Title:    "Task review",
+		Type:     model.ResolutionTypeManual,
+		Question: "Did the task review happen?",
+	})
+	require.NoError(t, err)
 
-// ============= Phase 8 Write Command Tests =============
+	defer func() {
+		findIDsOnly, findTasksOnly, findWaitsOnly, findLimit = false, false, false, 0
+	}()
 
-func TestAddCommand(t *testing.T) {
-	_, s, cleanup := setupTestStorage(t)
-	defer cleanup()
+	run := func(query string) string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
 
-	// Reset flags
-	addProject = "TP"
-	addPriority = 2
-	addTags = []string{"test"}
-	addNotes = ""
-	addAssignee = ""
-	addDueDate = ""
-	addAutoComplete = false
-	addBlockedBy = ""
+		err := runFind(nil, []string{query})
 
-	// Capture output
-	old := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		require.NoError(t, err)
+		return buf.String()
+	}
 
-	err := runAdd(nil, []string{"Test task"})
+	findIDsOnly = true
+	assert.Equal(t, "TP-01\nTP-02\nTP-03\nTP-04\nTP-05\nTP-06W\n", run("task"))
 
-	w.Close()
-	var buf bytes.Buffer
-	buf.ReadFrom(r)
-	os.Stdout = old
+	findTasksOnly = true
+	findLimit = 2
+	assert.Equal(t, "TP-01\nTP-02\n", run("task"))
 
-	output := buf.String()
+	findTasksOnly, findWaitsOnly, findLimit = false, true, 0
+	assert.Equal(t, "TP-06W\n", run("task"))
 
-	assert.NoError(t, err)
-	assert.Contains(t, output, "TP-01")
-	assert.Contains(t, output, "Test task")
+	assert.Equal(t, "", run("nonexistent"), "no matches should print nothing")
 
-	// Verify task was created
-	pf, _ := s.LoadProject("TP")
-	assert.Equal(t, 1, len(pf.Tasks))
-	assert.Equal(t, "Test task", pf.Tasks[0].Title)
-	assert.Equal(t, 2, pf.Tasks[0].Priority)
-	assert.Contains(t, pf.Tasks[0].Tags, "test")
+	findIDsOnly = false
+	output := run("task")
+	assert.Contains(t, output, "Waits:")
+	assert.NotContains(t, output, "Tasks:")
 }
 
-func TestDoneCommand(t *testing.T) {
-	_, s, cleanup := setupTestStorageWithData(t)
+func TestListByPriorityShorthand(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
 	// Reset flags
-	doneForce = false
+	listProject = ""
+	listReady = false
+	listBlocked = false
+	listWaiting = false
+	listDone = false
+	listDropped = false
+	listAll = false
+	listPriority = 0
+	listP1 = true
+	listP2 = false
+	listP3 = false
+	listP4 = false
+	listTags = nil
 
 	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runDone(nil, []string{"TP-01"})
+	err := runList(nil, nil)
 
 	w.Close()
 	var buf bytes.Buffer
@@ -912,199 +1504,1339 @@ func TestDoneCommand(t *testing.T) {
 	output := buf.String()
 
 	assert.NoError(t, err)
-	assert.Contains(t, output, "TP-01 done")
-	assert.Contains(t, output, "Unblocked")
-	assert.Contains(t, output, "TP-02")
-
-	// Verify task status
-	pf, _ := s.LoadProject("TP")
-	var task *model.Task
-	for i := range pf.Tasks {
-		if pf.Tasks[i].ID == "TP-01" {
-			task = &pf.Tasks[i]
-			break
-		}
-	}
-	assert.Equal(t, model.TaskStatusDone, task.Status)
-	assert.NotNil(t, task.DoneAt)
+	// Only TP-01 has priority 1
+	assert.Contains(t, output, "TP-01")
+	assert.NotContains(t, output, "TP-02")
+	assert.NotContains(t, output, "TP-03")
+	assert.NotContains(t, output, "TP-05")
 }
 
-func TestDoneCommandWithBlockers(t *testing.T) {
+func TestListOnelineFormat(t *testing.T) {
 	_, _, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
-	// Try to complete a blocked task without force
-	doneForce = false
+	resetListFlags()
+	defer resetListFlags()
+	listFormat = "oneline"
+
+	cli.SetColorEnabled(true)
+	defer cli.SetColorEnabled(false)
 
-	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runDone(nil, []string{"TP-02"})
+	err := runList(nil, nil)
 
 	w.Close()
 	var buf bytes.Buffer
 	buf.ReadFrom(r)
 	os.Stdout = old
 
-	output := buf.String()
-
-	// The command should return an error
-	assert.Error(t, err)
-	// The output should contain information about blockers
-	assert.Contains(t, output, "incomplete blockers")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{
+		"TP-01 P1 Ready task",
+		"TP-02 P2 Blocked task",
+		"TP-03 P3 Waiting task",
+		"TP-05 P4 Task with notes about gravel",
+	}, lines)
+	assert.NotContains(t, buf.String(), "\033[")
 }
 
-func TestDoneCommandForce(t *testing.T) {
+func TestListJSON(t *testing.T) {
 	_, s, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
-	// Complete a blocked task with force
-	doneForce = true
+	require.NoError(t, ops.CreateProject(s, "alpha", "AL", "Alpha", ""))
+	_, err := ops.AddTask(s, "AL", "First alpha task", ops.TaskOptions{Priority: 4})
+	require.NoError(t, err)
 
-	// Capture output
-	old := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+	resetListFlags()
+	defer resetListFlags()
+	listJSON = true
+
+	run := func(fn func(*cobra.Command, []string) error) string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := fn(nil, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		require.NoError(t, err)
+		return buf.String()
+	}
 
-	err := runDone(nil, []string{"TP-02"})
+	var results []ops.TaskResult
+	require.NoError(t, json.Unmarshal([]byte(run(runList)), &results))
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.Task.ID)
+	}
+	assert.Equal(t, []string{"AL-01", "TP-01", "TP-02", "TP-03", "TP-05"}, ids)
+	assert.Equal(t, "AL", results[0].Project)
+	assert.Equal(t, model.TaskStateBlocked, results[2].State)
+	assert.Equal(t, []string{"TP-01"}, results[2].Task.BlockedBy)
+
+	// ready --limit picks by urgency but still prints sorted by ID
+	readyLimit = 1
+	require.NoError(t, json.Unmarshal([]byte(run(runReady)), &results))
+	require.Len(t, results, 1)
+	assert.Equal(t, "TP-01", results[0].Task.ID)
+	assert.Equal(t, model.TaskStateReady, results[0].State)
 
-	w.Close()
-	var buf bytes.Buffer
-	buf.ReadFrom(r)
-	os.Stdout = old
+	resetListFlags()
+	listJSON = true
+	listProject = "TP"
+	listTags = []string{"nonexistent"}
+	assert.Equal(t, "[]\n", run(runList))
+}
 
-	assert.NoError(t, err)
+func TestListAssignee(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
 
-	// Verify task status
-	pf, _ := s.LoadProject("TP")
-	var task *model.Task
-	for i := range pf.Tasks {
-		if pf.Tasks[i].ID == "TP-02" {
-			task = &pf.Tasks[i]
-			break
-		}
+	assignee := "Dana"
+	require.NoError(t, ops.EditTask(s, "TP-01", ops.TaskChanges{Assignee: &assignee}))
+
+	resetListFlags()
+	defer resetListFlags()
+	listFormat = "oneline"
+
+	run := func() string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runList(listCmd, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		require.NoError(t, err)
+		return buf.String()
 	}
-	assert.Equal(t, model.TaskStatusDone, task.Status)
-	// Blockers should be removed
-	assert.Empty(t, task.BlockedBy)
+
+	listAssignee = "dana"
+	assert.Equal(t, "TP-01 P1 Ready task\n", run())
+
+	// An explicitly empty --assignee lists unassigned tasks
+	require.NoError(t, listCmd.Flags().Set("assignee", ""))
+	defer func() { listCmd.Flags().Lookup("assignee").Changed = false }()
+	output := run()
+	assert.NotContains(t, output, "TP-01")
+	assert.Contains(t, output, "TP-02")
+	assert.Contains(t, output, "TP-05")
 }
 
-// TestDoneCommandNoForceHintForDoneTask verifies that the --force hint is NOT
-// shown when trying to complete an already-done task (DF-04 fix).
-func TestDoneCommandNoForceHintForDoneTask(t *testing.T) {
+func TestListSort(t *testing.T) {
 	_, _, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
-	// TP-04 is already done in the test data
-	doneForce = false
-
-	// Capture output
-	old := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+	resetListFlags()
+	defer resetListFlags()
+	listFormat = "oneline"
 
-	err := runDone(nil, []string{"TP-04"})
+	run := func() (string, error) {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
 
-	w.Close()
-	var buf bytes.Buffer
-	buf.ReadFrom(r)
-	os.Stdout = old
+		err := runList(nil, nil)
 
-	output := buf.String()
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		return buf.String(), err
+	}
 
-	assert.Error(t, err)
-	assert.Contains(t, output, "not open")
-	assert.NotContains(t, output, "Use --force to remove blockers and complete anyway.")
+	listSort = "priority"
+	listReverse = true
+	output, err := run()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"TP-05 P4 Task with notes about gravel",
+		"TP-03 P3 Waiting task",
+		"TP-02 P2 Blocked task",
+		"TP-01 P1 Ready task",
+	}, strings.Split(strings.TrimSpace(output), "\n"))
+
+	listSort = "size"
+	_, err = run()
+	assert.ErrorContains(t, err, "invalid sort: size")
+
+	listSort = ""
+	_, err = run()
+	assert.ErrorContains(t, err, "--reverse requires --sort")
 }
 
-// TestDoneCommandForceHintForBlockedTask verifies that the --force hint IS
-// shown when trying to complete a task with incomplete blockers.
+func TestNextCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	run := func() string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runNext(nil, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	output := run()
+	assert.Contains(t, output, "TP-01: Ready task")
+	assert.Contains(t, output, "Priority:")
+
+	_, err := ops.CompleteTask(s, "TP-01", ops.CompleteOptions{})
+	require.NoError(t, err)
+	_, err = ops.DropTask(s, "TP-05", "", false, false)
+	require.NoError(t, err)
+	// TP-02 is ready now that TP-01 is done
+	assert.Contains(t, run(), "TP-02: Blocked task")
+
+	_, err = ops.CompleteTask(s, "TP-02", ops.CompleteOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "Nothing ready.\n", run())
+
+	// Ready tasks in projects other than default_project count too
+	require.NoError(t, ops.CreateProject(s, "backyard", "BY", "Backyard", ""))
+	require.NoError(t, ops.CreateProject(s, "house", "HM", "House", ""))
+	_, err = ops.AddTask(s, "BY", "Rake leaves", ops.TaskOptions{Priority: 3})
+	require.NoError(t, err)
+	_, err = ops.AddTask(s, "HM", "Fix the gutter", ops.TaskOptions{Priority: 1})
+	require.NoError(t, err)
+	assert.Contains(t, run(), "HM-01: Fix the gutter")
+
+	nextProject = "BY"
+	defer func() { nextProject = "" }()
+	assert.Contains(t, run(), "BY-01: Rake leaves")
+}
+
+func TestDueCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	defer func() { dueIn = "7d" }()
+
+	run := func() (string, error) {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runDue(nil, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		return buf.String(), err
+	}
+
+	dueIn = "3d"
+	output, err := run()
+	require.NoError(t, err)
+	assert.Equal(t, "Nothing due in the next 3d.\n", output)
+
+	soon := time.Now().AddDate(0, 0, 2)
+	later := time.Now().AddDate(0, 0, 10)
+	soonPtr, laterPtr := &soon, &later
+	require.NoError(t, ops.EditTask(s, "TP-01", ops.TaskChanges{DueDate: &soonPtr}))
+	require.NoError(t, ops.EditTask(s, "TP-05", ops.TaskChanges{DueDate: &laterPtr}))
+
+	output, err = run()
+	require.NoError(t, err)
+	assert.Contains(t, output, "TP-01")
+	assert.NotContains(t, output, "TP-05")
+
+	dueIn = "2w"
+	output, err = run()
+	require.NoError(t, err)
+	assert.Contains(t, output, "TP-05")
+
+	dueIn = "soon"
+	_, err = run()
+	assert.ErrorContains(t, err, "invalid duration")
+}
+
+func TestListBlockedBy(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// TP-01 -> TP-02 -> TP-06
+	pf, _ := s.LoadProject("TP")
+	pf.Tasks = append(pf.Tasks, model.Task{
+		ID:        "TP-06",
+		Title:     "Downstream task",
+		Status:    model.TaskStatusOpen,
+		Priority:  3,
+		BlockedBy: []string{"TP-02"},
+		Created:   time.Now(),
+		Updated:   time.Now(),
+	})
+	pf.NextID = 7
+	require.NoError(t, s.SaveProject(pf))
+
+	tests := []struct {
+		name     string
+		flags    func()
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "transitive",
+			flags:    func() { listBlockedBy = "tp-01" },
+			contains: []string{"TP-02", "TP-06"},
+			excludes: []string{"TP-01", "TP-03", "TP-05"},
+		},
+		{
+			name:     "direct",
+			flags:    func() { listBlockedBy = "TP-01"; listDirect = true },
+			contains: []string{"TP-02"},
+			excludes: []string{"TP-01", "TP-03", "TP-06"},
+		},
+		{
+			name:     "wait blocker",
+			flags:    func() { listBlockedBy = "TP-01W" },
+			contains: []string{"TP-03"},
+			excludes: []string{"TP-01", "TP-02", "TP-06"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetListFlags()
+			defer resetListFlags()
+			tt.flags()
+
+			old := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := runList(nil, nil)
+
+			w.Close()
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+			os.Stdout = old
+
+			require.NoError(t, err)
+			output := buf.String()
+			for _, c := range tt.contains {
+				assert.Contains(t, output, c)
+			}
+			for _, e := range tt.excludes {
+				assert.NotContains(t, output, e)
+			}
+		})
+	}
+}
+
+func TestListBlockedByErrors(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	resetListFlags()
+	defer resetListFlags()
+
+	listBlockedBy = "TP-99"
+	err := runList(nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	resetListFlags()
+	listDirect = true
+	err = runList(nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--direct requires --blocked-by")
+}
+
+func TestListWaitingOn(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// TP-06 waits on TP-01W but is also blocked by an open task, so it is not waiting
+	pf, _ := s.LoadProject("TP")
+	pf.Tasks = append(pf.Tasks, model.Task{
+		ID:        "TP-06",
+		Title:     "Also blocked",
+		Status:    model.TaskStatusOpen,
+		Priority:  3,
+		BlockedBy: []string{"TP-01", "TP-01W"},
+		Created:   time.Now(),
+		Updated:   time.Now(),
+	})
+	pf.NextID = 7
+	require.NoError(t, s.SaveProject(pf))
+
+	resetListFlags()
+	defer resetListFlags()
+	listWaitingOn = "tp-01w"
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runList(nil, nil)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	output := buf.String()
+	assert.Contains(t, output, "TP-03")
+	assert.NotContains(t, output, "TP-06")
+	assert.NotContains(t, output, "TP-01 ")
+
+	// Task IDs are rejected
+	resetListFlags()
+	listWaitingOn = "TP-01"
+	err = runList(nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not a wait ID")
+}
+
+func TestListTitleTruncation(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	longTitle := strings.Repeat("long ", 40)
+	pf, _ := s.LoadProject("TP")
+	pf.Tasks[0].Title = longTitle
+	require.NoError(t, s.SaveProject(pf))
+
+	run := func() string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runList(nil, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	resetListFlags()
+	defer resetListFlags()
+
+	t.Setenv("COLUMNS", "80")
+	for _, line := range strings.Split(strings.TrimRight(run(), "\n"), "\n") {
+		assert.LessOrEqual(t, len(line), 80)
+	}
+
+	listFull = true
+	assert.Contains(t, run(), longTitle)
+}
+
+func TestListInvalidFormat(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	resetListFlags()
+	defer resetListFlags()
+	listFormat = "fancy"
+
+	err := runList(nil, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid format")
+}
+
+func TestCaseInsensitiveIDLookup(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// Test lowercase ID
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runShow(nil, []string{"tp-01"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Ready task")
+}
+
+func TestGraphDOTFormat(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	graphProject = ""
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runGraph(nil, nil)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	assert.NoError(t, err)
+
+	// Verify DOT structure
+	assert.True(t, strings.HasPrefix(output, "digraph tk {"))
+	assert.Contains(t, output, "rankdir=LR")
+	assert.Contains(t, output, "node [shape=box]")
+	assert.True(t, strings.HasSuffix(strings.TrimSpace(output), "}"))
+
+	// Verify edges are correct direction (blocker -> blocked)
+	assert.Contains(t, output, `"TP-01" -> "TP-02"`)
+	assert.Contains(t, output, `"TP-01W" -> "TP-03"`)
+
+	// Verify wait edges are dashed
+	assert.Contains(t, output, "[style=dashed]")
+}
+
+func TestGraphWaitsOnly(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	graphProject = ""
+	graphWaitsOnly = true
+	defer func() { graphWaitsOnly = false }()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runGraph(nil, nil)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, `"TP-01W" [`)
+	assert.Contains(t, output, `"TP-02W" [`)
+	assert.Contains(t, output, `"TP-03" [`)
+	assert.Contains(t, output, `"TP-01W" -> "TP-03"`)
+
+	// Tasks not adjacent to a wait are left out, along with their edges
+	assert.NotContains(t, output, `"TP-05" [`)
+	assert.NotContains(t, output, `"TP-01" -> "TP-02"`)
+}
+
+func TestGraphStats(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	graphProject = "TP"
+	graphStats = true
+	defer func() {
+		graphProject = ""
+		graphStats = false
+	}()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runGraph(nil, nil)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	assert.Equal(t, `TP: Test Project
+  Items:         7
+  Links:         2
+  Max depth:     1
+  Roots:         5
+  Leaf blockers: 2
+  Components:    5
+`, buf.String())
+}
+
+// ============= Phase 8 Write Command Tests =============
+
+func TestAddCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	// Reset flags
+	addProject = "TP"
+	addPriority = 2
+	addTags = []string{"test"}
+	addNotes = ""
+	addAssignee = ""
+	addDueDate = ""
+	addAutoComplete = false
+	addBlockedBy = ""
+
+	// Capture output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runAdd(nil, []string{"Test task"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "TP-01")
+	assert.Contains(t, output, "Test task")
+
+	// Verify task was created
+	pf, _ := s.LoadProject("TP")
+	assert.Equal(t, 1, len(pf.Tasks))
+	assert.Equal(t, "Test task", pf.Tasks[0].Title)
+	assert.Equal(t, 2, pf.Tasks[0].Priority)
+	assert.Contains(t, pf.Tasks[0].Tags, "test")
+	assert.Equal(t, model.TaskSourceCLI, pf.Tasks[0].Source)
+}
+
+func TestAddAutoCompleteManualWaitWarning(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	add := func(title, blockedBy string) string {
+		addProject = "TP"
+		addPriority = 0
+		addTags = nil
+		addNotes = ""
+		addAssignee = ""
+		addDueDate = ""
+		addAutoComplete = true
+		addBlockedBy = blockedBy
+		defer func() { addAutoComplete = false; addBlockedBy = "" }()
+
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runAdd(nil, []string{title})
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	output := add("Milestone", "TP-01,TP-01W")
+	assert.Contains(t, output, "won't auto-complete while manual wait TP-01W is open")
+
+	// Time waits resolve on their own, so they don't warrant a warning
+	output = add("Later milestone", "TP-01,TP-02W")
+	assert.NotContains(t, output, "Warning")
+}
+
+func TestDoneCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// Reset flags
+	doneForce = false
+
+	// Capture output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runDone(nil, []string{"TP-01"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "TP-01 done")
+	assert.Contains(t, output, "Unblocked")
+	assert.Contains(t, output, "TP-02")
+
+	// Verify task status
+	pf, _ := s.LoadProject("TP")
+	var task *model.Task
+	for i := range pf.Tasks {
+		if pf.Tasks[i].ID == "TP-01" {
+			task = &pf.Tasks[i]
+			break
+		}
+	}
+	assert.Equal(t, model.TaskStatusDone, task.Status)
+	assert.NotNil(t, task.DoneAt)
+}
+
+func TestDoneExplainAndDryRun(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	doneForce = false
+	donePick = false
+	defer func() {
+		doneExplain = false
+		doneDryRun = false
+	}()
+
+	run := func() string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runDone(nil, []string{"TP-01"})
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	taskStatus := func(id string) model.TaskStatus {
+		pf, _ := s.LoadProject("TP")
+		for _, task := range pf.Tasks {
+			if task.ID == id {
+				return task.Status
+			}
+		}
+		return ""
+	}
+
+	doneDryRun = true
+	output := run()
+	assert.Contains(t, output, "Completing TP-01 will:")
+	assert.Contains(t, output, "unblock: TP-02")
+	assert.Contains(t, output, "Dry run: no changes made.")
+	assert.NotContains(t, output, "TP-01 done.")
+	assert.Equal(t, model.TaskStatusOpen, taskStatus("TP-01"))
+
+	doneDryRun = false
+	doneExplain = true
+	output = run()
+	assert.Contains(t, output, "Completing TP-01 will:")
+	assert.Contains(t, output, "TP-01 done.")
+	assert.Equal(t, model.TaskStatusDone, taskStatus("TP-01"))
+}
+
+func TestDoneResolveTimeWaits(t *testing.T) {
+	_, s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	after := time.Now().AddDate(0, 0, 14)
+	wait, err := ops.AddWait(s, "TP", ops.WaitOptions{Type: model.ResolutionTypeTime, After: &after})
+	require.NoError(t, err)
+	task, err := ops.AddTask(s, "TP", "Plant bulbs", ops.TaskOptions{BlockedBy: []string{wait.ID}})
+	require.NoError(t, err)
+
+	doneForce = false
+	donePick = false
+	doneResolveTimeWaits = true
+	defer func() { doneResolveTimeWaits = false }()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = runDone(nil, []string{task.ID})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	output := buf.String()
+	assert.Contains(t, output, "Resolved early: "+wait.ID)
+	assert.Contains(t, output, task.ID+" done.")
+
+	pf, _ := s.LoadProject("TP")
+	assert.Equal(t, model.WaitStatusDone, pf.Waits[0].Status)
+	assert.Equal(t, "resolved early by completing "+task.ID, pf.Waits[0].Resolution)
+	assert.Equal(t, model.TaskStatusDone, pf.Tasks[0].Status)
+}
+
+func TestDoneCommandPick(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	doneForce = false
+	donePick = false
+	pickInput = strings.NewReader("3\n")
+	defer func() { pickInput = os.Stdin }()
+
+	// Capture output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runDone(nil, []string{})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	require.NoError(t, err)
+	// Ready and waiting tasks are eligible; blocked ones are not
+	assert.Contains(t, output, "1) TP-01")
+	assert.Contains(t, output, "2) TP-03")
+	assert.Contains(t, output, "3) TP-05")
+	assert.NotContains(t, output, "TP-02")
+	assert.Contains(t, output, "TP-05 done")
+
+	pf, _ := s.LoadProject("TP")
+	for _, task := range pf.Tasks {
+		if task.ID == "TP-05" {
+			assert.Equal(t, model.TaskStatusDone, task.Status)
+		}
+	}
+}
+
+func TestPickWithExplicitIDError(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	dropPick = true
+	defer func() { dropPick = false }()
+
+	err := runDrop(nil, []string{"TP-01"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--pick")
+}
+
+func TestDoneCommandWithBlockers(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// Try to complete a blocked task without force
+	doneForce = false
+
+	// Capture output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runDone(nil, []string{"TP-02"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	// The command should return an error
+	assert.Error(t, err)
+	// The output should contain information about blockers
+	assert.Contains(t, output, "incomplete blockers")
+}
+
+func TestDoneCommandForce(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// Complete a blocked task with force
+	doneForce = true
+
+	// Capture output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runDone(nil, []string{"TP-02"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	assert.NoError(t, err)
+
+	// Verify task status
+	pf, _ := s.LoadProject("TP")
+	var task *model.Task
+	for i := range pf.Tasks {
+		if pf.Tasks[i].ID == "TP-02" {
+			task = &pf.Tasks[i]
+			break
+		}
+	}
+	assert.Equal(t, model.TaskStatusDone, task.Status)
+	// Blockers should be removed
+	assert.Empty(t, task.BlockedBy)
+}
+
+func TestDoneCommandKeepBlockers(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	doneForce = false
+	doneKeepBlockers = true
+	defer func() { doneKeepBlockers = false }()
+
+	err := runDone(nil, []string{"TP-02"})
+	assert.ErrorContains(t, err, "--keep-blockers only applies with --force")
+
+	doneForce = true
+	defer func() { doneForce = false }()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = runDone(nil, []string{"TP-02"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "TP-02 done.")
+
+	pf, _ := s.LoadProject("TP")
+	for _, task := range pf.Tasks {
+		if task.ID == "TP-02" {
+			assert.Equal(t, model.TaskStatusDone, task.Status)
+			assert.Equal(t, []string{"TP-01"}, task.BlockedBy)
+		}
+	}
+}
+
+// TestDoneCommandNoForceHintForDoneTask verifies that the --force hint is NOT
+// shown when trying to complete an already-done task (DF-04 fix).
+func TestDoneCommandNoForceHintForDoneTask(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// TP-04 is already done in the test data
+	doneForce = false
+
+	// Capture output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runDone(nil, []string{"TP-04"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	assert.Error(t, err)
+	assert.Contains(t, output, "not open")
+	assert.NotContains(t, output, "Use --force to remove blockers and complete anyway.")
+}
+
+// TestDoneCommandForceHintForBlockedTask verifies that the --force hint IS
+// shown when trying to complete a task with incomplete blockers.
 func TestDoneCommandForceHintForBlockedTask(t *testing.T) {
 	_, _, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
-	// TP-02 is blocked by TP-01 in the test data
-	doneForce = false
+	// TP-02 is blocked by TP-01 in the test data
+	doneForce = false
+
+	// Capture output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runDone(nil, []string{"TP-02"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	assert.Error(t, err)
+	assert.Contains(t, output, "incomplete blockers")
+	assert.Contains(t, output, "Use --force to remove blockers and complete anyway.")
+}
+
+// TestDoneCommandBatchMixedErrorsShowsHint verifies that in batch mode, the
+// --force hint is shown when at least one error is a blocker error, even if
+// other errors are about task status.
+func TestDoneCommandBatchMixedErrorsShowsHint(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// TP-04 is done, TP-02 is blocked
+	doneForce = false
+
+	// Capture output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runDone(nil, []string{"TP-04", "TP-02"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	assert.Error(t, err)
+	assert.Contains(t, output, "not open")
+	assert.Contains(t, output, "incomplete blockers")
+	assert.Contains(t, output, "Use --force to remove blockers and complete anyway.")
+}
+
+// TestDoneCommandBatchAllNonOpenNoHint verifies that in batch mode, the
+// --force hint is NOT shown when all errors are about task status (not blockers).
+func TestDoneCommandBatchAllNonOpenNoHint(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// Create a dropped task to have two non-open tasks
+	pf, _ := s.LoadProject("TP")
+	pf.Tasks = append(pf.Tasks, model.Task{
+		ID:       "TP-06",
+		Title:    "Dropped task",
+		Status:   model.TaskStatusDropped,
+		Priority: 3,
+		Created:  time.Now(),
+		Updated:  time.Now(),
+	})
+	pf.NextID = 7
+	s.SaveProject(pf)
+
+	// TP-04 is done, TP-06 is dropped
+	doneForce = false
+
+	// Capture output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runDone(nil, []string{"TP-04", "TP-06"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	assert.Error(t, err)
+	assert.Contains(t, output, "not open")
+	assert.NotContains(t, output, "Use --force to remove blockers and complete anyway.")
+}
+
+func TestDropCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// Reset flags
+	dropReason = "Not needed"
+	dropDropDeps = false
+	dropRemoveDeps = true
+
+	// Capture output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runDrop(nil, []string{"TP-01"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "TP-01 dropped")
+	assert.Contains(t, output, "Unlinked from: TP-02")
+	assert.Contains(t, output, "Now unblocked: TP-02")
+
+	// Verify task status
+	pf, _ := s.LoadProject("TP")
+	var task *model.Task
+	for i := range pf.Tasks {
+		if pf.Tasks[i].ID == "TP-01" {
+			task = &pf.Tasks[i]
+			break
+		}
+	}
+	assert.Equal(t, model.TaskStatusDropped, task.Status)
+	assert.Equal(t, "Not needed", task.DropReason)
+}
+
+func TestDropShowImpact(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	dropReason = ""
+	dropDropDeps = false
+	dropRemoveDeps = false
+	dropShowImpact = true
+	defer func() { dropShowImpact = false }()
+
+	drop := func() string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runDrop(nil, []string{"TP-01"})
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	output := drop()
+	assert.Contains(t, output, "TP-01 blocks: TP-02")
+	assert.Contains(t, output, "requires --drop-deps")
+
+	dropRemoveDeps = true
+	defer func() { dropRemoveDeps = false }()
+	output = drop()
+	assert.Contains(t, output, "Dropping TP-01 will:")
+	assert.Contains(t, output, "unblock: TP-02")
+	assert.Contains(t, output, "No changes made.")
+
+	pf, _ := s.LoadProject("TP")
+	for _, task := range pf.Tasks {
+		if task.ID == "TP-01" {
+			assert.Equal(t, model.TaskStatusOpen, task.Status)
+		}
+	}
+}
+
+func TestReopenCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// Capture output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runReopen(nil, []string{"TP-04"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "TP-04 reopened")
+
+	// Verify task status
+	pf, _ := s.LoadProject("TP")
+	var task *model.Task
+	for i := range pf.Tasks {
+		if pf.Tasks[i].ID == "TP-04" {
+			task = &pf.Tasks[i]
+			break
+		}
+	}
+	assert.Equal(t, model.TaskStatusOpen, task.Status)
+	assert.Nil(t, task.DoneAt)
+}
+
+func TestWaitResolveChain(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// TP-07W is dormant behind the manual wait TP-01W
+	pf, _ := s.LoadProject("TP")
+	pf.Waits = append(pf.Waits, model.Wait{
+		ID:        "TP-07W",
+		Status:    model.WaitStatusOpen,
+		BlockedBy: []string{"TP-01W"},
+		Created:   time.Now(),
+		ResolutionCriteria: model.ResolutionCriteria{
+			Type:     model.ResolutionTypeManual,
+			Question: "Was it installed?",
+		},
+	})
+	pf.NextID = 8
+	s.SaveProject(pf)
+
+	waitResolveChain = true
+	waitResolveResolution = "delivered and installed"
+	defer func() { waitResolveChain, waitResolveResolution = false, "" }()
 
-	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runDone(nil, []string{"TP-02"})
+	err := runWaitResolve(nil, []string{"TP-07W"})
 
 	w.Close()
 	var buf bytes.Buffer
 	buf.ReadFrom(r)
 	os.Stdout = old
 
-	output := buf.String()
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "Resolved 2 waits: TP-01W, TP-07W")
 
-	assert.Error(t, err)
-	assert.Contains(t, output, "incomplete blockers")
-	assert.Contains(t, output, "Use --force to remove blockers and complete anyway.")
+	pf, _ = s.LoadProject("TP")
+	for _, wait := range pf.Waits {
+		if wait.ID == "TP-01W" || wait.ID == "TP-07W" {
+			assert.Equal(t, model.WaitStatusDone, wait.Status, wait.ID)
+			assert.Equal(t, "delivered and installed", wait.Resolution, wait.ID)
+		}
+	}
 }
 
-// TestDoneCommandBatchMixedErrorsShowsHint verifies that in batch mode, the
-// --force hint is shown when at least one error is a blocker error, even if
-// other errors are about task status.
-func TestDoneCommandBatchMixedErrorsShowsHint(t *testing.T) {
-	_, _, cleanup := setupTestStorageWithData(t)
+func TestUncheckCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
-	// TP-04 is done, TP-02 is blocked
-	doneForce = false
+	// TP-02W's date has passed and tk check resolved it
+	pf, _ := s.LoadProject("TP")
+	doneAt := time.Now().Add(-time.Hour)
+	for i := range pf.Waits {
+		if pf.Waits[i].ID == "TP-02W" {
+			pf.Waits[i].ResolutionCriteria.After = &doneAt
+			pf.Waits[i].Status = model.WaitStatusDone
+			pf.Waits[i].DoneAt = &doneAt
+		}
+	}
+	s.SaveProject(pf)
+
+	uncheckDays = 3
+	defer func() { uncheckDays = 0 }()
 
-	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runDone(nil, []string{"TP-04", "TP-02"})
+	err := runUncheck(nil, []string{"TP-02W"})
 
 	w.Close()
 	var buf bytes.Buffer
 	buf.ReadFrom(r)
 	os.Stdout = old
 
-	output := buf.String()
+	assert.NoError(t, err)
+	want := model.EndOfDay(time.Now().AddDate(0, 0, 3))
+	assert.Contains(t, buf.String(), "TP-02W reopened, now until "+model.FormatDate(want))
 
-	assert.Error(t, err)
-	assert.Contains(t, output, "not open")
-	assert.Contains(t, output, "incomplete blockers")
-	assert.Contains(t, output, "Use --force to remove blockers and complete anyway.")
+	pf, _ = s.LoadProject("TP")
+	for _, wait := range pf.Waits {
+		if wait.ID == "TP-02W" {
+			assert.Equal(t, model.WaitStatusOpen, wait.Status)
+			assert.True(t, wait.ResolutionCriteria.After.Equal(want))
+		}
+	}
 }
 
-// TestDoneCommandBatchAllNonOpenNoHint verifies that in batch mode, the
-// --force hint is NOT shown when all errors are about task status (not blockers).
-func TestDoneCommandBatchAllNonOpenNoHint(t *testing.T) {
+func TestWaitConvertCommand(t *testing.T) {
 	_, s, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
-	// Create a dropped task to have two non-open tasks
+	defer func() { waitConvertTo, waitConvertAfter, waitConvertQuestion = "", "", "" }()
+
+	run := func(id string) (string, error) {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runWaitConvert(nil, []string{id})
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		return buf.String(), err
+	}
+
+	// The manual wait TP-01W got a firm date
+	waitConvertTo, waitConvertAfter = "time", "2026-02-01"
+	output, err := run("TP-01W")
+	require.NoError(t, err)
+	assert.Contains(t, output, "TP-01W converted to a time wait.")
+
+	// The time wait TP-02W became open-ended
+	waitConvertTo, waitConvertAfter, waitConvertQuestion = "manual", "", "Did it ship?"
+	_, err = run("TP-02W")
+	require.NoError(t, err)
+
+	waitConvertTo = "someday"
+	_, err = run("TP-02W")
+	assert.ErrorContains(t, err, "expected time or manual")
+
 	pf, _ := s.LoadProject("TP")
-	pf.Tasks = append(pf.Tasks, model.Task{
-		ID:       "TP-06",
-		Title:    "Dropped task",
-		Status:   model.TaskStatusDropped,
-		Priority: 3,
-		Created:  time.Now(),
-		Updated:  time.Now(),
-	})
-	pf.NextID = 7
-	s.SaveProject(pf)
+	for _, wait := range pf.Waits {
+		switch wait.ID {
+		case "TP-01W":
+			assert.Equal(t, model.ResolutionTypeTime, wait.ResolutionCriteria.Type)
+			assert.Equal(t, "2026-02-01", model.Day(*wait.ResolutionCriteria.After))
+			assert.Empty(t, wait.ResolutionCriteria.Question)
+		case "TP-02W":
+			assert.Equal(t, model.ResolutionTypeManual, wait.ResolutionCriteria.Type)
+			assert.Equal(t, "Did it ship?", wait.ResolutionCriteria.Question)
+			assert.Nil(t, wait.ResolutionCriteria.After)
+		}
+	}
+}
 
-	// TP-04 is done, TP-06 is dropped
-	doneForce = false
+func TestTagCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
 
 	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runDone(nil, []string{"TP-04", "TP-06"})
+	err := runTag(nil, []string{"TP-01", "newtag"})
 
 	w.Close()
 	var buf bytes.Buffer
@@ -1113,26 +2845,103 @@ func TestDoneCommandBatchAllNonOpenNoHint(t *testing.T) {
 
 	output := buf.String()
 
-	assert.Error(t, err)
-	assert.Contains(t, output, "not open")
-	assert.NotContains(t, output, "Use --force to remove blockers and complete anyway.")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Added tag")
+	assert.Contains(t, output, "newtag")
+
+	// Verify tag was added
+	pf, _ := s.LoadProject("TP")
+	var task *model.Task
+	for i := range pf.Tasks {
+		if pf.Tasks[i].ID == "TP-01" {
+			task = &pf.Tasks[i]
+			break
+		}
+	}
+	assert.Contains(t, task.Tags, "newtag")
 }
 
-func TestDropCommand(t *testing.T) {
+func TestUntagCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// Capture output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runUntag(nil, []string{"TP-01", "urgent"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Removed tag")
+	assert.Contains(t, output, "urgent")
+
+	// Verify tag was removed
+	pf, _ := s.LoadProject("TP")
+	var task *model.Task
+	for i := range pf.Tasks {
+		if pf.Tasks[i].ID == "TP-01" {
+			task = &pf.Tasks[i]
+			break
+		}
+	}
+	assert.NotContains(t, task.Tags, "urgent")
+}
+
+func TestUntagPrefixCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	tags := []string{"sprint-0901", "urgent", "Sprint-0915"}
+	require.NoError(t, ops.EditTask(s, "TP-01", ops.TaskChanges{Tags: &tags}))
+
+	untagPrefix = "sprint-"
+	defer func() { untagPrefix = "" }()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runUntag(nil, []string{"TP-01"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Removed 2 tags from TP-01: sprint-0901, sprint-0915")
+
+	pf, _ := s.LoadProject("TP")
+	for _, task := range pf.Tasks {
+		if task.ID == "TP-01" {
+			assert.Equal(t, []string{"urgent"}, task.Tags)
+		}
+	}
+
+	assert.Error(t, runUntag(nil, []string{"TP-01", "urgent"}))
+}
+
+func TestBlockCommand(t *testing.T) {
 	_, s, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
 	// Reset flags
-	dropReason = "Not needed"
-	dropDropDeps = false
-	dropRemoveDeps = true
+	blockBy = "TP-04"
 
 	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runDrop(nil, []string{"TP-01"})
+	err := runBlock(nil, []string{"TP-05"})
 
 	w.Close()
 	var buf bytes.Buffer
@@ -1142,31 +2951,33 @@ func TestDropCommand(t *testing.T) {
 	output := buf.String()
 
 	assert.NoError(t, err)
-	assert.Contains(t, output, "TP-01 dropped")
+	assert.Contains(t, output, "blocked by")
 
-	// Verify task status
+	// Verify blocker was added
 	pf, _ := s.LoadProject("TP")
 	var task *model.Task
 	for i := range pf.Tasks {
-		if pf.Tasks[i].ID == "TP-01" {
+		if pf.Tasks[i].ID == "TP-05" {
 			task = &pf.Tasks[i]
 			break
 		}
 	}
-	assert.Equal(t, model.TaskStatusDropped, task.Status)
-	assert.Equal(t, "Not needed", task.DropReason)
+	assert.Contains(t, task.BlockedBy, "TP-04")
 }
 
-func TestReopenCommand(t *testing.T) {
+func TestUnblockCommand(t *testing.T) {
 	_, s, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
+	// Reset flags
+	unblockFrom = "TP-01"
+
 	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runReopen(nil, []string{"TP-04"})
+	err := runUnblock(nil, []string{"TP-02"})
 
 	w.Close()
 	var buf bytes.Buffer
@@ -1176,23 +2987,127 @@ func TestReopenCommand(t *testing.T) {
 	output := buf.String()
 
 	assert.NoError(t, err)
-	assert.Contains(t, output, "TP-04 reopened")
+	assert.Contains(t, output, "no longer blocked")
 
-	// Verify task status
+	// Verify blocker was removed
 	pf, _ := s.LoadProject("TP")
 	var task *model.Task
 	for i := range pf.Tasks {
-		if pf.Tasks[i].ID == "TP-04" {
+		if pf.Tasks[i].ID == "TP-02" {
 			task = &pf.Tasks[i]
 			break
 		}
 	}
-	assert.Equal(t, model.TaskStatusOpen, task.Status)
-	assert.Nil(t, task.DoneAt)
+	assert.NotContains(t, task.BlockedBy, "TP-01")
+}
+
+func TestBlockedByCommand(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// Capture output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runBlockedBy(nil, []string{"TP-02"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	output := buf.String()
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "TP-01")
+}
+
+func TestDepCommands(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	_, err := ops.AddTask(s, "TP", "Top task", ops.TaskOptions{BlockedBy: []string{"TP-02", "TP-01"}})
+	require.NoError(t, err)
+
+	run := func(fn func(*cobra.Command, []string) error, args ...string) string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := fn(nil, args)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	output := run(runDepTree, "TP-06")
+	assert.Equal(t, "TP-06  [blocked]  Top task\n"+
+		"  TP-01  [ready]  Ready task\n"+
+		"  TP-02  [blocked]  Blocked task\n"+
+		"    TP-01  [ready]  Ready task (see above)\n", output)
+
+	assert.Contains(t, run(runDepTree, "TP-01"), "TP-01 has no blockers.")
+
+	assert.Equal(t, "TP-06 depends on TP-01:\n  TP-06 → TP-02 → TP-01\n", run(runDepPath, "TP-01", "TP-06"))
+	assert.Equal(t, "TP-03 and TP-05 don't depend on each other.\n", run(runDepPath, "TP-03", "TP-05"))
+}
+
+func TestCriticalPathCommand(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	criticalPathProject = "TP"
+	defer func() { criticalPathProject = "" }()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runCriticalPath(nil, nil)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	output := buf.String()
+	assert.Contains(t, output, "Critical path for TP: 2 items")
+	assert.Regexp(t, `(?s)TP-01 .*Ready task.*TP-02 .*Blocked task`, output)
+	assert.NotContains(t, output, "TP-03")
+}
+
+func TestPlanCommand(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	planProject = "TP"
+	defer func() { planProject = "" }()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runPlan(nil, nil)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	output := buf.String()
+	assert.Regexp(t, `(?s)TP-01 .*TP-02 .*TP-03 .*TP-05 `, output)
+	assert.NotContains(t, output, "TP-04")
 }
 
-func TestTagCommand(t *testing.T) {
-	_, s, cleanup := setupTestStorageWithData(t)
+func TestBlockingCommand(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
 	// Capture output
@@ -1200,7 +3115,7 @@ func TestTagCommand(t *testing.T) {
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runTag(nil, []string{"TP-01", "newtag"})
+	err := runBlocking(nil, []string{"TP-01"})
 
 	w.Close()
 	var buf bytes.Buffer
@@ -1210,31 +3125,70 @@ func TestTagCommand(t *testing.T) {
 	output := buf.String()
 
 	assert.NoError(t, err)
-	assert.Contains(t, output, "Added tag")
-	assert.Contains(t, output, "newtag")
+	assert.Contains(t, output, "TP-02")
+}
 
-	// Verify tag was added
-	pf, _ := s.LoadProject("TP")
-	var task *model.Task
-	for i := range pf.Tasks {
-		if pf.Tasks[i].ID == "TP-01" {
-			task = &pf.Tasks[i]
-			break
-		}
+func TestBlockingCommandWaits(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	require.NoError(t, ops.AddWaitBlocker(s, "TP-02W", "TP-05"))
+
+	run := func(fn func(*cobra.Command, []string) error, id string) (string, error) {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := fn(nil, []string{id})
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		return buf.String(), err
 	}
-	assert.Contains(t, task.Tags, "newtag")
+
+	// A wait lists the tasks waiting on it
+	output, err := run(runBlocking, "TP-01W")
+	require.NoError(t, err)
+	assert.Contains(t, output, "TP-03")
+	assert.NotContains(t, output, "TP-02 ")
+
+	// ...and its own blockers
+	output, err = run(runBlockedBy, "TP-02W")
+	require.NoError(t, err)
+	assert.Contains(t, output, "TP-05")
+
+	// The task side sees the wait as a dependent
+	output, err = run(runBlocking, "TP-05")
+	require.NoError(t, err)
+	assert.Contains(t, output, "TP-02W")
+
+	output, err = run(runBlockedBy, "TP-01W")
+	require.NoError(t, err)
+	assert.Contains(t, output, "TP-01W has no blockers.")
+
+	_, err = run(runBlocking, "TP-99W")
+	assert.ErrorContains(t, err, "wait TP-99W not found")
+	_, err = run(runBlockedBy, "TP-99W")
+	assert.ErrorContains(t, err, "wait TP-99W not found")
 }
 
-func TestUntagCommand(t *testing.T) {
-	_, s, cleanup := setupTestStorageWithData(t)
+func TestProjectNewCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorage(t)
 	defer cleanup()
 
+	// Reset flags
+	projectNewPrefix = "NP"
+	projectNewName = "New Project"
+	projectNewDescription = "Test description"
+
 	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runUntag(nil, []string{"TP-01", "urgent"})
+	err := runProjectNew(nil, []string{"newproject"})
 
 	w.Close()
 	var buf bytes.Buffer
@@ -1244,125 +3198,175 @@ func TestUntagCommand(t *testing.T) {
 	output := buf.String()
 
 	assert.NoError(t, err)
-	assert.Contains(t, output, "Removed tag")
-	assert.Contains(t, output, "urgent")
+	assert.Contains(t, output, "Created project")
+	assert.Contains(t, output, "NP")
 
-	// Verify tag was removed
-	pf, _ := s.LoadProject("TP")
-	var task *model.Task
-	for i := range pf.Tasks {
-		if pf.Tasks[i].ID == "TP-01" {
-			task = &pf.Tasks[i]
-			break
-		}
-	}
-	assert.NotContains(t, task.Tags, "urgent")
+	// Verify project was created
+	pf, err := s.LoadProject("NP")
+	assert.NoError(t, err)
+	assert.Equal(t, "newproject", pf.ID)
+	assert.Equal(t, "New Project", pf.Name)
 }
 
-func TestBlockCommand(t *testing.T) {
+func TestProjectCopyCommand(t *testing.T) {
 	_, s, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
-	// Reset flags
-	blockBy = "TP-04"
+	projectCopyPrefix = "TQ"
+	projectCopyName = "Copy"
+	projectCopyResetStatus = true
+	defer func() {
+		projectCopyPrefix, projectCopyName, projectCopyResetStatus = "", "", false
+	}()
 
-	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runBlock(nil, []string{"TP-05"})
+	err := runProjectCopy(nil, []string{"TP", "copy"})
 
 	w.Close()
 	var buf bytes.Buffer
 	buf.ReadFrom(r)
 	os.Stdout = old
 
-	output := buf.String()
-
 	assert.NoError(t, err)
-	assert.Contains(t, output, "blocked by")
+	assert.Contains(t, buf.String(), "Copied TP to TQ (5 tasks, 2 waits)")
 
-	// Verify blocker was added
-	pf, _ := s.LoadProject("TP")
-	var task *model.Task
-	for i := range pf.Tasks {
-		if pf.Tasks[i].ID == "TP-05" {
-			task = &pf.Tasks[i]
-			break
+	pf, err := s.LoadProject("TQ")
+	assert.NoError(t, err)
+	assert.Equal(t, "copy", pf.ID)
+	assert.Equal(t, "Copy", pf.Name)
+	for _, task := range pf.Tasks {
+		assert.Equal(t, model.TaskStatusOpen, task.Status, task.ID)
+		if task.ID == "TQ-02" {
+			assert.Equal(t, []string{"TQ-01"}, task.BlockedBy)
 		}
 	}
-	assert.Contains(t, task.BlockedBy, "TP-04")
 }
 
-func TestUnblockCommand(t *testing.T) {
+func TestProjectCompleteCommand(t *testing.T) {
 	_, s, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
-	// Reset flags
-	unblockFrom = "TP-01"
+	err := runProjectComplete(nil, []string{"TP"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "still has open items: TP-01, TP-02, TP-03, TP-05, TP-01W, TP-02W")
+
+	projectCompleteForce = true
+	defer func() { projectCompleteForce = false }()
 
-	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runUnblock(nil, []string{"TP-02"})
+	err = runProjectComplete(nil, []string{"default"})
 
 	w.Close()
 	var buf bytes.Buffer
 	buf.ReadFrom(r)
 	os.Stdout = old
 
-	output := buf.String()
-
-	assert.NoError(t, err)
-	assert.Contains(t, output, "no longer blocked")
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Dropped 6 open items")
+	assert.Contains(t, buf.String(), "Project TP marked done.")
 
-	// Verify blocker was removed
 	pf, _ := s.LoadProject("TP")
-	var task *model.Task
-	for i := range pf.Tasks {
-		if pf.Tasks[i].ID == "TP-02" {
-			task = &pf.Tasks[i]
-			break
-		}
+	assert.Equal(t, model.ProjectStatusDone, pf.Status)
+}
+
+func TestArchiveCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	run := func() string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runArchive(nil, []string{"TP"})
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		require.NoError(t, err)
+		return buf.String()
 	}
-	assert.NotContains(t, task.BlockedBy, "TP-01")
+
+	assert.Contains(t, run(), "Archived 1 task and 0 waits from TP.")
+
+	pf, err := s.LoadProject("TP")
+	require.NoError(t, err)
+	assert.Len(t, pf.Tasks, 4)
+	archive, err := s.LoadArchive("TP")
+	require.NoError(t, err)
+	require.Len(t, archive.Tasks, 1)
+	assert.Equal(t, "TP-04", archive.Tasks[0].ID)
+
+	assert.Contains(t, run(), "Nothing to archive in TP.")
+
+	// --list shows the archived items without archiving anything
+	archiveList = true
+	defer func() { archiveList = false }()
+	output := run()
+	assert.Regexp(t, `TP-04\s+done\s+`, output)
+	assert.Contains(t, output, archive.Tasks[0].Title)
+	assert.NotContains(t, output, "TP-01")
 }
 
-func TestBlockedByCommand(t *testing.T) {
-	_, _, cleanup := setupTestStorageWithData(t)
+func TestProjectNewSimilarPrefix(t *testing.T) {
+	_, s, cleanup := setupTestStorage(t)
 	defer cleanup()
 
-	// Capture output
+	projectNewName = "Near Miss"
+	projectNewDescription = ""
+	defer func() { projectNewStrict = false }()
+
+	// --strict refuses it outright
+	projectNewPrefix = "tq"
+	projectNewStrict = true
+	err := runProjectNew(nil, nil)
+	assert.ErrorContains(t, err, `prefix "TQ" is too close to existing TP`)
+	assert.False(t, s.ProjectExists("TQ"))
+
+	// Otherwise it is created with a warning
+	projectNewStrict = false
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runBlockedBy(nil, []string{"TP-02"})
+	err = runProjectNew(nil, nil)
 
 	w.Close()
 	var buf bytes.Buffer
 	buf.ReadFrom(r)
 	os.Stdout = old
 
-	output := buf.String()
-
-	assert.NoError(t, err)
-	assert.Contains(t, output, "TP-01")
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Warning: prefix TQ is one letter away from TP")
+	assert.True(t, s.ProjectExists("TQ"))
 }
 
-func TestBlockingCommand(t *testing.T) {
-	_, _, cleanup := setupTestStorageWithData(t)
+func TestProjectDeleteCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorage(t)
 	defer cleanup()
 
+	// Create a second project first
+	projectNewPrefix = "DP"
+	projectNewName = "To Delete"
+	projectNewDescription = ""
+	runProjectNew(nil, []string{"todelete"})
+
+	// Reset delete flags
+	projectDeleteForce = true
+
 	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runBlocking(nil, []string{"TP-01"})
+	err := runProjectDelete(nil, []string{"DP"})
 
 	w.Close()
 	var buf bytes.Buffer
@@ -1372,24 +3376,40 @@ func TestBlockingCommand(t *testing.T) {
 	output := buf.String()
 
 	assert.NoError(t, err)
-	assert.Contains(t, output, "TP-02")
+	assert.Contains(t, output, "Deleted")
+
+	// Verify project was deleted
+	_, err = s.LoadProject("DP")
+	assert.Error(t, err)
 }
 
-func TestProjectNewCommand(t *testing.T) {
+func TestCheckCommand(t *testing.T) {
 	_, s, cleanup := setupTestStorage(t)
 	defer cleanup()
 
-	// Reset flags
-	projectNewPrefix = "NP"
-	projectNewName = "New Project"
-	projectNewDescription = "Test description"
+	// Create a time wait that has passed
+	pf, _ := s.LoadProject("TP")
+	past := time.Now().Add(-24 * time.Hour)
+	pf.Waits = []model.Wait{
+		{
+			ID:     "TP-01W",
+			Status: model.WaitStatusOpen,
+			ResolutionCriteria: model.ResolutionCriteria{
+				Type:  model.ResolutionTypeTime,
+				After: &past,
+			},
+			Created: time.Now(),
+		},
+	}
+	pf.NextID = 2
+	s.SaveProject(pf)
 
 	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runProjectNew(nil, []string{"newproject"})
+	err := runCheck(nil, nil)
 
 	w.Close()
 	var buf bytes.Buffer
@@ -1399,35 +3419,163 @@ func TestProjectNewCommand(t *testing.T) {
 	output := buf.String()
 
 	assert.NoError(t, err)
-	assert.Contains(t, output, "Created project")
-	assert.Contains(t, output, "NP")
+	assert.Contains(t, output, "Resolved")
+	assert.Contains(t, output, "TP-01W")
 
-	// Verify project was created
-	pf, err := s.LoadProject("NP")
-	assert.NoError(t, err)
-	assert.Equal(t, "newproject", pf.ID)
-	assert.Equal(t, "New Project", pf.Name)
+	// Verify wait was resolved
+	pf, _ = s.LoadProject("TP")
+	assert.Equal(t, model.WaitStatusDone, pf.Waits[0].Status)
 }
 
-func TestProjectDeleteCommand(t *testing.T) {
+func TestFormatCheckSummary(t *testing.T) {
+	tests := []struct {
+		name   string
+		result ops.CheckResult
+		want   string
+	}{
+		{"nothing", ops.CheckResult{}, ""},
+		{
+			"everything",
+			ops.CheckResult{
+				ResolvedWaits: []string{"TP-01W", "TP-02W"},
+				Unblocked:     []string{"TP-04"},
+				AutoCompleted: []string{"TP-07"},
+			},
+			"Resolved 2 waits, unblocked TP-04, auto-completed TP-07",
+		},
+		{"one wait", ops.CheckResult{ResolvedWaits: []string{"TP-01W"}}, "Resolved 1 wait"},
+		{"unblocked only", ops.CheckResult{Unblocked: []string{"TP-04", "TP-05"}}, "Unblocked TP-04, TP-05"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatCheckSummary(&tt.result))
+		})
+	}
+}
+
+func TestCheckCommandNotify(t *testing.T) {
 	_, s, cleanup := setupTestStorage(t)
 	defer cleanup()
 
-	// Create a second project first
-	projectNewPrefix = "DP"
-	projectNewName = "To Delete"
-	projectNewDescription = ""
-	runProjectNew(nil, []string{"todelete"})
+	checkNotify = true
+	defer func() { checkNotify = false }()
 
-	// Reset delete flags
-	projectDeleteForce = true
+	run := func() string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runCheck(nil, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	// No changes, no output
+	assert.Equal(t, "", run())
+
+	past := time.Now().Add(-24 * time.Hour)
+	pf, _ := s.LoadProject("TP")
+	pf.Waits = []model.Wait{{
+		ID:     "TP-01W",
+		Status: model.WaitStatusOpen,
+		ResolutionCriteria: model.ResolutionCriteria{
+			Type:  model.ResolutionTypeTime,
+			After: &past,
+		},
+		Created: time.Now(),
+	}}
+	pf.NextID = 2
+	require.NoError(t, s.SaveProject(pf))
+
+	assert.Equal(t, "Resolved 1 wait\n", run())
+
+	checkJSON = true
+	defer func() { checkJSON = false }()
+	assert.Error(t, runCheck(nil, nil))
+}
+
+func TestCheckCommandJSON(t *testing.T) {
+	_, s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	checkJSON = true
+	defer func() { checkJSON = false }()
+
+	runCheckJSON := func() map[string]interface{} {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runCheck(nil, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		var out map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+		return out
+	}
+
+	// Nothing to resolve is still an explicit object
+	out := runCheckJSON()
+	assert.Equal(t, false, out["changed"])
+	assert.Equal(t, []interface{}{}, out["resolved_waits"])
+	assert.Equal(t, []interface{}{}, out["unblocked"])
+	assert.Equal(t, []interface{}{}, out["now_ready"])
+	assert.Equal(t, []interface{}{}, out["auto_completed"])
+
+	past := time.Now().Add(-24 * time.Hour)
+	pf, _ := s.LoadProject("TP")
+	pf.Tasks = []model.Task{{
+		ID: "TP-01", Title: "Blocked", Status: model.TaskStatusOpen, Priority: 3,
+		BlockedBy: []string{"TP-02W"}, Created: time.Now(), Updated: time.Now(),
+	}}
+	pf.Waits = []model.Wait{{
+		ID:     "TP-02W",
+		Status: model.WaitStatusOpen,
+		ResolutionCriteria: model.ResolutionCriteria{
+			Type:  model.ResolutionTypeTime,
+			After: &past,
+		},
+		Created: time.Now(),
+	}}
+	pf.NextID = 3
+	require.NoError(t, s.SaveProject(pf))
+
+	out = runCheckJSON()
+	assert.Equal(t, true, out["changed"])
+	assert.Equal(t, []interface{}{"TP-02W"}, out["resolved_waits"])
+	assert.Equal(t, []interface{}{"TP-01"}, out["unblocked"])
+	assert.Equal(t, []interface{}{"TP-01"}, out["now_ready"])
+}
+
+func TestWaitAddManualCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	// Reset flags
+	waitAddProject = "TP"
+	waitAddQuestion = "Did the package arrive?"
+	waitAddAfter = ""
+	waitAddCheckAfter = ""
+	waitAddNotes = ""
+	waitAddBlockedBy = ""
 
 	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runProjectDelete(nil, []string{"DP"})
+	err := runWaitAdd(nil, nil)
 
 	w.Close()
 	var buf bytes.Buffer
@@ -1437,40 +3585,39 @@ func TestProjectDeleteCommand(t *testing.T) {
 	output := buf.String()
 
 	assert.NoError(t, err)
-	assert.Contains(t, output, "Deleted")
+	assert.Contains(t, output, "TP-01W")
+	assert.Contains(t, output, "Did the package arrive?")
 
-	// Verify project was deleted
-	_, err = s.LoadProject("DP")
-	assert.Error(t, err)
+	// Verify wait was created
+	pf, _ := s.LoadProject("TP")
+	assert.Equal(t, 1, len(pf.Waits))
+	assert.Equal(t, model.ResolutionTypeManual, pf.Waits[0].ResolutionCriteria.Type)
 }
 
-func TestCheckCommand(t *testing.T) {
+func TestWaitAddTrackingAndLink(t *testing.T) {
 	_, s, cleanup := setupTestStorage(t)
 	defer cleanup()
 
-	// Create a time wait that has passed
-	pf, _ := s.LoadProject("TP")
-	past := time.Now().Add(-24 * time.Hour)
-	pf.Waits = []model.Wait{
-		{
-			ID:     "TP-01W",
-			Status: model.WaitStatusOpen,
-			ResolutionCriteria: model.ResolutionCriteria{
-				Type:  model.ResolutionTypeTime,
-				After: &past,
-			},
-			Created: time.Now(),
-		},
-	}
-	pf.NextID = 2
-	s.SaveProject(pf)
+	waitAddProject = "TP"
+	waitAddQuestion = "Did the parts arrive?"
+	waitAddAfter = ""
+	waitAddCheckAfter = ""
+	waitAddNotes = ""
+	waitAddBlockedBy = ""
+	waitAddTracking = "1Z999AA10123456784"
+	waitAddLink = "https://example.com/track/1Z999AA10123456784"
+	defer func() {
+		waitAddTracking = ""
+		waitAddLink = ""
+	}()
 
-	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runCheck(nil, nil)
+	err := runWaitAdd(nil, nil)
+	require.NoError(t, err)
+	err = runShow(nil, []string{"TP-01W"})
 
 	w.Close()
 	var buf bytes.Buffer
@@ -1480,48 +3627,60 @@ func TestCheckCommand(t *testing.T) {
 	output := buf.String()
 
 	assert.NoError(t, err)
-	assert.Contains(t, output, "Resolved")
-	assert.Contains(t, output, "TP-01W")
+	assert.Contains(t, output, "Tracking:    1Z999AA10123456784")
+	assert.Contains(t, output, "Link:")
+	assert.Contains(t, output, "https://example.com/track/1Z999AA10123456784")
 
-	// Verify wait was resolved
-	pf, _ = s.LoadProject("TP")
-	assert.Equal(t, model.WaitStatusDone, pf.Waits[0].Status)
+	pf, _ := s.LoadProject("TP")
+	require.Len(t, pf.Waits, 1)
+	assert.Equal(t, "1Z999AA10123456784", pf.Waits[0].Tracking)
+	assert.Equal(t, "https://example.com/track/1Z999AA10123456784", pf.Waits[0].Link)
 }
 
-func TestWaitAddManualCommand(t *testing.T) {
-	_, s, cleanup := setupTestStorage(t)
+func TestWaitAddDependsOnWait(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
 
-	// Reset flags
 	waitAddProject = "TP"
-	waitAddQuestion = "Did the package arrive?"
+	waitAddQuestion = "Did the install get scheduled?"
 	waitAddAfter = ""
 	waitAddCheckAfter = ""
 	waitAddNotes = ""
 	waitAddBlockedBy = ""
+	waitAddDependsOnWait = "TP-01"
+	defer func() {
+		waitAddDependsOnWait = ""
+		showDepsOnly = false
+	}()
+
+	err := runWaitAdd(nil, nil)
+	assert.ErrorContains(t, err, "--depends-on-wait takes wait IDs, got TP-01")
+
+	waitAddDependsOnWait = "tp-01w"
 
-	// Capture output
 	old := os.Stdout
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
-	err := runWaitAdd(nil, nil)
+	err = runWaitAdd(nil, nil)
+	require.NoError(t, err)
+	pf, _ := s.LoadProject("TP")
+	id := pf.Waits[len(pf.Waits)-1].ID
+	err = runShow(nil, []string{id})
+	require.NoError(t, err)
+	showDepsOnly = true
+	err = runShow(nil, []string{id})
 
 	w.Close()
 	var buf bytes.Buffer
 	buf.ReadFrom(r)
 	os.Stdout = old
 
+	require.NoError(t, err)
 	output := buf.String()
-
-	assert.NoError(t, err)
-	assert.Contains(t, output, "TP-01W")
-	assert.Contains(t, output, "Did the package arrive?")
-
-	// Verify wait was created
-	pf, _ := s.LoadProject("TP")
-	assert.Equal(t, 1, len(pf.Waits))
-	assert.Equal(t, model.ResolutionTypeManual, pf.Waits[0].ResolutionCriteria.Type)
+	assert.Equal(t, []string{"TP-01W"}, pf.Waits[len(pf.Waits)-1].BlockedBy)
+	assert.Contains(t, output, "Status:      open (dormant until TP-01W resolves)")
+	assert.Contains(t, output, "State: dormant until TP-01W resolves")
 }
 
 func TestWaitAddTimeCommand(t *testing.T) {
@@ -1684,6 +3843,57 @@ func TestDeferCommand(t *testing.T) {
 	assert.True(t, found, "expected task to be blocked by a wait")
 }
 
+func TestDeferSoftCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	deferDays = 7
+	deferUntil = ""
+	deferSoft = true
+	defer func() { deferSoft = false }()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runDefer(nil, []string{"TP-05"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "TP-05 snoozed until")
+
+	// No wait is created and the task keeps its blockers
+	pf, _ := s.LoadProject("TP")
+	assert.Len(t, pf.Waits, 2)
+	var task *model.Task
+	for i := range pf.Tasks {
+		if pf.Tasks[i].ID == "TP-05" {
+			task = &pf.Tasks[i]
+		}
+	}
+	require.NotNil(t, task.SnoozedUntil)
+	assert.Empty(t, task.BlockedBy)
+
+	// Hidden from list and ready, shown with --snoozed
+	results, err := ops.ListTasks(s, ops.TaskFilter{HideSnoozed: true})
+	require.NoError(t, err)
+	for _, r := range results {
+		assert.NotEqual(t, "TP-05", r.Task.ID)
+	}
+	// Other callers, such as dump and export, still see it
+	results, err = ops.ListTasks(s, ops.TaskFilter{})
+	require.NoError(t, err)
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.Task.ID)
+	}
+	assert.Contains(t, ids, "TP-05")
+}
+
 func TestDumpCommand(t *testing.T) {
 	_, _, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
@@ -1710,6 +3920,226 @@ func TestDumpCommand(t *testing.T) {
 	assert.Contains(t, output, "### TP-01W")
 }
 
+func TestDumpHeadingOffsetAndDropped(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+	defer func() {
+		dumpHeadingOffset = 0
+		dumpNoDropped = false
+	}()
+
+	pf, _ := s.LoadProject("TP")
+	pf.Tasks[4].Status = model.TaskStatusDropped
+	require.NoError(t, s.SaveProject(pf))
+
+	dump := func() string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runDump(nil, []string{"TP"})
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	// Dropped items are shown by default
+	out := dump()
+	assert.Contains(t, out, "### TP-01: ")
+	assert.Contains(t, out, "### TP-05: ")
+
+	dumpHeadingOffset = 1
+	dumpNoDropped = true
+	out = dump()
+	assert.Contains(t, out, "## TP: Test Project\n")
+	assert.Contains(t, out, "### Tasks\n")
+	assert.Contains(t, out, "#### TP-01: ")
+	assert.NotContains(t, out, "TP-05")
+	assert.NotContains(t, out, "\n# ")
+
+	dumpHeadingOffset = 4
+	assert.ErrorContains(t, runDump(nil, []string{"TP"}), "--heading-offset must be between 0 and 3")
+}
+
+func TestDateFormatAcrossCommands(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+	defer model.SetDateFormat("")
+
+	due := time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2026, time.January, 2, 10, 30, 0, 0, time.Local)
+	after := time.Date(2030, time.April, 1, 9, 0, 0, 0, time.Local)
+
+	pf, _ := s.LoadProject("TP")
+	pf.Tasks = append(pf.Tasks, model.Task{
+		ID: "TP-06", Title: "Dated task", Status: model.TaskStatusOpen, Priority: 3,
+		DueDate: &due, Created: created, Updated: created,
+	})
+	pf.Waits = append(pf.Waits, model.Wait{
+		ID:     "TP-07W",
+		Status: model.WaitStatusOpen,
+		ResolutionCriteria: model.ResolutionCriteria{
+			Type:  model.ResolutionTypeTime,
+			After: &after,
+		},
+		Created: created,
+	})
+	pf.NextID = 8
+	require.NoError(t, s.SaveProject(pf))
+
+	capture := func(run func() error) string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := run()
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		return buf.String()
+	}
+	show := func() string { return capture(func() error { return runShow(nil, []string{"TP-06"}) }) }
+	showWait := func() string { return capture(func() error { return runShow(nil, []string{"TP-07W"}) }) }
+	dump := func() string { return capture(func() error { return runDump(nil, []string{"TP"}) }) }
+	waits := func() string {
+		resetWaitsFlags()
+		return capture(func() error { return runWaits(nil, nil) })
+	}
+
+	t.Run("default format", func(t *testing.T) {
+		require.NoError(t, model.SetDateFormat(""))
+
+		out := show()
+		assert.Contains(t, out, "Due:           2026-03-05")
+		assert.Contains(t, out, "Created:       "+created.Format(time.RFC3339))
+
+		out = showWait()
+		assert.Contains(t, out, "After:       "+after.Format(time.RFC3339))
+
+		out = dump()
+		assert.Contains(t, out, "Due: 2026-03-05")
+		assert.Contains(t, out, "Created: "+created.Format(time.RFC3339))
+
+		assert.Contains(t, waits(), "Until 2030-04-01")
+	})
+
+	t.Run("configured format", func(t *testing.T) {
+		require.NoError(t, model.SetDateFormat("Jan 2, 2006"))
+
+		out := show()
+		assert.Contains(t, out, "Due:           Mar 5, 2026")
+		assert.Contains(t, out, "Created:       Jan 2, 2026 10:30:00 "+created.Format("MST"))
+
+		out = showWait()
+		assert.Contains(t, out, "After:       Apr 1, 2030 09:00:00 "+after.Format("MST"))
+
+		// dump is machine-readable and keeps full timestamps
+		out = dump()
+		assert.Contains(t, out, "Due: 2026-03-05")
+		assert.Contains(t, out, "Created: "+created.Format(time.RFC3339))
+
+		assert.Contains(t, waits(), "Until Apr 1, 2030")
+	})
+}
+
+func TestBadDateFormatWarns(t *testing.T) {
+	dir, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+	defer model.SetDateFormat("")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".tkconfig.yaml"), []byte("date_format: YYYY-MM-DD\n"), 0644))
+
+	old := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+	err := applyConfig(nil, nil)
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stderr = old
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "warning: invalid date_format")
+	assert.Equal(t, "2026-03-05", model.FormatDate(time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestDroppedUnblocksConfig(t *testing.T) {
+	dir, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// TP-02 is blocked by TP-01; drop TP-01 without unlinking it
+	pf, _ := s.LoadProject("TP")
+	pf.Tasks[0].Status = model.TaskStatusDropped
+	require.NoError(t, s.SaveProject(pf))
+
+	ready := func() string {
+		resetListFlags()
+		defer resetListFlags()
+		listReady = true
+
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runList(nil, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	assert.Contains(t, ready(), "TP-02")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".tkconfig.yaml"), []byte("dropped_unblocks: false\n"), 0644))
+	assert.NotContains(t, ready(), "TP-02")
+}
+
+func TestPriorityLabelsConfig(t *testing.T) {
+	dir, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+	defer model.SetPriorityLabels(nil)
+
+	config := "priority_labels:\n  1: critical\n  2: high\n  3: normal\n  4: low\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".tkconfig.yaml"), []byte(config), 0644))
+	require.NoError(t, applyConfig(nil, nil))
+
+	resetListFlags()
+	defer resetListFlags()
+	listFormat = "oneline"
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runList(nil, nil)
+	require.NoError(t, err)
+	err = runShow(nil, []string{"TP-01"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	output := buf.String()
+	assert.Contains(t, output, "TP-01 critical ")
+	assert.Contains(t, output, "TP-05 low ")
+	assert.Contains(t, output, "Priority:      1 (critical)")
+	assert.NotContains(t, output, "P1")
+}
+
 func TestBatchDoneCommand(t *testing.T) {
 	_, s, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
@@ -2093,6 +4523,82 @@ func TestAddCommandInvalidPriority(t *testing.T) {
 	}
 }
 
+func TestEditAddBlockedBySorted(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	editAddBlockedBy = []string{"TP-04", "TP-01W", "TP-01"}
+	editRemoveBlockedBy = nil
+	defer func() { editAddBlockedBy = nil }()
+
+	// Repeated runs must produce the same order
+	for i := 0; i < 5; i++ {
+		var changes ops.TaskChanges
+		hasChanges := false
+		require.NoError(t, handleBlockerChanges(s, "TP-05", &changes, &cobra.Command{}, &hasChanges))
+		require.NotNil(t, changes.BlockedBy)
+		assert.Equal(t, []string{"TP-01", "TP-01W", "TP-04"}, *changes.BlockedBy)
+	}
+}
+
+func TestAllowInactiveChangesInactiveProject(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+	defer func() {
+		allowInactive = false
+		doneForce = false
+	}()
+
+	pf, _ := s.LoadProject("TP")
+	pf.Status = model.ProjectStatusPaused
+	require.NoError(t, s.SaveProject(pf))
+
+	old := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+	defer func() {
+		w.Close()
+		os.Stdout = old
+	}()
+
+	allowInactive = false
+	err := runNote(nil, []string{"TP-01", "blocked", "by", "pause"})
+	var statusErr *ops.ProjectStatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, string(model.ProjectStatusPaused), statusErr.Status)
+
+	allowInactive = true
+	require.NoError(t, runNote(nil, []string{"TP-01", "allowed", "with", "flag"}))
+
+	// done's --force only removes blockers; it doesn't lift the restriction
+	allowInactive = false
+	doneForce = true
+	require.Error(t, runDone(nil, []string{"TP-05"}))
+	doneForce = false
+	allowInactive = true
+	require.NoError(t, runDone(nil, []string{"TP-05"}))
+
+	pf, _ = s.LoadProject("TP")
+	for _, task := range pf.Tasks {
+		switch task.ID {
+		case "TP-01":
+			assert.Contains(t, task.Notes, "allowed with flag")
+			assert.NotContains(t, task.Notes, "blocked by pause")
+		case "TP-05":
+			assert.Equal(t, model.TaskStatusDone, task.Status)
+		}
+	}
+}
+
+func TestAllowInactiveFlagOnlyOnChangingCommands(t *testing.T) {
+	assert.Nil(t, rootCmd.PersistentFlags().Lookup("force"))
+	assert.Nil(t, showCmd.Flags().Lookup("allow-inactive"))
+	assert.Nil(t, listCmd.Flags().Lookup("allow-inactive"))
+	assert.NotNil(t, doneCmd.Flags().Lookup("allow-inactive"))
+	assert.NotNil(t, waitResolveCmd.Flags().Lookup("allow-inactive"))
+	assert.Equal(t, "remove incomplete blockers and complete", doneCmd.Flags().Lookup("force").Usage)
+}
+
 func TestEditCommandInvalidPriority(t *testing.T) {
 	_, _, cleanup := setupTestStorageWithData(t)
 	defer cleanup()
@@ -2144,6 +4650,116 @@ func TestEditCommandInvalidPriority(t *testing.T) {
 	}
 }
 
+func TestEditInteractiveMultiple(t *testing.T) {
+	dir, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	editInteractive = true
+	defer func() { editInteractive = false }()
+
+	// useEditor points TK_EDITOR at a script applying the given sed edits
+	useEditor := func(sedArgs string) {
+		script := filepath.Join(dir, "editor.sh")
+		require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nsed -i "+sedArgs+" \"$1\"\n"), 0755))
+		t.Setenv("TK_EDITOR", script)
+	}
+	edit := func(ids ...string) (string, error) {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runEdit(&cobra.Command{}, ids)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		return buf.String(), err
+	}
+	load := func(id string) model.Task {
+		pf, err := s.LoadProject("TP")
+		require.NoError(t, err)
+		for _, task := range pf.Tasks {
+			if task.ID == id {
+				return task
+			}
+		}
+		t.Fatalf("task %s not found", id)
+		return model.Task{}
+	}
+
+	// Each entry is applied to its own task; untouched entries are skipped
+	useEditor(`-e 's/title: Ready task/title: Renamed/' -e 's/priority: 3/priority: 1/'`)
+	output, err := edit("TP-01", "TP-03", "TP-05")
+	require.NoError(t, err)
+	assert.Equal(t, "TP-01 updated.\nTP-03 updated.\n", output)
+	assert.Equal(t, "Renamed", load("TP-01").Title)
+	assert.Equal(t, 1, load("TP-03").Priority)
+	assert.Equal(t, 4, load("TP-05").Priority)
+
+	// An invalid entry aborts the whole edit
+	useEditor(`-e 's/title: Renamed/title: Again/' -e 's/priority: 4/priority: 9/'`)
+	_, err = edit("TP-01", "TP-05")
+	assert.ErrorContains(t, err, "TP-05: invalid priority")
+	assert.Equal(t, "Renamed", load("TP-01").Title)
+
+	// Ids cannot be changed
+	useEditor(`-e 's/id: TP-05/id: TP-02/'`)
+	_, err = edit("TP-01", "TP-05")
+	assert.ErrorContains(t, err, `entry with id "TP-02" does not match any task being edited`)
+
+	// Several IDs without -i are rejected
+	editInteractive = false
+	_, err = edit("TP-01", "TP-05")
+	assert.ErrorContains(t, err, "requires -i")
+}
+
+func TestValidateSuggestCycleBreak(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	validateSuggestCycleBreak = true
+	defer func() {
+		validateSuggestCycleBreak = false
+		pickInput = os.Stdin
+	}()
+
+	// Hand-edit a cycle: TP-01 is now blocked by its own dependent TP-02
+	pf, err := s.LoadProject("TP")
+	require.NoError(t, err)
+	pf.Tasks[0].BlockedBy = []string{"TP-02"}
+	pf.Tasks[0].Updated = time.Now()
+	require.NoError(t, s.SaveProject(pf))
+
+	run := func(answer string) (string, error) {
+		pickInput = strings.NewReader(answer)
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runValidate(nil, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		return buf.String(), err
+	}
+
+	// Declining leaves the cycle in place
+	output, err := run("n\n")
+	assert.ErrorContains(t, err, "1 cycle left in place")
+	assert.Contains(t, output, "Suggest removing: TP-01 blocked by TP-02")
+
+	output, err = run("y\n")
+	require.NoError(t, err)
+	assert.Contains(t, output, "TP-01 is no longer blocked by TP-02.")
+
+	output, err = run("")
+	require.NoError(t, err)
+	assert.Contains(t, output, "No dependency cycles found.")
+}
+
 func TestValidateCommandDetectsInvalidPriority(t *testing.T) {
 	_, s, cleanup := setupTestStorage(t)
 	defer cleanup()
@@ -2178,7 +4794,55 @@ func TestValidateCommandDetectsInvalidPriority(t *testing.T) {
 	assert.True(t, found, "expected invalid_priority error to be detected")
 }
 
-// ============= DF-05: Conflicting List Filter Tests =============
+// ============= DF-05: Conflicting List Filter Tests =============
+
+func TestListCreatedFilters(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	resetListFlags()
+	defer resetListFlags()
+
+	// Fixture tasks are all created now
+	listCreatedToday = true
+	listFormat = "oneline"
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runList(nil, nil)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "TP-01")
+
+	resetListFlags()
+	listCreatedBefore = "2020-01-01"
+	listFormat = "oneline"
+
+	r, w, _ = os.Pipe()
+	os.Stdout = w
+
+	err = runList(nil, nil)
+
+	w.Close()
+	buf.Reset()
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	assert.NoError(t, err)
+	assert.Empty(t, strings.TrimSpace(buf.String()))
+
+	resetListFlags()
+	listCreatedAfter = "01/02/2026"
+	err = runList(nil, nil)
+	assert.ErrorContains(t, err, "invalid created-after date")
+}
 
 func resetListFlags() {
 	listProject = ""
@@ -2194,7 +4858,27 @@ func resetListFlags() {
 	listP3 = false
 	listP4 = false
 	listTags = nil
+	listAssignee = ""
 	listOverdue = false
+	listFormat = "table"
+	listBlockedBy = ""
+	listDirect = false
+	listWaitingOn = ""
+	listFull = false
+	listSnoozed = false
+	listNoWaiting = false
+	listNoBlocked = false
+	listCreatedAfter = ""
+	listCreatedBefore = ""
+	listCreatedToday = false
+	listMinPriority = 0
+	listMaxPriority = 0
+	listJSON = false
+	listSort = ""
+	listReverse = false
+	readyLimit = 0
+	readySort = ""
+	readyByProject = false
 }
 
 func resetWaitsFlags() {
@@ -2696,3 +5380,561 @@ func TestNoteCommandCaseInsensitiveID(t *testing.T) {
 	}
 	assert.Equal(t, "lowercase id note", task.Notes)
 }
+
+func TestExportDOT(t *testing.T) {
+	dir, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	require.NoError(t, ops.CreateProject(s, "house", "HM", "House", ""))
+	_, err := ops.AddTask(s, "HM", "Move in", ops.TaskOptions{})
+	require.NoError(t, err)
+
+	out := filepath.Join(dir, "system.dot")
+	exportFormat = "dot"
+	exportOutput = out
+	defer func() {
+		exportFormat = "yaml"
+		exportOutput = ""
+		exportAll = false
+	}()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = runExport(nil, nil)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Exported 2 projects to")
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	dot := string(data)
+	assert.True(t, strings.HasPrefix(dot, "digraph tk {"))
+	assert.Contains(t, dot, "subgraph cluster_HM {")
+	assert.Contains(t, dot, `label="HM: House";`)
+	assert.Contains(t, dot, "subgraph cluster_TP {")
+	assert.Contains(t, dot, `    "TP-01" [`)
+	assert.Contains(t, dot, `    "HM-01" [`)
+	assert.Contains(t, dot, `  "TP-01" -> "TP-02";`)
+
+	// --all conflicts with a project, and bundles hold a single project
+	exportAll = true
+	assert.Error(t, runExport(nil, []string{"TP"}))
+	exportFormat = "yaml"
+	assert.ErrorContains(t, runExport(nil, nil), "--format=dot")
+}
+
+func TestExportCSV(t *testing.T) {
+	dir, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	require.NoError(t, ops.CreateProject(s, "house", "HM", "House", ""))
+	_, err := ops.AddTask(s, "HM", "Paint, then hang pictures", ops.TaskOptions{Tags: []string{"weekend", "paint"}})
+	require.NoError(t, err)
+
+	out := filepath.Join(dir, "tasks.csv")
+	exportFormat = "csv"
+	exportProject = "HM"
+	exportOutput = out
+	defer func() {
+		exportFormat = "yaml"
+		exportOutput = ""
+		exportProject = ""
+	}()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = runExport(nil, nil)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Exported 1 task to")
+
+	f, err := os.Open(out)
+	require.NoError(t, err)
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{"id", "project", "title", "status", "state", "priority", "assignee", "due_date", "tags", "created", "updated"}, rows[0])
+	assert.Equal(t, []string{"HM-01", "HM", "Paint, then hang pictures", "open", "ready", "3", ""}, rows[1][:7])
+	assert.Equal(t, "weekend;paint", rows[1][8])
+
+	// Without a project, every task is exported, done and dropped included
+	exportProject = ""
+	require.NoError(t, runExport(nil, nil))
+	f2, err := os.Open(out)
+	require.NoError(t, err)
+	defer f2.Close()
+	rows, err = csv.NewReader(f2).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, rows, 7)
+
+	exportProject = "HM"
+	assert.ErrorContains(t, runExport(nil, []string{"TP"}), "not both")
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	dir, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	bundle := filepath.Join(dir, "tp.json")
+	exportPortable = true
+	exportFormat = "json"
+	exportOutput = bundle
+	defer func() {
+		exportPortable = false
+		exportFormat = "yaml"
+		exportOutput = ""
+	}()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runExport(nil, []string{"TP"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Exported TP to")
+
+	data, err := os.ReadFile(bundle)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"prefix": "TP"`)
+
+	// Importing over the existing project is refused
+	assert.Error(t, runImport(nil, []string{bundle}))
+
+	require.NoError(t, s.DeleteProject("TP"))
+	require.NoError(t, runImport(nil, []string{bundle}))
+
+	pf, err := s.LoadProject("TP")
+	require.NoError(t, err)
+	assert.Len(t, pf.Tasks, 5)
+	assert.Len(t, pf.Waits, 2)
+	assert.Equal(t, 6, pf.NextID)
+}
+
+func TestListPriorityRange(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	resetListFlags()
+	defer resetListFlags()
+	listFormat = "oneline"
+
+	run := func() (string, error) {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runList(nil, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		return buf.String(), err
+	}
+
+	// Open tasks are TP-01 (P1), TP-02 (P2), TP-03 (P3), TP-05 (P4)
+	listMaxPriority = 2
+	output, err := run()
+	require.NoError(t, err)
+	assert.Contains(t, output, "TP-01 ")
+	assert.Contains(t, output, "TP-02 ")
+	assert.NotContains(t, output, "TP-03 ")
+
+	listMinPriority = 2
+	listMaxPriority = 3
+	output, err = run()
+	require.NoError(t, err)
+	assert.NotContains(t, output, "TP-01 ")
+	assert.Contains(t, output, "TP-02 ")
+	assert.Contains(t, output, "TP-03 ")
+	assert.NotContains(t, output, "TP-05 ")
+
+	listMinPriority = 3
+	listMaxPriority = 2
+	_, err = run()
+	assert.ErrorContains(t, err, "--min-priority 3 is greater than --max-priority 2")
+
+	listMinPriority = 0
+	listMaxPriority = 5
+	_, err = run()
+	assert.ErrorContains(t, err, "between 1 and 4")
+
+	listMaxPriority = 2
+	listP1 = true
+	_, err = run()
+	assert.ErrorContains(t, err, "cannot combine --priority")
+}
+
+func TestReadyLimit(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	resetListFlags()
+	defer resetListFlags()
+
+	due := time.Date(2030, 1, 15, 0, 0, 0, 0, time.Local)
+	_, err := ops.AddTask(s, "TP", "Urgent with due date", ops.TaskOptions{Priority: 1, DueDate: &due})
+	require.NoError(t, err)
+	_, err = ops.AddTask(s, "TP", "Urgent without due date", ops.TaskOptions{Priority: 1})
+	require.NoError(t, err)
+
+	readyLimit = 3
+	listFormat = "oneline"
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = runReady(nil, nil)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	// Priority 1 first; a due date beats none; then the older task wins
+	assert.True(t, strings.HasPrefix(lines[0], "TP-06 "), lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "TP-01 "), lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "TP-07 "), lines[2])
+}
+
+func TestReadyByProject(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	resetListFlags()
+	defer resetListFlags()
+
+	err := ops.CreateProject(s, "alpha", "AL", "Alpha", "")
+	require.NoError(t, err)
+	for _, title := range []string{"First alpha", "Second alpha", "Third alpha"} {
+		_, err = ops.AddTask(s, "AL", title, ops.TaskOptions{})
+		require.NoError(t, err)
+	}
+
+	readyByProject = true
+	readyLimit = 2
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = runReady(nil, nil)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	output := buf.String()
+	assert.Contains(t, output, "AL: Alpha (3 ready)")
+	assert.Contains(t, output, "TP: Test Project (")
+	assert.Less(t, strings.Index(output, "AL: Alpha"), strings.Index(output, "TP: Test Project"))
+	// --limit caps each project rather than the whole list
+	assert.Contains(t, output, "AL-02")
+	assert.NotContains(t, output, "AL-03")
+	assert.Contains(t, output, "TP-01")
+	assert.NotContains(t, output, "TP-02")
+}
+
+func TestReadyIncludesSoonConfig(t *testing.T) {
+	dir, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	resetListFlags()
+	defer resetListFlags()
+	listFormat = "oneline"
+
+	// TP-03 waits only on TP-01W; make it a time wait due tomorrow
+	pf, _ := s.LoadProject("TP")
+	tomorrow := time.Now().Add(24 * time.Hour)
+	for i := range pf.Waits {
+		if pf.Waits[i].ID == "TP-01W" {
+			pf.Waits[i].ResolutionCriteria = model.ResolutionCriteria{Type: model.ResolutionTypeTime, After: &tomorrow}
+		}
+	}
+	require.NoError(t, s.SaveProject(pf))
+
+	ready := func() string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runReady(nil, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	assert.NotContains(t, ready(), "TP-03")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".tkconfig.yaml"), []byte("ready_includes_soon: 2\n"), 0644))
+	assert.Contains(t, ready(), "TP-03")
+}
+
+func TestReadySortScore(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	resetListFlags()
+	defer resetListFlags()
+	listFormat = "oneline"
+
+	// Of two P4 tasks, the one that unblocks two others ranks higher
+	_, err := ops.AddTask(s, "TP", "Foundation", ops.TaskOptions{Priority: 4})
+	require.NoError(t, err)
+	_, err = ops.AddTask(s, "TP", "Walls", ops.TaskOptions{Priority: 2, BlockedBy: []string{"TP-06"}})
+	require.NoError(t, err)
+	_, err = ops.AddTask(s, "TP", "Roof", ops.TaskOptions{Priority: 2, BlockedBy: []string{"TP-06"}})
+	require.NoError(t, err)
+	_, err = ops.AddTask(s, "TP", "Leaf", ops.TaskOptions{Priority: 4})
+	require.NoError(t, err)
+
+	readySort = "score"
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = runReady(nil, nil)
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "TP-01 "), lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "TP-06 "), lines[1])
+	// TP-05 and TP-09 are both P4 leaves; the older one comes first
+	assert.True(t, strings.HasPrefix(lines[2], "TP-05 "), lines[2])
+
+	readySort = "bogus"
+	assert.Error(t, runReady(nil, nil))
+}
+
+func TestRecentCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	// TP-04 was completed two hours ago; TP-05 just now
+	pf, err := s.LoadProject("TP")
+	require.NoError(t, err)
+	earlier := time.Now().Add(-2 * time.Hour)
+	for i := range pf.Tasks {
+		if pf.Tasks[i].ID == "TP-04" {
+			pf.Tasks[i].DoneAt = &earlier
+		}
+	}
+	require.NoError(t, s.SaveProject(pf))
+	_, err = ops.CompleteTask(s, "TP-05", ops.CompleteOptions{})
+	require.NoError(t, err)
+
+	recent := func() string {
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runRecent(nil, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+
+		require.NoError(t, err)
+		return buf.String()
+	}
+	defer func() {
+		recentSince = "1h"
+		recentReopen = false
+	}()
+
+	recentSince = "1h"
+	output := recent()
+	assert.Contains(t, output, "TP-05")
+	assert.NotContains(t, output, "TP-04")
+
+	recentSince = "3h"
+	assert.Regexp(t, `TP-05 [^\n]*\n[^\n]*TP-04 `, recent())
+
+	// Minutes parse as a Go duration; days fall back to the shared parser
+	recentSince = "30m"
+	output = recent()
+	assert.Contains(t, output, "TP-05")
+	assert.NotContains(t, output, "TP-04")
+	recentSince = "1d"
+	assert.Regexp(t, `TP-05 [^\n]*\n[^\n]*TP-04 `, recent())
+
+	recentSince = "0d"
+	assert.ErrorContains(t, runRecent(nil, nil), "--since must be positive")
+	recentSince = "3"
+	assert.ErrorContains(t, runRecent(nil, nil), "invalid duration")
+	recentSince = "3h"
+
+	// --reopen undoes the most recent completion only
+	recentReopen = true
+	assert.Contains(t, recent(), "TP-05 reopened.")
+
+	pf, err = s.LoadProject("TP")
+	require.NoError(t, err)
+	for _, task := range pf.Tasks {
+		switch task.ID {
+		case "TP-05":
+			assert.Equal(t, model.TaskStatusOpen, task.Status)
+		case "TP-04":
+			assert.Equal(t, model.TaskStatusDone, task.Status)
+		}
+	}
+}
+
+func TestHistoryCommand(t *testing.T) {
+	_, _, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runHistory(nil, []string{"TP-04"})
+
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	require.NoError(t, err)
+	output := buf.String()
+	assert.True(t, strings.HasPrefix(output, "TP-04: "))
+	assert.Contains(t, output, "created")
+	assert.Contains(t, output, "done")
+	assert.Less(t, strings.Index(output, "created"), strings.Index(output, "done"))
+
+	err = runHistory(nil, []string{"TP-99"})
+	assert.Error(t, err)
+}
+
+func TestLintCommand(t *testing.T) {
+	_, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	_, err := ops.AddTask(s, "TP", "Ready task!", ops.TaskOptions{})
+	require.NoError(t, err)
+
+	// TP-02 hasn't been touched in months
+	pf, err := s.LoadProject("TP")
+	require.NoError(t, err)
+	pf.Tasks[1].Updated = time.Now().AddDate(0, 0, -(ops.StaleTaskDays + 10))
+	require.NoError(t, s.SaveProject(pf))
+
+	lint := func(dupes, stale bool) string {
+		lintDupes = dupes
+		lintStale = stale
+		defer func() { lintDupes = false; lintStale = false }()
+
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runLint(nil, nil)
+
+		w.Close()
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		os.Stdout = old
+		require.NoError(t, err)
+		return buf.String()
+	}
+
+	const dupesHeader = "Found 1 group of likely duplicates:"
+	const staleHeader = "Found 1 stale task not updated in 90 days:"
+
+	// Without a check flag, every check runs
+	output := lint(false, false)
+	assert.Contains(t, output, dupesHeader)
+	assert.Contains(t, output, "suggest: tk merge TP-01 TP-06")
+	assert.Contains(t, output, staleHeader)
+	assert.Regexp(t, `TP-02 .*last updated`, output)
+
+	// A check flag runs only that check
+	output = lint(true, false)
+	assert.Contains(t, output, dupesHeader)
+	assert.NotContains(t, output, staleHeader)
+
+	output = lint(false, true)
+	assert.Contains(t, output, staleHeader)
+	assert.NotContains(t, output, dupesHeader)
+}
+
+func TestStrictLoadConfig(t *testing.T) {
+	dir, s, cleanup := setupTestStorageWithData(t)
+	defer cleanup()
+
+	pf, _ := s.LoadProject("TP")
+	pf.Tasks[1].ID = "TP-01"
+	require.NoError(t, s.SaveProject(pf))
+
+	// Without strict_load the corrupt file is still used
+	require.NoError(t, runTag(nil, []string{"TP-03", "before"}))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".tkconfig.yaml"), []byte("strict_load: true\n"), 0644))
+	err := runTag(nil, []string{"TP-03", "after"})
+	assert.ErrorContains(t, err, "project TP failed validation: TP-01: duplicate_id")
+	assert.ErrorContains(t, err, "tk validate --fix")
+
+	// Commands that only read, or change whole projects, refuse it too
+	old := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+	defer func() {
+		w.Close()
+		os.Stdout = old
+	}()
+	for name, run := range map[string]func() error{
+		"check":   func() error { return runCheck(nil, nil) },
+		"archive": func() error { return runArchive(nil, []string{"TP"}) },
+		"show":    func() error { return runShow(nil, []string{"TP-03"}) },
+	} {
+		var corrupt *ops.CorruptProjectError
+		assert.ErrorAs(t, run(), &corrupt, name)
+	}
+
+	// tk validate still loads it to report the problem
+	validateSuggestCycleBreak = true
+	defer func() { validateSuggestCycleBreak = false }()
+	err = runValidate(nil, nil)
+	var corrupt *ops.CorruptProjectError
+	assert.False(t, errors.As(err, &corrupt), "validate refused to load: %v", err)
+}
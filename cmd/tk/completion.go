@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/jacksmith/tk/internal/model"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -78,7 +77,7 @@ func init() {
 
 // completeProjectIDs returns a completion function for project prefixes and IDs.
 func completeProjectIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -126,7 +125,7 @@ func completeAnyIDs(cmd *cobra.Command, args []string, toComplete string) ([]str
 
 // completeIDs is a helper that returns task and/or wait IDs.
 func completeIDs(includeWaits, includeTasks bool, toComplete string) ([]string, cobra.ShellCompDirective) {
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -175,7 +174,7 @@ func completeIDs(includeWaits, includeTasks bool, toComplete string) ([]string,
 
 // completeTags returns a completion function for tags across all projects.
 func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
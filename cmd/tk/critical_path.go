@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jacksmith/tk/internal/cli"
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var criticalPathCmd = &cobra.Command{
+	Use:   "critical-path",
+	Short: "Show the longest chain of open dependencies in a project",
+	Long: `Show the longest chain of open tasks and waits in a project where each
+item is blocked by the one before it. Since each has to finish before the
+next can start, this chain sets the earliest the project can be done.
+
+Items are listed in the order they have to be done. Done and dropped items
+are left out, since they no longer hold anything up. If several chains are
+equally long, one of them is shown.
+
+Without -p, the default_project from .tkconfig.yaml is used.
+
+Examples:
+  tk critical-path -p BY
+  tk critical-path -p backyard`,
+	Args: cobra.NoArgs,
+	RunE: runCriticalPath,
+}
+
+var criticalPathProject string
+
+func init() {
+	criticalPathCmd.Flags().StringVarP(&criticalPathProject, "project", "p", "", "project (prefix or ID)")
+	criticalPathCmd.RegisterFlagCompletionFunc("project", completeProjectIDs)
+	rootCmd.AddCommand(criticalPathCmd)
+}
+
+func runCriticalPath(cmd *cobra.Command, args []string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	pf, err := ops.LookupProject(s, criticalPathProject)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	path := ops.CriticalPath(pf, cfg.DroppedUnblocks)
+	if len(path) < 2 {
+		fmt.Printf("No open items in %s are blocked by another open item.\n", pf.Prefix)
+		return nil
+	}
+
+	fmt.Printf("Critical path for %s: %s\n", pf.Prefix, cli.Count(len(path), "item"))
+	table := cli.NewTable()
+	for _, node := range path {
+		table.AddRow(node.ID, formatNodeState(node), node.Title)
+	}
+	table.Render(os.Stdout)
+	return nil
+}
@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -17,9 +17,16 @@ var deferCmd = &cobra.Command{
 The task must be open and cannot already have open waits.
 Either --days or --until must be specified.
 
+With --soft, no wait is created. The task is snoozed instead: it keeps its
+state and blockers but is hidden from 'tk list' and 'tk ready' until the
+date passes (use 'tk list --snoozed' to see it). Use a hard defer when
+something outside your control must happen first; use --soft for a simple
+"not now".
+
 Examples:
   tk defer BY-07 --days=4
-  tk defer BY-07 --until=2026-01-20`,
+  tk defer BY-07 --until=2026-01-20
+  tk defer BY-07 --soft --days=7`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runDefer,
 	ValidArgsFunction: completeTaskIDs,
@@ -28,11 +35,14 @@ Examples:
 var (
 	deferDays  int
 	deferUntil string
+	deferSoft  bool
 )
 
 func init() {
 	deferCmd.Flags().IntVar(&deferDays, "days", 0, "defer for N days")
 	deferCmd.Flags().StringVar(&deferUntil, "until", "", "defer until date (YYYY-MM-DD)")
+	deferCmd.Flags().BoolVar(&deferSoft, "soft", false, "snooze the task instead of creating a wait")
+	allowInactiveFlag(deferCmd)
 	rootCmd.AddCommand(deferCmd)
 }
 
@@ -43,7 +53,7 @@ func runDefer(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("either --days or --until must be specified")
 	}
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -52,16 +62,23 @@ func runDefer(cmd *cobra.Command, args []string) error {
 	var until time.Time
 	if deferDays > 0 {
 		// End of day N days from now
-		target := time.Now().AddDate(0, 0, deferDays)
-		until = time.Date(target.Year(), target.Month(), target.Day(), 23, 59, 59, 0, target.Location())
+		until = model.EndOfDay(time.Now().AddDate(0, 0, deferDays))
 	} else {
 		// Parse the date
-		t, err := time.Parse("2006-01-02", deferUntil)
+		t, err := model.ParseDate(deferUntil)
 		if err != nil {
 			return fmt.Errorf("invalid date format (expected YYYY-MM-DD): %v", err)
 		}
 		// End of that day
-		until = time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, time.Local)
+		until = model.EndOfDay(t)
+	}
+
+	if deferSoft {
+		if err := ops.SnoozeTask(s, taskID, until); err != nil {
+			return err
+		}
+		fmt.Printf("%s snoozed until %s.\n", taskID, model.FormatDate(until))
+		return nil
 	}
 
 	wait, err := ops.DeferTask(s, taskID, until)
@@ -69,7 +86,7 @@ func runDefer(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s deferred until %s.\n", taskID, until.Format("2006-01-02"))
+	fmt.Printf("%s deferred until %s.\n", taskID, model.FormatDate(until))
 	fmt.Printf("Created wait %s.\n", wait.ID)
 	return nil
 }
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var depCmd = &cobra.Command{
+	Use:   "dep",
+	Short: "Explore dependency chains",
+	Long: `Explore the chains of blockers behind tasks and waits.
+
+tk blocked-by and tk blocking show only direct links; these subcommands
+follow them all the way.`,
+}
+
+var depTreeCmd = &cobra.Command{
+	Use:   "tree <id>",
+	Short: "Show the full blocker tree of an item",
+	Long: `Show every blocker of a task or wait as an indented tree: its direct
+blockers, their blockers, and so on, each with its current state. Use it
+to find what deep in the chain is holding an item up.
+
+An item that blocks several others in the tree is expanded the first time
+only; later it is marked "(see above)".
+
+Examples:
+  tk dep tree BY-07
+  tk dep tree BY-03W`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runDepTree,
+	ValidArgsFunction: completeAnyIDs,
+}
+
+var depPathCmd = &cobra.Command{
+	Use:   "path <from> <to>",
+	Short: "Show how one item depends on another",
+	Long: `Show a chain of blockers linking two items in the same project, if
+there is one. The chain is searched for in both directions, so the order of
+the IDs doesn't matter.
+
+Examples:
+  tk dep path BY-07 BY-02
+  tk dep path BY-07 BY-03W`,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runDepPath,
+	ValidArgsFunction: completeAnyIDs,
+}
+
+func init() {
+	depCmd.AddCommand(depTreeCmd)
+	depCmd.AddCommand(depPathCmd)
+	rootCmd.AddCommand(depCmd)
+}
+
+func runDepTree(cmd *cobra.Command, args []string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	root, err := ops.BlockerTree(s, args[0])
+	if err != nil {
+		return err
+	}
+
+	var printNode func(node *ops.BlockerNode, depth int)
+	printNode = func(node *ops.BlockerNode, depth int) {
+		indent := strings.Repeat("  ", depth)
+		suffix := ""
+		if node.Seen {
+			suffix = " (see above)"
+		}
+		fmt.Printf("%s%s  %s  %s%s\n", indent, node.ID, formatNodeState(node), node.Title, suffix)
+		for _, blocker := range node.Blockers {
+			printNode(blocker, depth+1)
+		}
+	}
+	printNode(root, 0)
+
+	if len(root.Blockers) == 0 {
+		fmt.Printf("%s has no blockers.\n", root.ID)
+	}
+	return nil
+}
+
+// formatNodeState renders a blocker tree node's state the way tk list and
+// tk waits do for tasks and waits.
+func formatNodeState(node *ops.BlockerNode) string {
+	switch {
+	case model.IsWaitID(node.ID):
+		return formatWaitState(model.WaitState(node.State))
+	case model.IsTaskID(node.ID):
+		return formatTaskState(model.TaskState(node.State))
+	default:
+		return formatStatusBracket(node.State)
+	}
+}
+
+func runDepPath(cmd *cobra.Command, args []string) error {
+	from, to := args[0], args[1]
+
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	path, err := ops.DependencyPath(s, from, to)
+	if err != nil {
+		return err
+	}
+	if path == nil {
+		if path, err = ops.DependencyPath(s, to, from); err != nil {
+			return err
+		}
+	}
+	if path == nil {
+		fmt.Printf("%s and %s don't depend on each other.\n", from, to)
+		return nil
+	}
+
+	fmt.Printf("%s depends on %s:\n", path[0], path[len(path)-1])
+	fmt.Printf("  %s\n", strings.Join(path, " → "))
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jacksmith/tk/internal/cli"
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/jacksmith/tk/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the health of the whole store",
+	Long: `Run every health check on the store in one place:
+- Project data, as 'tk validate' checks it (orphan blockers, cycles,
+  duplicate or invalid IDs, next_id, tag case, time waits never checked)
+- Config integrity: default_project and ignored_projects must name existing
+  projects, default_priority must be 1-4, and hooks must use a known event
+- Manual waits whose check-after date passed more than a week ago
+
+Use --fix to repair what can be fixed safely: everything 'tk validate --fix'
+repairs, plus clearing a default_project that names no project. Exits with
+an error if any issues remain.
+
+Examples:
+  tk doctor
+  tk doctor --fix`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+var doctorFix bool
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "repair safely fixable issues")
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	s, err := storage.Open(".")
+	if err != nil {
+		return err
+	}
+
+	report, err := ops.Doctor(s, s, doctorFix)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Checked %s and config.\n", cli.Count(report.Projects, "project"))
+
+	if len(report.Fixes) > 0 {
+		fmt.Printf("\nFixes applied (%d):\n", len(report.Fixes))
+		for _, f := range report.Fixes {
+			fmt.Printf("  %s: %s\n", f.ItemID, f.Description)
+		}
+	}
+
+	if len(report.Issues) == 0 {
+		fmt.Println()
+		fmt.Println(cli.Green("No issues found."))
+		return nil
+	}
+
+	fmt.Printf("\nIssues (%d):\n", len(report.Issues))
+	counts := make(map[ops.ValidationErrorType]int)
+	for _, e := range report.Issues {
+		counts[e.Type]++
+		fmt.Printf("  %s %s: %s\n", e.ItemID, formatValidationErrorType(e.Type), e.Message)
+		if len(e.Details) > 0 {
+			fmt.Printf("    %s\n", strings.Join(e.Details, " → "))
+		}
+	}
+
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, string(t))
+	}
+	sort.Strings(types)
+	summary := make([]string, len(types))
+	for i, t := range types {
+		summary[i] = fmt.Sprintf("%s %d", t, counts[ops.ValidationErrorType(t)])
+	}
+	fmt.Printf("\nSummary: %s\n", strings.Join(summary, ", "))
+	if !doctorFix {
+		fmt.Println("Run 'tk doctor --fix' to repair what can be fixed safely.")
+	}
+
+	return fmt.Errorf("%s left unrepaired", cli.Count(len(report.Issues), "issue"))
+}
@@ -5,18 +5,20 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
 var doneCmd = &cobra.Command{
-	Use:   "done <id>...",
+	Use:   "done [id]...",
 	Short: "Mark task(s) as done",
 	Long: `Mark one or more tasks as done.
 
 If a task has incomplete blockers, an error is shown.
-Use --force to remove incomplete blockers and complete anyway.
+Use --force to remove incomplete blockers and complete anyway. Add
+--keep-blockers to leave the incomplete blockers on the task as a record of
+what it depended on. Tasks in paused or done projects need --allow-inactive.
 
 Multiple tasks can be specified (batch mode):
   tk done BY-07 BY-08 BY-09
@@ -24,46 +26,140 @@ Multiple tasks can be specified (batch mode):
 In batch mode, tasks that can be completed will be completed,
 and errors will be reported for tasks that couldn't be completed.
 
+If completing a task would auto-complete more tasks than max_auto_cascade
+(in .tkconfig.yaml) allows, nothing is changed and the tasks that would be
+auto-completed are listed. Use --force-cascade to apply the cascade.
+
+Use --resolve-time-waits when the work is finished ahead of schedule: if
+a task's only open blockers are time waits that haven't elapsed, they are
+resolved early so the task can be completed. The waits are only resolved
+if the task is completed too.
+
+Use --explain to print the cascade (auto-completed tasks, activated waits,
+unblocked items) before completing. Use --dry-run to print it without
+changing anything. In batch mode each task is previewed on its own.
+
+Run without an ID (or with --pick) to choose from a numbered list of
+ready and waiting tasks.
+
 Examples:
   tk done BY-07
+  tk done --pick
   tk done BY-07 --force
-  tk done BY-07 BY-08 BY-09`,
-	Args:              cobra.MinimumNArgs(1),
+  tk done BY-07 --force --keep-blockers
+  tk done BY-07 BY-08 BY-09
+  tk done BY-07 --force-cascade
+  tk done BY-07 --resolve-time-waits
+  tk done BY-07 --explain
+  tk done BY-07 --dry-run`,
 	RunE:              runDone,
 	ValidArgsFunction: completeTaskIDs,
 }
 
-var doneForce bool
+var (
+	doneForce            bool
+	doneKeepBlockers     bool
+	doneForceCascade     bool
+	donePick             bool
+	doneExplain          bool
+	doneDryRun           bool
+	doneResolveTimeWaits bool
+)
 
 func init() {
 	doneCmd.Flags().BoolVar(&doneForce, "force", false, "remove incomplete blockers and complete")
+	doneCmd.Flags().BoolVar(&doneKeepBlockers, "keep-blockers", false, "with --force, keep incomplete blockers on the task")
+	doneCmd.Flags().BoolVar(&doneForceCascade, "force-cascade", false, "apply auto-complete cascades above max_auto_cascade")
+	doneCmd.Flags().BoolVar(&donePick, "pick", false, "choose the task from a numbered list")
+	doneCmd.Flags().BoolVar(&doneExplain, "explain", false, "print the cascade before completing")
+	doneCmd.Flags().BoolVar(&doneDryRun, "dry-run", false, "print the cascade without completing")
+	doneCmd.Flags().BoolVar(&doneResolveTimeWaits, "resolve-time-waits", false, "resolve time waits blocking the task early")
+	allowInactiveFlag(doneCmd)
 	rootCmd.AddCommand(doneCmd)
 }
 
 func runDone(cmd *cobra.Command, args []string) error {
-	s, err := storage.Open(".")
+	if doneKeepBlockers && !doneForce {
+		return fmt.Errorf("--keep-blockers only applies with --force")
+	}
+
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
 
+	if len(args) == 0 || donePick {
+		taskID, err := resolvePickedID(args, donePick, func() (string, error) {
+			return pickTask(s, func(state model.TaskState) bool {
+				return state == model.TaskStateReady || state == model.TaskStateWaiting
+			})
+		})
+		if err != nil {
+			return err
+		}
+		args = []string{taskID}
+	}
+
 	var errs []string
 	var successes []string
 	hasBlockerError := false
+	hasCascadeError := false
+	hasInactiveError := false
+
+	opts := ops.CompleteOptions{
+		Force:            doneForce,
+		KeepBlockers:     doneKeepBlockers,
+		ForceCascade:     doneForceCascade,
+		ResolveTimeWaits: doneResolveTimeWaits,
+	}
 
 	for _, taskID := range args {
-		result, err := ops.CompleteTask(s, taskID, doneForce)
+		if doneExplain || doneDryRun {
+			preview, err := ops.PreviewCompleteTask(s, taskID, opts)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", taskID, err))
+				var blockerErr *ops.IncompleteBlockersError
+				if errors.As(err, &blockerErr) {
+					hasBlockerError = true
+				}
+				var statusErr *ops.ProjectStatusError
+				if errors.As(err, &statusErr) {
+					hasInactiveError = true
+				}
+				continue
+			}
+			printCompletionPreview(taskID, preview)
+			if doneDryRun {
+				successes = append(successes, taskID)
+				continue
+			}
+		}
+
+		result, err := ops.CompleteTask(s, taskID, opts)
 		if err != nil {
 			errs = append(errs, fmt.Sprintf("%s: %v", taskID, err))
 			var blockerErr *ops.IncompleteBlockersError
 			if errors.As(err, &blockerErr) {
 				hasBlockerError = true
 			}
+			var statusErr *ops.ProjectStatusError
+			if errors.As(err, &statusErr) {
+				hasInactiveError = true
+			}
+			var cascadeErr *ops.CascadeLimitError
+			if errors.As(err, &cascadeErr) {
+				hasCascadeError = true
+				fmt.Printf("%s would auto-complete: %s\n", taskID, strings.Join(cascadeErr.AutoCompleted, ", "))
+			}
 			continue
 		}
 
 		successes = append(successes, taskID)
 
 		// Print result for this task
+		if len(result.ResolvedEarly) > 0 {
+			fmt.Printf("Resolved early: %s\n", strings.Join(result.ResolvedEarly, ", "))
+		}
 		fmt.Printf("%s done.\n", taskID)
 
 		if len(result.Unblocked) > 0 {
@@ -77,6 +173,10 @@ func runDone(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if doneDryRun {
+		fmt.Println("Dry run: no changes made.")
+	}
+
 	// Report errors
 	if len(errs) > 0 {
 		fmt.Println()
@@ -86,6 +186,12 @@ func runDone(cmd *cobra.Command, args []string) error {
 		if !doneForce && hasBlockerError {
 			fmt.Println("\nUse --force to remove blockers and complete anyway.")
 		}
+		if !allowInactive && hasInactiveError {
+			fmt.Println("\nUse --allow-inactive to complete tasks in paused or done projects.")
+		}
+		if hasCascadeError {
+			fmt.Println("\nUse --force-cascade to apply the auto-complete cascade.")
+		}
 		// Return error if all failed
 		if len(successes) == 0 {
 			return fmt.Errorf("failed to complete any tasks")
@@ -94,3 +200,24 @@ func runDone(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// printCompletionPreview prints the cascade that completing taskID causes.
+func printCompletionPreview(taskID string, result *ops.CompletionResult) {
+	if len(result.ResolvedEarly) == 0 && len(result.AutoCompleted) == 0 && len(result.Activated) == 0 && len(result.Unblocked) == 0 {
+		fmt.Printf("Completing %s has no cascading effects.\n", taskID)
+		return
+	}
+	fmt.Printf("Completing %s will:\n", taskID)
+	if len(result.ResolvedEarly) > 0 {
+		fmt.Printf("  resolve early: %s\n", strings.Join(result.ResolvedEarly, ", "))
+	}
+	if len(result.AutoCompleted) > 0 {
+		fmt.Printf("  auto-complete: %s\n", strings.Join(result.AutoCompleted, ", "))
+	}
+	if len(result.Activated) > 0 {
+		fmt.Printf("  activate waits: %s\n", strings.Join(result.Activated, ", "))
+	}
+	if len(result.Unblocked) > 0 {
+		fmt.Printf("  unblock: %s\n", strings.Join(result.Unblocked, ", "))
+	}
+}
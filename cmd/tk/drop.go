@@ -2,14 +2,14 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
 var dropCmd = &cobra.Command{
-	Use:   "drop <id>",
+	Use:   "drop [id]",
 	Short: "Drop a task",
 	Long: `Drop a task (mark as not needed).
 
@@ -17,12 +17,19 @@ If the task has dependent items (tasks or waits blocked by it):
 - Use --drop-deps to also drop all dependent items recursively
 - Use --remove-deps to unlink this task from dependents
 
+After dropping, tk reports which dependents were dropped or unlinked, and
+which unlinked dependents have no open blockers left and are now ready.
+Use --show-impact to see this without dropping anything.
+
+Run without an ID (or with --pick) to choose from a numbered list of open tasks.
+
 Examples:
   tk drop BY-07
   tk drop BY-07 --reason="No longer needed"
   tk drop BY-07 --drop-deps
-  tk drop BY-07 --remove-deps`,
-	Args:              cobra.ExactArgs(1),
+  tk drop BY-07 --remove-deps
+  tk drop BY-07 --remove-deps --show-impact`,
+	Args:              cobra.MaximumNArgs(1),
 	RunE:              runDrop,
 	ValidArgsFunction: completeTaskIDs,
 }
@@ -31,27 +38,81 @@ var (
 	dropReason     string
 	dropDropDeps   bool
 	dropRemoveDeps bool
+	dropPick       bool
+	dropShowImpact bool
 )
 
 func init() {
 	dropCmd.Flags().StringVar(&dropReason, "reason", "", "reason for dropping")
 	dropCmd.Flags().BoolVar(&dropDropDeps, "drop-deps", false, "also drop dependent items")
 	dropCmd.Flags().BoolVar(&dropRemoveDeps, "remove-deps", false, "unlink from dependent items")
+	dropCmd.Flags().BoolVar(&dropPick, "pick", false, "choose the task from a numbered list")
+	dropCmd.Flags().BoolVar(&dropShowImpact, "show-impact", false, "show the effect on dependents without dropping")
+	allowInactiveFlag(dropCmd)
 	rootCmd.AddCommand(dropCmd)
 }
 
 func runDrop(cmd *cobra.Command, args []string) error {
-	taskID := args[0]
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
 
-	s, err := storage.Open(".")
+	taskID, err := resolvePickedID(args, dropPick, func() (string, error) {
+		return pickTask(s, nil)
+	})
 	if err != nil {
 		return err
 	}
 
-	if err := ops.DropTask(s, taskID, dropReason, dropDropDeps, dropRemoveDeps); err != nil {
+	if dropShowImpact {
+		result, err := ops.PreviewDropTask(s, taskID, dropDropDeps, dropRemoveDeps)
+		if err != nil {
+			return err
+		}
+		printDropPreview(taskID, result)
+		return nil
+	}
+
+	result, err := ops.DropTask(s, taskID, dropReason, dropDropDeps, dropRemoveDeps)
+	if err != nil {
 		return err
 	}
 
 	fmt.Printf("%s dropped.\n", taskID)
+	if len(result.Dropped) > 0 {
+		fmt.Printf("Also dropped: %s\n", strings.Join(result.Dropped, ", "))
+	}
+	if len(result.Unlinked) > 0 {
+		fmt.Printf("Unlinked from: %s\n", strings.Join(result.Unlinked, ", "))
+	}
+	if len(result.Unblocked) > 0 {
+		fmt.Printf("Now unblocked: %s\n", strings.Join(result.Unblocked, ", "))
+	}
 	return nil
 }
+
+// printDropPreview describes what dropping taskID with the given flags would
+// do to its dependents.
+func printDropPreview(taskID string, result *ops.DropResult) {
+	if len(result.Dependents) == 0 {
+		fmt.Printf("Dropping %s affects no other items.\n", taskID)
+		return
+	}
+	if len(result.Dropped) == 0 && len(result.Unlinked) == 0 {
+		fmt.Printf("%s blocks: %s\n", taskID, strings.Join(result.Dependents, ", "))
+		fmt.Println("Dropping it requires --drop-deps (drop them too) or --remove-deps (unlink them).")
+		return
+	}
+	fmt.Printf("Dropping %s will:\n", taskID)
+	if len(result.Dropped) > 0 {
+		fmt.Printf("  also drop: %s\n", strings.Join(result.Dropped, ", "))
+	}
+	if len(result.Unlinked) > 0 {
+		fmt.Printf("  unlink from: %s\n", strings.Join(result.Unlinked, ", "))
+	}
+	if len(result.Unblocked) > 0 {
+		fmt.Printf("  unblock: %s\n", strings.Join(result.Unblocked, ", "))
+	}
+	fmt.Println("No changes made.")
+}
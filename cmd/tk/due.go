@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jacksmith/tk/internal/cli"
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var dueCmd = &cobra.Command{
+	Use:   "due",
+	Short: "List tasks due within a period",
+	Long: `List open tasks due within a period from now, for a weekly review of
+what is landing soon.
+
+--in takes a number of hours, days, or weeks (24h, 3d, 2w) and defaults to
+7d. A task is listed when its due date falls on or before the day the
+period ends. Overdue tasks are always included, so nothing slips past.
+
+Tasks are sorted by due date. Unlike 'tk agenda', reminder windows are
+ignored.
+
+Examples:
+  tk due
+  tk due --in=3d
+  tk due --in=2w -p BY`,
+	Args: cobra.NoArgs,
+	RunE: runDue,
+}
+
+var (
+	dueIn      string
+	dueProject string
+)
+
+func init() {
+	dueCmd.Flags().StringVar(&dueIn, "in", "7d", "period to look ahead (e.g. 24h, 3d, 2w)")
+	dueCmd.Flags().StringVarP(&dueProject, "project", "p", "", "filter by project (prefix or ID)")
+	dueCmd.RegisterFlagCompletionFunc("project", completeProjectIDs)
+	rootCmd.AddCommand(dueCmd)
+}
+
+func runDue(cmd *cobra.Command, args []string) error {
+	within, err := cli.ParseDuration(dueIn)
+	if err != nil {
+		return err
+	}
+
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	ops.AutoCheck(s)
+
+	results, err := ops.ListTasks(s, ops.TaskFilter{Project: dueProject, DueWithin: &within})
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		fmt.Printf("Nothing due in the next %s.\n", dueIn)
+		return nil
+	}
+	printDueTable(results)
+	return nil
+}
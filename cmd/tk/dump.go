@@ -7,7 +7,6 @@ import (
 
 	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -20,65 +19,118 @@ This is a one-way export for viewing/sharing - it cannot be re-imported.
 
 The project can be specified by its ID (e.g., "backyard") or prefix (e.g., "BY").
 
+The output is Markdown with the project at heading level 1, sections at
+level 2, and items at level 3. Use --heading-offset to push every heading
+down so the dump nests under a section of a larger document. Use
+--no-dropped to leave out dropped tasks and waits.
+
 Examples:
   tk dump backyard
-  tk dump BY`,
+  tk dump BY
+  tk dump BY --heading-offset=1 --no-dropped`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runDump,
 	ValidArgsFunction: completeProjectIDs,
 }
 
+var (
+	dumpHeadingOffset int
+	dumpNoDropped     bool
+)
+
+// maxHeadingLevel is the deepest heading Markdown supports.
+const maxHeadingLevel = 6
+
 func init() {
+	dumpCmd.Flags().IntVar(&dumpHeadingOffset, "heading-offset", 0, "add N levels to every heading, for nesting in another document")
+	dumpCmd.Flags().BoolVar(&dumpNoDropped, "no-dropped", false, "exclude dropped tasks and waits")
 	rootCmd.AddCommand(dumpCmd)
 }
 
+// dumpRenderer writes a project as Markdown.
+type dumpRenderer struct {
+	headingOffset   int
+	hideDropped     bool
+	droppedUnblocks bool
+}
+
 func runDump(cmd *cobra.Command, args []string) error {
-	s, err := storage.Open(".")
+	if dumpHeadingOffset < 0 || dumpHeadingOffset > maxHeadingLevel-3 {
+		return fmt.Errorf("--heading-offset must be between 0 and %d", maxHeadingLevel-3)
+	}
+
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
 
-	pf, err := ops.ResolveProject(s, args[0])
+	pf, err := ops.LookupProject(s, args[0])
 	if err != nil {
 		return err
 	}
 
-	blockerStates := ops.ComputeBlockerStates(pf)
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return err
+	}
 
-	fmt.Printf("# %s: %s\n", pf.Prefix, pf.Name)
+	r := dumpRenderer{headingOffset: dumpHeadingOffset, hideDropped: dumpNoDropped, droppedUnblocks: cfg.DroppedUnblocks}
+	r.dumpProject(pf)
+	return nil
+}
+
+func (r dumpRenderer) dumpProject(pf *model.ProjectFile) {
+	blockerStates := ops.ComputeBlockerStates(pf, r.droppedUnblocks)
+
+	r.heading(1, "%s: %s", pf.Prefix, pf.Name)
 	if pf.Description != "" {
-		fmt.Printf("# %s\n", pf.Description)
+		r.heading(1, "%s", pf.Description)
 	}
-	fmt.Printf("# Status: %s\n", pf.Status)
-	fmt.Printf("# Created: %s\n", pf.Created.Format(time.RFC3339))
+	r.heading(1, "Status: %s", pf.Status)
+	r.heading(1, "Created: %s", pf.Created.Format(time.RFC3339))
 	fmt.Println()
 
-	if len(pf.Tasks) > 0 {
-		fmt.Println("## Tasks")
+	var tasks []model.Task
+	for _, t := range pf.Tasks {
+		if !r.hideDropped || t.Status != model.TaskStatusDropped {
+			tasks = append(tasks, t)
+		}
+	}
+	if len(tasks) > 0 {
+		r.heading(2, "Tasks")
 		fmt.Println()
-		for _, t := range pf.Tasks {
+		for _, t := range tasks {
 			state := model.ComputeTaskState(&t, blockerStates)
-			dumpTask(&t, state)
+			r.dumpTask(&t, state)
 			fmt.Println()
 		}
 	}
 
-	if len(pf.Waits) > 0 {
-		fmt.Println("## Waits")
+	var waits []model.Wait
+	for _, w := range pf.Waits {
+		if !r.hideDropped || w.Status != model.WaitStatusDropped {
+			waits = append(waits, w)
+		}
+	}
+	if len(waits) > 0 {
+		r.heading(2, "Waits")
 		fmt.Println()
 		now := time.Now()
-		for _, w := range pf.Waits {
+		for _, w := range waits {
 			state := model.ComputeWaitState(&w, blockerStates, now)
-			dumpWait(&w, state)
+			r.dumpWait(&w, state)
 			fmt.Println()
 		}
 	}
+}
 
-	return nil
+// heading prints a Markdown heading at level, shifted by the heading offset.
+func (r dumpRenderer) heading(level int, format string, args ...any) {
+	fmt.Printf("%s %s\n", strings.Repeat("#", level+r.headingOffset), fmt.Sprintf(format, args...))
 }
 
-func dumpTask(t *model.Task, state model.TaskState) {
-	fmt.Printf("### %s: %s\n", t.ID, t.Title)
+func (r dumpRenderer) dumpTask(t *model.Task, state model.TaskState) {
+	r.heading(3, "%s: %s", t.ID, t.Title)
 	fmt.Printf("Status: %s (%s)\n", t.Status, state)
 	fmt.Printf("Priority: P%d\n", t.Priority)
 
@@ -117,8 +169,8 @@ func dumpTask(t *model.Task, state model.TaskState) {
 	}
 }
 
-func dumpWait(w *model.Wait, state model.WaitState) {
-	fmt.Printf("### %s: %s\n", w.ID, w.DisplayText())
+func (r dumpRenderer) dumpWait(w *model.Wait, state model.WaitState) {
+	r.heading(3, "%s: %s", w.ID, w.DisplayText())
 	fmt.Printf("Status: %s (%s)\n", w.Status, state)
 	fmt.Printf("Type: %s\n", w.ResolutionCriteria.Type)
 	if w.Title != "" {
@@ -2,23 +2,28 @@ package main
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/jacksmith/tk/internal/cli"
 	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
 
 var editCmd = &cobra.Command{
-	Use:   "edit <id>",
+	Use:   "edit [id...]",
 	Short: "Edit a task",
 	Long: `Edit a task's fields.
 
 Use flags to change specific fields, or -i to edit in $EDITOR.
+Run without an ID (or with --pick) to choose from a numbered list of open tasks.
+
+With -i and several IDs, all the tasks open together in one buffer as a
+YAML list. Each entry is matched back to its task by id, and only the
+tasks that changed are updated.
 
 Examples:
   tk edit BY-07 --title="New title"
@@ -30,31 +35,35 @@ Examples:
   tk edit BY-07 --blocked-by=BY-05,BY-06    # replaces blockers
   tk edit BY-07 --add-blocked-by=BY-08      # adds blocker
   tk edit BY-07 --remove-blocked-by=BY-05   # removes blocker
-  tk edit BY-07 -i                          # open in $EDITOR`,
-	Args:              cobra.ExactArgs(1),
+  tk edit BY-07 -i                          # open in $EDITOR
+  tk edit BY-07 BY-08 BY-09 -i              # edit several in one buffer
+  tk edit --pick -i                         # pick a task, then open in $EDITOR`,
+	Args:              cobra.ArbitraryArgs,
 	RunE:              runEdit,
 	ValidArgsFunction: completeTaskIDs,
 }
 
 var (
-	editTitle          string
-	editPriority       int
-	editP1             bool
-	editP2             bool
-	editP3             bool
-	editP4             bool
-	editNotes          string
-	editAssignee       string
-	editDueDate        string
-	editClearDueDate   bool
-	editAutoComplete   string // "true", "false", or ""
-	editTags           string
-	editAddTag         []string
-	editRemoveTag      []string
-	editBlockedBy      string
-	editAddBlockedBy   []string
+	editTitle           string
+	editPriority        int
+	editP1              bool
+	editP2              bool
+	editP3              bool
+	editP4              bool
+	editNotes           string
+	editAssignee        string
+	editDueDate         string
+	editClearDueDate    bool
+	editRemindBefore    int
+	editAutoComplete    string // "true", "false", or ""
+	editTags            string
+	editAddTag          []string
+	editRemoveTag       []string
+	editBlockedBy       string
+	editAddBlockedBy    []string
 	editRemoveBlockedBy []string
-	editInteractive    bool
+	editInteractive     bool
+	editPick            bool
 )
 
 func init() {
@@ -68,6 +77,7 @@ func init() {
 	editCmd.Flags().StringVar(&editAssignee, "assignee", "", "set task assignee")
 	editCmd.Flags().StringVar(&editDueDate, "due-date", "", "set due date (YYYY-MM-DD)")
 	editCmd.Flags().BoolVar(&editClearDueDate, "clear-due-date", false, "clear due date")
+	editCmd.Flags().IntVar(&editRemindBefore, "remind-before", 0, "days before the due date to show on the agenda (0 to clear)")
 	editCmd.Flags().StringVar(&editAutoComplete, "auto-complete", "", "set auto-complete (true/false)")
 	editCmd.Flags().StringVar(&editTags, "tags", "", "replace all tags (comma-separated)")
 	editCmd.Flags().StringArrayVar(&editAddTag, "add-tag", nil, "add a tag")
@@ -76,6 +86,7 @@ func init() {
 	editCmd.Flags().StringArrayVar(&editAddBlockedBy, "add-blocked-by", nil, "add a blocker")
 	editCmd.Flags().StringArrayVar(&editRemoveBlockedBy, "remove-blocked-by", nil, "remove a blocker")
 	editCmd.Flags().BoolVarP(&editInteractive, "interactive", "i", false, "edit in $EDITOR")
+	editCmd.Flags().BoolVar(&editPick, "pick", false, "choose the task from a numbered list")
 
 	// Register completion functions
 	editCmd.RegisterFlagCompletionFunc("add-tag", completeTags)
@@ -83,13 +94,29 @@ func init() {
 	editCmd.RegisterFlagCompletionFunc("add-blocked-by", completeAnyIDs)
 	editCmd.RegisterFlagCompletionFunc("remove-blocked-by", completeAnyIDs)
 
+	allowInactiveFlag(editCmd)
 	rootCmd.AddCommand(editCmd)
 }
 
 func runEdit(cmd *cobra.Command, args []string) error {
-	taskID := args[0]
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 1 {
+		if !editInteractive {
+			return fmt.Errorf("editing several tasks at once requires -i")
+		}
+		if editPick {
+			return fmt.Errorf("cannot combine --pick with an explicit ID")
+		}
+		return runEditInteractiveMulti(s, args)
+	}
 
-	s, err := storage.Open(".")
+	taskID, err := resolvePickedID(args, editPick, func() (string, error) {
+		return pickTask(s, nil)
+	})
 	if err != nil {
 		return err
 	}
@@ -141,7 +168,7 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		changes.DueDate = &nilTime
 		hasChanges = true
 	} else if editDueDate != "" {
-		t, err := time.Parse("2006-01-02", editDueDate)
+		t, err := model.ParseDate(editDueDate)
 		if err != nil {
 			return fmt.Errorf("invalid due date format (expected YYYY-MM-DD): %v", err)
 		}
@@ -150,6 +177,11 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		hasChanges = true
 	}
 
+	if cmd.Flags().Changed("remind-before") {
+		changes.RemindBefore = &editRemindBefore
+		hasChanges = true
+	}
+
 	if editAutoComplete != "" {
 		switch strings.ToLower(editAutoComplete) {
 		case "true", "yes", "1":
@@ -183,10 +215,11 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("%s updated.\n", taskID)
+	warnAutoCompleteManualWaits(s, taskID)
 	return nil
 }
 
-func handleTagChanges(s *storage.Storage, taskID string, changes *ops.TaskChanges, cmd *cobra.Command, hasChanges *bool) error {
+func handleTagChanges(s ops.Store, taskID string, changes *ops.TaskChanges, cmd *cobra.Command, hasChanges *bool) error {
 	// If --tags is set, it replaces all tags
 	if cmd.Flags().Changed("tags") {
 		var tags []string
@@ -220,7 +253,7 @@ func handleTagChanges(s *storage.Storage, taskID string, changes *ops.TaskChange
 			}
 		}
 		if task == nil {
-			return fmt.Errorf("task %s not found", taskID)
+			return &ops.NotFoundError{Kind: "task", ItemID: taskID}
 		}
 
 		// Build new tag list
@@ -244,6 +277,7 @@ func handleTagChanges(s *storage.Storage, taskID string, changes *ops.TaskChange
 		for tag := range tagSet {
 			newTags = append(newTags, tag)
 		}
+		sort.Strings(newTags)
 
 		changes.Tags = &newTags
 		*hasChanges = true
@@ -252,7 +286,7 @@ func handleTagChanges(s *storage.Storage, taskID string, changes *ops.TaskChange
 	return nil
 }
 
-func handleBlockerChanges(s *storage.Storage, taskID string, changes *ops.TaskChanges, cmd *cobra.Command, hasChanges *bool) error {
+func handleBlockerChanges(s ops.Store, taskID string, changes *ops.TaskChanges, cmd *cobra.Command, hasChanges *bool) error {
 	// If --blocked-by is set, it replaces all blockers
 	if cmd.Flags().Changed("blocked-by") {
 		var blockers []string
@@ -284,7 +318,7 @@ func handleBlockerChanges(s *storage.Storage, taskID string, changes *ops.TaskCh
 			}
 		}
 		if task == nil {
-			return fmt.Errorf("task %s not found", taskID)
+			return &ops.NotFoundError{Kind: "task", ItemID: taskID}
 		}
 
 		// Build new blocker list
@@ -308,6 +342,7 @@ func handleBlockerChanges(s *storage.Storage, taskID string, changes *ops.TaskCh
 		for b := range blockerSet {
 			newBlockers = append(newBlockers, b)
 		}
+		sort.Strings(newBlockers)
 
 		changes.BlockedBy = &newBlockers
 		*hasChanges = true
@@ -324,48 +359,26 @@ type editableTask struct {
 	Notes        string   `yaml:"notes,omitempty"`
 	Assignee     string   `yaml:"assignee,omitempty"`
 	DueDate      string   `yaml:"due_date,omitempty"`
+	RemindBefore int      `yaml:"remind_before,omitempty"`
 	AutoComplete bool     `yaml:"auto_complete"`
 	BlockedBy    []string `yaml:"blocked_by,omitempty"`
 }
 
-func runEditInteractive(s *storage.Storage, taskID string) error {
-	prefix := model.ExtractPrefix(taskID)
-	if prefix == "" {
-		return fmt.Errorf("invalid task ID: %s", taskID)
-	}
+// editableTaskItem is one entry of a multi-task edit buffer. The id is
+// used to match the entry back to its task and cannot itself be changed.
+type editableTaskItem struct {
+	ID           string `yaml:"id"`
+	editableTask `yaml:",inline"`
+}
 
-	pf, err := s.LoadProject(prefix)
+func runEditInteractive(s ops.Store, taskID string) error {
+	task, err := loadEditTask(s, taskID)
 	if err != nil {
 		return err
 	}
 
-	// Find the task
-	var task *model.Task
-	for i := range pf.Tasks {
-		if strings.EqualFold(pf.Tasks[i].ID, taskID) {
-			task = &pf.Tasks[i]
-			break
-		}
-	}
-	if task == nil {
-		return fmt.Errorf("task %s not found", taskID)
-	}
-
-	// Create editable representation
-	editable := editableTask{
-		Title:        task.Title,
-		Priority:     task.Priority,
-		Tags:         task.Tags,
-		Notes:        task.Notes,
-		Assignee:     task.Assignee,
-		AutoComplete: task.AutoComplete,
-		BlockedBy:    task.BlockedBy,
-	}
-	if task.DueDate != nil {
-		editable.DueDate = task.DueDate.Format("2006-01-02")
-	}
-
 	// Marshal to YAML
+	editable := newEditableTask(task)
 	content, err := yaml.Marshal(&editable)
 	if err != nil {
 		return fmt.Errorf("failed to marshal task: %w", err)
@@ -387,14 +400,144 @@ func runEditInteractive(s *storage.Storage, taskID string) error {
 		return fmt.Errorf("invalid YAML: %w", err)
 	}
 
-	// Build changes
+	changes, err := editableTaskChanges(task, &newEditable)
+	if err != nil {
+		return err
+	}
+
+	if err := ops.EditTask(s, taskID, changes); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s updated.\n", taskID)
+	warnAutoCompleteManualWaits(s, taskID)
+	return nil
+}
+
+// runEditInteractiveMulti opens several tasks in one editor buffer as a YAML
+// list and applies each entry's changes to its task. Every entry is checked
+// before any task is updated, so a mistake in one entry leaves all tasks
+// untouched. Entries deleted from the buffer leave their task unchanged.
+func runEditInteractiveMulti(s ops.Store, taskIDs []string) error {
+	tasks := make(map[string]*model.Task, len(taskIDs))
+	var items []editableTaskItem
+	for _, id := range taskIDs {
+		task, err := loadEditTask(s, id)
+		if err != nil {
+			return err
+		}
+		key := strings.ToUpper(task.ID)
+		if tasks[key] != nil {
+			return fmt.Errorf("task %s given more than once", task.ID)
+		}
+		tasks[key] = task
+		items = append(items, editableTaskItem{ID: task.ID, editableTask: newEditableTask(task)})
+	}
+
+	content, err := yaml.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tasks: %w", err)
+	}
+
+	header := fmt.Sprintf("# Editing tasks %s\n# Each entry is matched to its task by id; do not change the ids.\n# Save and close editor to apply changes. Exit without saving to cancel.\n\n", strings.Join(taskIDs, ", "))
+	content = append([]byte(header), content...)
+
+	edited, err := cli.EditInEditor(content, ".yaml")
+	if err != nil {
+		return err
+	}
+
+	var newItems []editableTaskItem
+	if err := yaml.Unmarshal(edited, &newItems); err != nil {
+		return fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	var pending []ops.TaskEdit
+	seen := make(map[string]bool)
+	for i := range newItems {
+		item := &newItems[i]
+		key := strings.ToUpper(item.ID)
+		task := tasks[key]
+		if task == nil {
+			return fmt.Errorf("entry with id %q does not match any task being edited", item.ID)
+		}
+		if seen[key] {
+			return fmt.Errorf("task %s appears more than once", task.ID)
+		}
+		seen[key] = true
+
+		changes, err := editableTaskChanges(task, &item.editableTask)
+		if err != nil {
+			return fmt.Errorf("%s: %w", task.ID, err)
+		}
+		if changes != (ops.TaskChanges{}) {
+			pending = append(pending, ops.TaskEdit{TaskID: task.ID, Changes: changes})
+		}
+	}
+
+	if len(pending) == 0 {
+		fmt.Println("No changes.")
+		return nil
+	}
+
+	if err := ops.EditTasks(s, pending); err != nil {
+		return err
+	}
+	for _, p := range pending {
+		fmt.Printf("%s updated.\n", p.TaskID)
+		warnAutoCompleteManualWaits(s, p.TaskID)
+	}
+	return nil
+}
+
+// loadEditTask loads the task to edit interactively.
+func loadEditTask(s ops.Store, taskID string) (*model.Task, error) {
+	prefix := model.ExtractPrefix(taskID)
+	if prefix == "" {
+		return nil, fmt.Errorf("invalid task ID: %s", taskID)
+	}
+
+	pf, err := s.LoadProject(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range pf.Tasks {
+		if strings.EqualFold(pf.Tasks[i].ID, taskID) {
+			return &pf.Tasks[i], nil
+		}
+	}
+	return nil, &ops.NotFoundError{Kind: "task", ItemID: taskID}
+}
+
+// newEditableTask creates the editable representation of a task.
+func newEditableTask(task *model.Task) editableTask {
+	editable := editableTask{
+		Title:        task.Title,
+		Priority:     task.Priority,
+		Tags:         task.Tags,
+		Notes:        task.Notes,
+		Assignee:     task.Assignee,
+		RemindBefore: task.RemindBefore,
+		AutoComplete: task.AutoComplete,
+		BlockedBy:    task.BlockedBy,
+	}
+	if task.DueDate != nil {
+		editable.DueDate = model.DueDay(*task.DueDate)
+	}
+	return editable
+}
+
+// editableTaskChanges diffs an edited representation against the task it
+// was created from.
+func editableTaskChanges(task *model.Task, newEditable *editableTask) (ops.TaskChanges, error) {
 	changes := ops.TaskChanges{}
 	if newEditable.Title != task.Title {
 		changes.Title = &newEditable.Title
 	}
 	if newEditable.Priority != task.Priority {
 		if err := ops.ValidatePriority(newEditable.Priority); err != nil {
-			return err
+			return changes, err
 		}
 		changes.Priority = &newEditable.Priority
 	}
@@ -411,35 +554,33 @@ func runEditInteractive(s *storage.Storage, taskID string) error {
 	// Handle due date changes
 	oldDueDate := ""
 	if task.DueDate != nil {
-		oldDueDate = task.DueDate.Format("2006-01-02")
+		oldDueDate = model.DueDay(*task.DueDate)
 	}
 	if newEditable.DueDate != oldDueDate {
 		if newEditable.DueDate == "" {
 			var nilTime *time.Time
 			changes.DueDate = &nilTime
 		} else {
-			t, err := time.Parse("2006-01-02", newEditable.DueDate)
+			t, err := model.ParseDate(newEditable.DueDate)
 			if err != nil {
-				return fmt.Errorf("invalid due_date format (expected YYYY-MM-DD): %v", err)
+				return changes, fmt.Errorf("invalid due_date format (expected YYYY-MM-DD): %v", err)
 			}
 			tPtr := &t
 			changes.DueDate = &tPtr
 		}
 	}
 
+	if newEditable.RemindBefore != task.RemindBefore {
+		changes.RemindBefore = &newEditable.RemindBefore
+	}
+
 	if newEditable.AutoComplete != task.AutoComplete {
 		changes.AutoComplete = &newEditable.AutoComplete
 	}
 	if !stringSliceEqual(newEditable.BlockedBy, task.BlockedBy) {
 		changes.BlockedBy = &newEditable.BlockedBy
 	}
-
-	if err := ops.EditTask(s, taskID, changes); err != nil {
-		return err
-	}
-
-	fmt.Printf("%s updated.\n", taskID)
-	return nil
+	return changes, nil
 }
 
 func stringSliceEqual(a, b []string) bool {
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jacksmith/tk/internal/cli"
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [project]",
+	Short: "Export project as an importable bundle",
+	Long: `Export a project as YAML or JSON that can be loaded with 'tk import'.
+
+Unlike 'tk dump', the output is machine-readable and round-trips through
+'tk import' in another tk directory.
+
+Use --portable to make sure the bundle is self-contained: per-user snooze
+state is stripped, and the export fails if any blocker reference points at
+an item outside the project.
+
+--format=dot writes the dependency graph instead, with each project's items
+grouped in a labeled cluster, ready for graphviz. Without a project (or with
+--all) it covers every active project except those in ignored_projects.
+
+--format=csv writes a spreadsheet of tasks, one row per task including done
+and dropped ones, with columns id, project, title, status, state, priority,
+assignee, due_date, tags (separated by semicolons), created, and updated.
+Without a project it covers every project except those in
+ignored_projects.
+
+The project can be given as an argument or with -p. Use -o to write to a
+file instead of stdout.
+
+Examples:
+  tk export backyard --portable > backyard.yaml
+  tk export BY --portable --format=json -o backyard.json
+  tk export --format=dot -o system.dot && dot -Tpng system.dot -o system.png
+  tk export --format=csv -p backyard -o backyard.csv`,
+	Args:              cobra.MaximumNArgs(1),
+	RunE:              runExport,
+	ValidArgsFunction: completeProjectIDs,
+}
+
+var (
+	exportPortable bool
+	exportFormat   string
+	exportOutput   string
+	exportAll      bool
+	exportProject  string
+)
+
+func init() {
+	exportCmd.Flags().BoolVar(&exportPortable, "portable", false, "strip per-user state and require a self-contained project")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "yaml", "output format: yaml, json, dot, or csv")
+	exportCmd.Flags().StringVarP(&exportProject, "project", "p", "", "project to export (prefix or ID)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "write to file instead of stdout")
+	exportCmd.Flags().BoolVar(&exportAll, "all", false, "export every active project (dot format only)")
+	exportCmd.RegisterFlagCompletionFunc("project", completeProjectIDs)
+
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	if exportProject != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("give the project as an argument or with -p, not both")
+		}
+		args = []string{exportProject}
+	}
+
+	switch exportFormat {
+	case "dot":
+		return runExportDOT(args)
+	case "csv":
+		return runExportCSV(args)
+	case "yaml", "json":
+	default:
+		return fmt.Errorf("unknown format %q (use yaml, json, dot, or csv)", exportFormat)
+	}
+	if exportAll || len(args) == 0 {
+		return fmt.Errorf("%s bundles hold one project; name it, or use --format=dot to export them all", exportFormat)
+	}
+
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	pf, err := ops.ExportProject(s, args[0], exportPortable)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	switch exportFormat {
+	case "yaml":
+		data, err = model.MarshalProject(pf)
+	case "json":
+		data, err = json.MarshalIndent(pf, "", "  ")
+		data = append(data, '\n')
+	default:
+		return fmt.Errorf("unknown format %q (use yaml, json, dot, or csv)", exportFormat)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode project: %w", err)
+	}
+
+	if exportOutput == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(exportOutput, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", exportOutput, err)
+	}
+	fmt.Printf("Exported %s to %s\n", pf.Prefix, exportOutput)
+	return nil
+}
+
+// runExportDOT writes the dependency graph of one project, or of every
+// active project, as DOT with a cluster per project.
+func runExportDOT(args []string) error {
+	if exportAll && len(args) > 0 {
+		return fmt.Errorf("--all exports every project; drop the project argument or --all")
+	}
+	if exportPortable {
+		return fmt.Errorf("--portable applies to yaml and json bundles, not dot")
+	}
+
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	var projects []*model.ProjectFile
+	if len(args) > 0 {
+		pf, err := ops.ResolveProject(s, args[0])
+		if err != nil {
+			return err
+		}
+		projects = append(projects, pf)
+	} else {
+		projects, err = ops.LoadActiveProjects(s, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	writeDOT(&buf, projects, dotOptions{clusters: true, droppedUnblocks: cfg.DroppedUnblocks})
+
+	if exportOutput == "" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(exportOutput, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", exportOutput, err)
+	}
+	fmt.Printf("Exported %s to %s\n", cli.Count(len(projects), "project"), exportOutput)
+	return nil
+}
+
+// runExportCSV writes every task of one project, or of all projects, as CSV
+// with a header row.
+func runExportCSV(args []string) error {
+	if exportPortable {
+		return fmt.Errorf("--portable applies to yaml and json bundles, not csv")
+	}
+
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	filter := ops.TaskFilter{All: true}
+	if len(args) > 0 {
+		// Resolve exactly, as the other formats do; ListTasks would accept
+		// a partial reference
+		pf, err := ops.ResolveProject(s, args[0])
+		if err != nil {
+			return err
+		}
+		filter.Project = pf.Prefix
+	}
+	results, err := ops.ListTasks(s, filter)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"id", "project", "title", "status", "state", "priority", "assignee", "due_date", "tags", "created", "updated"})
+	for _, r := range results {
+		t := r.Task
+		var due string
+		if t.DueDate != nil {
+			due = model.DueDay(*t.DueDate)
+		}
+		w.Write([]string{
+			t.ID,
+			r.Project,
+			t.Title,
+			string(t.Status),
+			string(r.State),
+			strconv.Itoa(t.Priority),
+			t.Assignee,
+			due,
+			strings.Join(t.Tags, ";"),
+			t.Created.In(model.Timezone()).Format(time.RFC3339),
+			t.Updated.In(model.Timezone()).Format(time.RFC3339),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to encode tasks: %w", err)
+	}
+
+	if exportOutput == "" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(exportOutput, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", exportOutput, err)
+	}
+	fmt.Printf("Exported %s to %s\n", cli.Count(len(results), "task"), exportOutput)
+	return nil
+}
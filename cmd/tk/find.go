@@ -3,10 +3,10 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/jacksmith/tk/internal/cli"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -21,17 +21,42 @@ Performs a case-insensitive substring search in:
 - Wait titles
 - Wait questions
 
-Use -p/--project to limit search to a specific project.
+Use -p/--project to limit search to a specific project, or to several
+projects with a comma-separated list.
 
-Results are grouped by type (Tasks, Waits) and show ID and matching text.`,
+Results are grouped by type (Tasks, Waits) and show ID and matching text.
+They are ordered by project, then ID. Use --limit to show only the first N
+tasks and N waits, and --tasks-only or --waits-only to search just one kind.
+
+Use --ids-only to print just the matching IDs, one per line (tasks first,
+then waits), for piping into other commands. Nothing is printed when
+nothing matches.
+
+Examples:
+  tk find gravel
+  tk find gravel -p BY
+  tk find gravel --project=BY,GD
+  tk find the --limit=5
+  tk find gravel --ids-only --tasks-only | xargs tk done`,
 	Args: cobra.ExactArgs(1),
 	RunE: runFind,
 }
 
-var findProject string
+var (
+	findProject   string
+	findLimit     int
+	findIDsOnly   bool
+	findTasksOnly bool
+	findWaitsOnly bool
+)
 
 func init() {
-	findCmd.Flags().StringVarP(&findProject, "project", "p", "", "limit search to project (prefix or ID)")
+	findCmd.Flags().StringVarP(&findProject, "project", "p", "", "limit search to projects (comma-separated prefixes or IDs)")
+	findCmd.Flags().IntVar(&findLimit, "limit", 0, "show at most N tasks and N waits (0 = no limit)")
+	findCmd.Flags().BoolVar(&findIDsOnly, "ids-only", false, "print only matching IDs, one per line")
+	findCmd.Flags().BoolVar(&findTasksOnly, "tasks-only", false, "search only tasks")
+	findCmd.Flags().BoolVar(&findWaitsOnly, "waits-only", false, "search only waits")
+	findCmd.MarkFlagsMutuallyExclusive("tasks-only", "waits-only")
 	findCmd.RegisterFlagCompletionFunc("project", completeProjectIDs)
 	rootCmd.AddCommand(findCmd)
 }
@@ -39,43 +64,87 @@ func init() {
 func runFind(cmd *cobra.Command, args []string) error {
 	query := args[0]
 
-	s, err := storage.Open(".")
+	if findLimit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
 
 	ops.AutoCheck(s)
 
-	result, err := ops.FindItems(s, query, findProject)
+	var projectRefs []string
+	for _, ref := range strings.Split(findProject, ",") {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			projectRefs = append(projectRefs, ref)
+		}
+	}
+
+	result, err := ops.FindItems(s, query, projectRefs)
 	if err != nil {
 		return err
 	}
+	if findWaitsOnly {
+		result.Tasks = nil
+	}
+	if findTasksOnly {
+		result.Waits = nil
+	}
 
 	if len(result.Tasks) == 0 && len(result.Waits) == 0 {
-		fmt.Printf("No results found for %q\n", query)
+		if !findIDsOnly {
+			fmt.Printf("No results found for %q\n", query)
+		}
 		return nil
 	}
 
-	if len(result.Tasks) > 0 {
+	tasks, moreTasks := result.Tasks, 0
+	if findLimit > 0 && len(tasks) > findLimit {
+		tasks, moreTasks = tasks[:findLimit], len(tasks)-findLimit
+	}
+	waits, moreWaits := result.Waits, 0
+	if findLimit > 0 && len(waits) > findLimit {
+		waits, moreWaits = waits[:findLimit], len(waits)-findLimit
+	}
+
+	if findIDsOnly {
+		for _, m := range tasks {
+			fmt.Println(m.Task.ID)
+		}
+		for _, m := range waits {
+			fmt.Println(m.Wait.ID)
+		}
+		return nil
+	}
+
+	if len(tasks) > 0 {
 		fmt.Println("Tasks:")
 		table := cli.NewTable()
 		table.SetMaxWidth(2, cli.DefaultMaxTitleWidth)
-		for _, m := range result.Tasks {
+		for _, m := range tasks {
 			table.AddRow(m.Task.ID, formatTaskState(m.State), m.Task.Title)
 		}
 		table.Render(os.Stdout)
+		if moreTasks > 0 {
+			fmt.Println(cli.Gray(fmt.Sprintf("+%d more", moreTasks)))
+		}
 	}
 
-	if len(result.Waits) > 0 {
-		if len(result.Tasks) > 0 {
+	if len(waits) > 0 {
+		if len(tasks) > 0 {
 			fmt.Println()
 		}
 		fmt.Println("Waits:")
 		table := cli.NewTable()
-		for _, m := range result.Waits {
+		for _, m := range waits {
 			table.AddRow(m.Wait.ID, m.Wait.DisplayText())
 		}
 		table.Render(os.Stdout)
+		if moreWaits > 0 {
+			fmt.Println(cli.Gray(fmt.Sprintf("+%d more", moreWaits)))
+		}
 	}
 
 	return nil
@@ -2,12 +2,14 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/jacksmith/tk/internal/graph"
 	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -24,14 +26,31 @@ Styling:
 - Tasks are boxes, waits are diamonds
 - Ready: green, Blocked: red, Waiting: yellow
 - Done: gray, Dropped: strikethrough
-- Wait dependencies use dashed lines`,
+- Wait dependencies use dashed lines
+
+Use --waits-only to show just waits, the items directly connected to them,
+and the edges that touch a wait:
+  tk graph --waits-only | dot -Tpng -o waits.png
+
+Use --stats to print metrics instead of DOT, to gauge how tangled each
+project has become: items, blocker links, the longest blocker chain, roots
+(items nothing depends on), leaf blockers (blockers with no blockers of
+their own), and connected components:
+  tk graph --stats -p backyard`,
 	RunE: runGraph,
 }
 
-var graphProject string
+var (
+	graphProject   string
+	graphWaitsOnly bool
+	graphStats     bool
+)
 
 func init() {
 	graphCmd.Flags().StringVarP(&graphProject, "project", "p", "", "limit to project (prefix or ID)")
+	graphCmd.Flags().BoolVar(&graphWaitsOnly, "waits-only", false, "show only waits and their direct neighbors")
+	graphCmd.Flags().BoolVar(&graphStats, "stats", false, "print graph metrics instead of DOT")
+	graphCmd.MarkFlagsMutuallyExclusive("waits-only", "stats")
 
 	// Register completion function
 	graphCmd.RegisterFlagCompletionFunc("project", completeProjectIDs)
@@ -40,7 +59,7 @@ func init() {
 }
 
 func runGraph(cmd *cobra.Command, args []string) error {
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -49,7 +68,7 @@ func runGraph(cmd *cobra.Command, args []string) error {
 
 	var projects []*model.ProjectFile
 	if graphProject != "" {
-		pf, err := ops.ResolveProject(s, graphProject)
+		pf, err := ops.LookupProject(s, graphProject)
 		if err != nil {
 			return err
 		}
@@ -62,54 +81,149 @@ func runGraph(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Generate DOT output
-	fmt.Println("digraph tk {")
-	fmt.Println("  rankdir=LR;")
-	fmt.Println("  node [shape=box];")
-	fmt.Println()
+	if graphStats {
+		printGraphStats(projects)
+		return nil
+	}
+
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	writeDOT(os.Stdout, projects, dotOptions{waitsOnly: graphWaitsOnly, droppedUnblocks: cfg.DroppedUnblocks})
+	return nil
+}
+
+// dotOptions controls what writeDOT draws.
+type dotOptions struct {
+	waitsOnly       bool // only waits and their direct neighbors
+	clusters        bool // group each project's nodes in a labeled cluster
+	droppedUnblocks bool // the dropped_unblocks setting
+}
+
+// writeDOT writes the dependency graph of the given projects in DOT format.
+func writeDOT(out io.Writer, projects []*model.ProjectFile, opts dotOptions) {
+	fmt.Fprintln(out, "digraph tk {")
+	fmt.Fprintln(out, "  rankdir=LR;")
+	fmt.Fprintln(out, "  node [shape=box];")
+	fmt.Fprintln(out)
 
 	now := time.Now()
 
 	for _, pf := range projects {
-		blockerStates := ops.ComputeBlockerStates(pf)
+		blockerStates := ops.ComputeBlockerStates(pf, opts.droppedUnblocks)
+
+		// include reports whether a node is drawn; showEdge whether an edge is
+		var include func(id string) bool
+		var showEdge func(from, to string) bool
+		if opts.waitsOnly {
+			neighborhood := waitNeighborhood(pf)
+			include = func(id string) bool { return neighborhood[id] }
+			showEdge = func(from, to string) bool {
+				return (model.IsWaitID(from) || model.IsWaitID(to)) && include(from) && include(to)
+			}
+		} else {
+			include = func(string) bool { return true }
+			showEdge = func(string, string) bool { return true }
+		}
+
+		indent := "  "
+		if opts.clusters {
+			fmt.Fprintf(out, "  subgraph cluster_%s {\n", pf.Prefix)
+			fmt.Fprintf(out, "    label=%q;\n", escapeLabel(pf.Prefix+": "+pf.Name))
+			indent = "    "
+		}
 
 		// Output task nodes
 		for _, t := range pf.Tasks {
+			if !include(t.ID) {
+				continue
+			}
 			state := model.ComputeTaskState(&t, blockerStates)
 			nodeAttrs := taskNodeAttrs(&t, state)
-			fmt.Printf("  %q %s;\n", t.ID, nodeAttrs)
+			fmt.Fprintf(out, "%s%q %s;\n", indent, t.ID, nodeAttrs)
 		}
 
 		// Output wait nodes
 		for _, w := range pf.Waits {
+			if !include(w.ID) {
+				continue
+			}
 			state := model.ComputeWaitState(&w, blockerStates, now)
 			nodeAttrs := waitNodeAttrs(&w, state)
-			fmt.Printf("  %q %s;\n", w.ID, nodeAttrs)
+			fmt.Fprintf(out, "%s%q %s;\n", indent, w.ID, nodeAttrs)
 		}
 
-		fmt.Println()
+		if opts.clusters {
+			fmt.Fprintln(out, "  }")
+		}
+		fmt.Fprintln(out)
 
-		// Output edges
+		// Output edges, sorted per item so the DOT is stable across runs
+		g := graph.BuildGraph(pf)
 		for _, t := range pf.Tasks {
-			for _, blockerID := range t.BlockedBy {
+			for _, blockerID := range g.BlockedBy(t.ID) {
+				if !showEdge(blockerID, t.ID) {
+					continue
+				}
 				edgeStyle := ""
 				if model.IsWaitID(blockerID) {
 					edgeStyle = " [style=dashed]"
 				}
-				fmt.Printf("  %q -> %q%s;\n", blockerID, t.ID, edgeStyle)
+				fmt.Fprintf(out, "  %q -> %q%s;\n", blockerID, t.ID, edgeStyle)
 			}
 		}
 
 		for _, w := range pf.Waits {
-			for _, blockerID := range w.BlockedBy {
+			for _, blockerID := range g.BlockedBy(w.ID) {
+				if !showEdge(blockerID, w.ID) {
+					continue
+				}
 				edgeStyle := " [style=dashed]"
-				fmt.Printf("  %q -> %q%s;\n", blockerID, w.ID, edgeStyle)
+				fmt.Fprintf(out, "  %q -> %q%s;\n", blockerID, w.ID, edgeStyle)
 			}
 		}
 	}
 
-	fmt.Println("}")
-	return nil
+	fmt.Fprintln(out, "}")
+}
+
+// printGraphStats prints the structural metrics of each project's graph.
+func printGraphStats(projects []*model.ProjectFile) {
+	for i, pf := range projects {
+		if i > 0 {
+			fmt.Println()
+		}
+		st := graph.BuildGraph(pf).Stats()
+		fmt.Printf("%s: %s\n", pf.Prefix, pf.Name)
+		fmt.Printf("  Items:         %d\n", st.Nodes)
+		fmt.Printf("  Links:         %d\n", st.Edges)
+		fmt.Printf("  Max depth:     %d\n", st.MaxDepth)
+		fmt.Printf("  Roots:         %d\n", st.Roots)
+		fmt.Printf("  Leaf blockers: %d\n", st.Leaves)
+		fmt.Printf("  Components:    %d\n", st.Components)
+	}
+}
+
+// waitNeighborhood returns the IDs of all waits in a project plus the items
+// one hop away from them: what each wait blocks and what blocks each wait.
+func waitNeighborhood(pf *model.ProjectFile) map[string]bool {
+	ids := make(map[string]bool)
+	for _, w := range pf.Waits {
+		ids[w.ID] = true
+		for _, blockerID := range w.BlockedBy {
+			ids[blockerID] = true
+		}
+	}
+	for _, t := range pf.Tasks {
+		for _, blockerID := range t.BlockedBy {
+			if model.IsWaitID(blockerID) {
+				ids[t.ID] = true
+			}
+		}
+	}
+	return ids
 }
 
 func taskNodeAttrs(t *model.Task, state model.TaskState) string {
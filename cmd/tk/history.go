@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jacksmith/tk/internal/cli"
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <id>",
+	Short: "Show a timeline for a task or wait",
+	Long: `Show a best-effort timeline for a task or wait, oldest first.
+
+The timeline is rebuilt from the timestamps stored on the item: when it was
+created, last updated, and completed, resolved, or dropped, plus when each
+of its current blockers was resolved. tk keeps no audit log, so earlier
+edits and blockers that were later removed don't appear.
+
+Examples:
+  tk history BY-07
+  tk history BY-03W`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runHistory,
+	ValidArgsFunction: completeAnyIDs,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	h, err := ops.GetItemHistory(s, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: %s\n", h.ID, h.Title)
+	table := cli.NewTable()
+	for _, e := range h.Events {
+		table.AddRow(model.FormatDateTime(e.Time), e.Description)
+	}
+	table.Render(os.Stdout)
+	return nil
+}
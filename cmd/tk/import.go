@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jacksmith/tk/internal/cli"
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a project bundle",
+	Long: `Import a project written by 'tk export' (YAML or JSON) as a new project.
+
+The project's prefix and ID must not already be in use, and every blocker
+reference in the bundle must point at an item inside it.
+
+Examples:
+  tk import backyard.yaml
+  tk import backyard.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", args[0], err)
+	}
+	pf, err := model.ParseProject(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", args[0], err)
+	}
+
+	if err := ops.ImportProject(s, pf); err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported project %s (%s) with %s and %s\n", pf.ID, pf.Prefix, cli.Count(len(pf.Tasks), "task"), cli.Count(len(pf.Waits), "wait"))
+	return nil
+}
@@ -2,7 +2,12 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/jacksmith/tk/internal/cli"
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/jacksmith/tk/internal/ops"
 	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
@@ -16,22 +21,45 @@ This creates the necessary directory structure for tk to manage tasks.
 If --name and --prefix are not specified, creates a project called "Default"
 with prefix "DF".
 
-Fails if .tk/ already exists in the current directory.`,
+Use --from to start from an existing task set instead of an empty default
+project: either another tk directory (all of its projects are copied) or a
+single file written by 'tk export'. Projects are copied as by 'tk export
+--portable' and 'tk import', so per-user snooze state is dropped and every
+blocker reference must stay inside its project. If none of the copied
+projects has the ID "default", default_project in .tkconfig.yaml is pointed
+at the first one.
+
+Fails if .tk/ already exists in the current directory.
+
+Examples:
+  tk init
+  tk init --name="Home" --prefix=HM
+  tk init --from=../client-template
+  tk init --from=onboarding.yaml`,
 	RunE: runInit,
 }
 
 var (
 	initName   string
 	initPrefix string
+	initFrom   string
 )
 
 func init() {
 	initCmd.Flags().StringVar(&initName, "name", "", "name for the default project")
 	initCmd.Flags().StringVar(&initPrefix, "prefix", "", "prefix for the default project (2-3 uppercase letters)")
+	initCmd.Flags().StringVar(&initFrom, "from", "", "copy projects from another tk directory or an exported file")
 	rootCmd.AddCommand(initCmd)
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
+	if initFrom != "" {
+		if initName != "" || initPrefix != "" {
+			return fmt.Errorf("cannot use --name or --prefix with --from")
+		}
+		return runInitFrom(initFrom)
+	}
+
 	s, err := storage.Init(".", initName, initPrefix)
 	if err != nil {
 		return err
@@ -56,3 +84,120 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runInitFrom(source string) error {
+	projects, err := loadInitSource(source)
+	if err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		return fmt.Errorf("no projects found in %s", source)
+	}
+
+	// projects_dir may point outside .tk/, so note which part of it is new
+	// to remove only what this run created if a copy fails
+	projectsPath, err := storage.ResolveProjectsDir(".")
+	if err != nil {
+		return err
+	}
+	newDir := firstMissingDir(projectsPath)
+
+	s, err := storage.InitEmpty(".")
+	if err != nil {
+		return err
+	}
+	var copied []string
+	for _, pf := range projects {
+		if err := ops.ImportProject(s, pf); err != nil {
+			for _, prefix := range copied {
+				s.DeleteProject(prefix)
+			}
+			if newDir != "" {
+				os.RemoveAll(newDir)
+			}
+			os.RemoveAll(s.TkPath())
+			return fmt.Errorf("failed to copy project %s: %w", pf.Prefix, err)
+		}
+		copied = append(copied, pf.Prefix)
+	}
+
+	fmt.Printf("Initialized tk in .tk/\n")
+	for _, pf := range projects {
+		fmt.Printf("Copied project %q with prefix %s (%s, %s)\n", pf.Name, pf.Prefix, cli.Count(len(pf.Tasks), "task"), cli.Count(len(pf.Waits), "wait"))
+	}
+
+	// Point default_project at a copied project unless it already names one
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return err
+	}
+	for _, pf := range projects {
+		if pf.ID == cfg.DefaultProject {
+			return nil
+		}
+	}
+	if err := s.SetConfigValue("default_project", projects[0].ID); err != nil {
+		return err
+	}
+	fmt.Printf("Set default_project to %q in .tkconfig.yaml\n", projects[0].ID)
+	return nil
+}
+
+// firstMissingDir returns the outermost directory on the way to path that
+// doesn't exist yet, which is what creating path would add, or "" if path
+// already exists.
+func firstMissingDir(path string) string {
+	path = filepath.Clean(path)
+	missing := ""
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return missing
+		}
+		missing = path
+		parent := filepath.Dir(path)
+		if parent == path {
+			return missing
+		}
+		path = parent
+	}
+}
+
+// loadInitSource reads the projects to copy for init --from: every project
+// of a tk directory, prepared as for a portable export, or the single project
+// in an exported file.
+func loadInitSource(source string) ([]*model.ProjectFile, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to access %s: %w", source, err)
+	}
+
+	if !info.IsDir() {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", source, err)
+		}
+		pf, err := model.ParseProject(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", source, err)
+		}
+		return []*model.ProjectFile{pf}, nil
+	}
+
+	src, err := storage.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	prefixes, err := src.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	var projects []*model.ProjectFile
+	for _, prefix := range prefixes {
+		pf, err := ops.ExportProject(src, prefix, true)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, pf)
+	}
+	return projects, nil
+}
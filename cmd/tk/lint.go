@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jacksmith/tk/internal/cli"
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Report likely problems with tasks",
+	Long: `Report likely problems with tasks. Output is advisory; nothing is changed.
+
+Checks:
+  --dupes   Open tasks in the same project with near-identical titles
+  --stale   Open tasks not updated in 90 days (snoozed tasks are skipped)
+
+Running without a check flag runs all checks; with one or more, only those
+run.
+
+Examples:
+  tk lint
+  tk lint --dupes -p BY
+  tk lint --stale`,
+	RunE: runLint,
+}
+
+var (
+	lintDupes   bool
+	lintStale   bool
+	lintProject string
+)
+
+func init() {
+	lintCmd.Flags().BoolVar(&lintDupes, "dupes", false, "report likely-duplicate tasks")
+	lintCmd.Flags().BoolVar(&lintStale, "stale", false, "report tasks not updated in 90 days")
+	lintCmd.Flags().StringVarP(&lintProject, "project", "p", "", "limit to a project (prefix or ID)")
+	rootCmd.AddCommand(lintCmd)
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	// Without a check flag, every check runs
+	all := !lintDupes && !lintStale
+
+	issues := 0
+	if all || lintDupes {
+		n, err := lintDuplicates(s)
+		if err != nil {
+			return err
+		}
+		issues += n
+	}
+	if all || lintStale {
+		if issues > 0 {
+			fmt.Println()
+		}
+		n, err := lintStaleTasks(s)
+		if err != nil {
+			return err
+		}
+		issues += n
+	}
+
+	if issues == 0 {
+		fmt.Println(cli.Green("No issues found."))
+	}
+	return nil
+}
+
+// lintDuplicates prints groups of likely-duplicate open tasks with a
+// suggested merge, and returns the number of groups.
+func lintDuplicates(s ops.Store) (int, error) {
+	groups, err := ops.FindDuplicateTasks(s, lintProject)
+	if err != nil {
+		return 0, err
+	}
+	if len(groups) == 0 {
+		return 0, nil
+	}
+
+	fmt.Printf("Found %s of likely duplicates:\n", cli.Count(len(groups), "group"))
+	for _, g := range groups {
+		fmt.Println()
+		ids := make([]string, len(g.Tasks))
+		for i, r := range g.Tasks {
+			ids[i] = r.Task.ID
+			fmt.Printf("  %s  %s\n", r.Task.ID, r.Task.Title)
+		}
+		fmt.Printf("  %s\n", cli.Gray("suggest: tk merge "+strings.Join(ids[:2], " ")))
+	}
+	return len(groups), nil
+}
+
+// lintStaleTasks prints open tasks that haven't changed in a long time, and
+// returns how many there are.
+func lintStaleTasks(s ops.Store) (int, error) {
+	stale, err := ops.FindStaleTasks(s, lintProject, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if len(stale) == 0 {
+		return 0, nil
+	}
+
+	fmt.Printf("Found %s not updated in %d days:\n", cli.Count(len(stale), "stale task"), ops.StaleTaskDays)
+	for _, r := range stale {
+		fmt.Printf("  %s  %s  %s\n", r.Task.ID, r.Task.Title, cli.Gray("last updated "+model.FormatDate(r.Task.Updated)))
+	}
+	fmt.Printf("  %s\n", cli.Gray("suggest: tk drop, or tk defer --soft to push back"))
+	return len(stale), nil
+}
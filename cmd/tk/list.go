@@ -1,14 +1,15 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/jacksmith/tk/internal/cli"
 	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -26,32 +27,80 @@ Filter flags:
   --done        Show only completed tasks
   --dropped     Show only dropped tasks
   --all         Show all tasks regardless of status
+  --no-waiting  Leave out tasks waiting on external conditions
+  --no-blocked  Leave out tasks blocked by other tasks
+                (the --no-* flags combine with each other and the above)
 
   -p, --project Limit to a specific project (by prefix or ID)
   --priority    Filter by priority (1-4)
   --p1/--p2/--p3/--p4  Shorthand for --priority=N
+  --min-priority, --max-priority
+                Filter by a range of priorities, inclusive; e.g.
+                --max-priority=2 shows P1 and P2
   --tag         Filter by tag (can be repeated, requires all tags)
+  --assignee    Show only tasks assigned to a person (case-insensitive);
+                --assignee="" shows only unassigned tasks
   --overdue     Show only tasks with due date in the past
+  --created-after   Show only tasks created on or after a date (YYYY-MM-DD)
+  --created-before  Show only tasks created before a date (YYYY-MM-DD)
+  --created-today   Show only tasks created today
+  --blocked-by  Show only open tasks downstream of a task or wait
+                (transitively; add --direct for one level only)
+  --waiting-on  Show only tasks currently waiting on a specific wait
 
-Tasks are sorted by ID.`,
+Titles are truncated to fit the terminal width ($COLUMNS is used when
+output is not a terminal). Use --full to show titles untruncated.
+
+Output format:
+  --format=table    Aligned table with state and tags (default)
+  --format=oneline  One "ID priority title" line per task, never colored,
+                    suitable for piping into fzf or other selectors
+  --json            A JSON array of {task, state, project} objects sorted
+                    by ID, for scripts; [] when nothing matches
+
+Sorting:
+  --sort=KEY    Order by priority (P1 first), due (soonest first, no due
+                date last), created or updated (oldest first), or id
+  --reverse     Reverse the order (tasks without a due date stay last)
+
+Tasks are sorted by ID unless --sort is given.`,
 	RunE: runList,
 }
 
 var (
-	listProject  string
-	listReady    bool
-	listBlocked  bool
-	listWaiting  bool
-	listDone     bool
-	listDropped  bool
-	listAll      bool
-	listPriority int
-	listP1       bool
-	listP2       bool
-	listP3       bool
-	listP4       bool
-	listTags     []string
-	listOverdue  bool
+	listProject   string
+	listReady     bool
+	listBlocked   bool
+	listWaiting   bool
+	listDone      bool
+	listDropped   bool
+	listAll       bool
+	listPriority  int
+	listP1        bool
+	listP2        bool
+	listP3        bool
+	listP4        bool
+	listTags      []string
+	listAssignee  string
+	listOverdue   bool
+	listFormat    string
+	listBlockedBy string
+	listDirect    bool
+	listWaitingOn string
+	listFull      bool
+	listJSON      bool
+	listSort      string
+	listReverse   bool
+	listSnoozed   bool
+	listNoWaiting bool
+	listNoBlocked bool
+
+	listMinPriority int
+	listMaxPriority int
+
+	listCreatedAfter  string
+	listCreatedBefore string
+	listCreatedToday  bool
 )
 
 func init() {
@@ -62,17 +111,36 @@ func init() {
 	listCmd.Flags().BoolVar(&listDone, "done", false, "show only done tasks")
 	listCmd.Flags().BoolVar(&listDropped, "dropped", false, "show only dropped tasks")
 	listCmd.Flags().BoolVar(&listAll, "all", false, "show all tasks")
+	listCmd.Flags().BoolVar(&listNoWaiting, "no-waiting", false, "exclude waiting tasks")
+	listCmd.Flags().BoolVar(&listNoBlocked, "no-blocked", false, "exclude blocked tasks")
 	listCmd.Flags().IntVar(&listPriority, "priority", 0, "filter by priority (1-4)")
+	listCmd.Flags().IntVar(&listMinPriority, "min-priority", 0, "show only priorities numbered at least N (1-4)")
+	listCmd.Flags().IntVar(&listMaxPriority, "max-priority", 0, "show only priorities numbered at most N (1-4)")
 	listCmd.Flags().BoolVar(&listP1, "p1", false, "shorthand for --priority=1")
 	listCmd.Flags().BoolVar(&listP2, "p2", false, "shorthand for --priority=2")
 	listCmd.Flags().BoolVar(&listP3, "p3", false, "shorthand for --priority=3")
 	listCmd.Flags().BoolVar(&listP4, "p4", false, "shorthand for --priority=4")
 	listCmd.Flags().StringArrayVar(&listTags, "tag", nil, "filter by tag (can be repeated)")
+	listCmd.Flags().StringVar(&listAssignee, "assignee", "", "filter by assignee (\"\" for unassigned tasks)")
 	listCmd.Flags().BoolVar(&listOverdue, "overdue", false, "show only overdue tasks")
+	listCmd.Flags().StringVar(&listCreatedAfter, "created-after", "", "show only tasks created on or after date (YYYY-MM-DD)")
+	listCmd.Flags().StringVar(&listCreatedBefore, "created-before", "", "show only tasks created before date (YYYY-MM-DD)")
+	listCmd.Flags().BoolVar(&listCreatedToday, "created-today", false, "show only tasks created today")
+	listCmd.Flags().StringVar(&listFormat, "format", "table", "output format (table, oneline)")
+	listCmd.Flags().StringVar(&listBlockedBy, "blocked-by", "", "show only tasks downstream of this task or wait")
+	listCmd.Flags().BoolVar(&listDirect, "direct", false, "with --blocked-by, only directly blocked tasks")
+	listCmd.Flags().StringVar(&listWaitingOn, "waiting-on", "", "show only tasks waiting on this wait")
+	listCmd.Flags().BoolVar(&listFull, "full", false, "do not truncate titles")
+	listCmd.Flags().BoolVar(&listJSON, "json", false, "output tasks as a JSON array")
+	listCmd.Flags().StringVar(&listSort, "sort", "", "order tasks by: priority, due, created, updated, id")
+	listCmd.Flags().BoolVar(&listReverse, "reverse", false, "reverse the sort order")
+	listCmd.Flags().BoolVar(&listSnoozed, "snoozed", false, "include tasks soft-deferred with 'tk defer --soft'")
 
 	// Register completion functions
 	listCmd.RegisterFlagCompletionFunc("project", completeProjectIDs)
 	listCmd.RegisterFlagCompletionFunc("tag", completeTags)
+	listCmd.RegisterFlagCompletionFunc("blocked-by", completeAnyIDs)
+	listCmd.RegisterFlagCompletionFunc("waiting-on", completeWaitIDs)
 
 	rootCmd.AddCommand(listCmd)
 }
@@ -81,8 +149,26 @@ func runList(cmd *cobra.Command, args []string) error {
 	if err := validateListStatusFilters(); err != nil {
 		return err
 	}
+	if listFormat != "table" && listFormat != "oneline" {
+		return fmt.Errorf("invalid format: %s (expected table/oneline)", listFormat)
+	}
+	if listJSON && listFormat != "table" {
+		return fmt.Errorf("--json cannot be combined with --format")
+	}
+	if listReverse && listSort == "" {
+		return fmt.Errorf("--reverse requires --sort")
+	}
+	if listDirect && listBlockedBy == "" {
+		return fmt.Errorf("--direct requires --blocked-by")
+	}
+	if listCreatedToday && listCreatedAfter != "" {
+		return fmt.Errorf("cannot use --created-today with --created-after")
+	}
+	if err := validatePriorityRange(); err != nil {
+		return err
+	}
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -96,23 +182,126 @@ func runList(cmd *cobra.Command, args []string) error {
 		Priority: resolvePriorityShorthand(listPriority, listP1, listP2, listP3, listP4),
 		Tags:     listTags,
 		Overdue:  listOverdue,
+		Assignee: listAssignee,
+
+		MinPriority: listMinPriority,
+		MaxPriority: listMaxPriority,
+
+		BlockedBy: listBlockedBy,
+		Direct:    listDirect,
+		WaitingOn: listWaitingOn,
+
+		HideSnoozed: !listSnoozed,
 	}
 	if state := resolveTaskStateFilter(); state != nil {
 		filter.State = state
 	}
+	// An explicitly empty --assignee asks for unassigned work
+	if cmd != nil && cmd.Flags().Changed("assignee") && listAssignee == "" {
+		filter.Unassigned = true
+	}
+	if listNoWaiting {
+		filter.Exclude = append(filter.Exclude, model.TaskStateWaiting)
+	}
+	if listNoBlocked {
+		filter.Exclude = append(filter.Exclude, model.TaskStateBlocked)
+	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if listReady {
+		filter.ReadySoon = time.Duration(cfg.ReadyIncludesSoon) * 24 * time.Hour
+	}
+	if listCreatedToday {
+		today := model.StartOfDay(time.Now())
+		filter.CreatedAfter = &today
+	}
+	if listCreatedAfter != "" {
+		t, err := model.ParseDate(listCreatedAfter)
+		if err != nil {
+			return fmt.Errorf("invalid created-after date (expected YYYY-MM-DD): %v", err)
+		}
+		filter.CreatedAfter = &t
+	}
+	if listCreatedBefore != "" {
+		t, err := model.ParseDate(listCreatedBefore)
+		if err != nil {
+			return fmt.Errorf("invalid created-before date (expected YYYY-MM-DD): %v", err)
+		}
+		filter.CreatedBefore = &t
+	}
 
 	results, err := ops.ListTasks(s, filter)
 	if err != nil {
 		return err
 	}
+	if readySort == "score" {
+		if err := ops.SortByScore(s, results, cfg.ScoreWeights, time.Now()); err != nil {
+			return err
+		}
+	} else if readyLimit > 0 {
+		ops.SortByUrgency(results)
+	}
+	if listSort != "" {
+		if err := ops.SortTasks(results, listSort, listReverse); err != nil {
+			return err
+		}
+	}
+	if readyByProject {
+		return printReadyByProject(s, results)
+	}
+	if readyLimit > 0 {
+		if len(results) > readyLimit {
+			results = results[:readyLimit]
+		}
+	}
+
+	if listJSON {
+		if listSort == "" {
+			ops.SortByID(results)
+		}
+		return printTasksJSON(results)
+	}
+
+	if listFormat == "oneline" {
+		for _, r := range results {
+			fmt.Printf("%s %s %s\n", r.Task.ID, formatPriority(r.Task.Priority), r.Task.Title)
+		}
+		return nil
+	}
 
 	if len(results) == 0 {
 		fmt.Println("No tasks found.")
 		return nil
 	}
+	printTaskTable(results)
+	return nil
+}
+
+// printTasksJSON prints task results as a JSON array. No results print
+// as [].
+func printTasksJSON(results []ops.TaskResult) error {
+	if results == nil {
+		results = []ops.TaskResult{}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
 
+// printTaskTable prints tasks as the ID, state, priority, title, tags table
+// used by tk list.
+func printTaskTable(results []ops.TaskResult) {
 	table := cli.NewTable()
-	table.SetMaxWidth(3, cli.DefaultMaxTitleWidth)
+	if !listFull {
+		if width := cli.TerminalWidth(); width > 0 {
+			table.FitColumn(3, width)
+		} else {
+			table.SetMaxWidth(3, cli.DefaultMaxTitleWidth)
+		}
+	}
 	for _, r := range results {
 		table.AddRow(
 			r.Task.ID,
@@ -123,7 +312,6 @@ func runList(cmd *cobra.Command, args []string) error {
 		)
 	}
 	table.Render(os.Stdout)
-	return nil
 }
 
 // resolveTaskStateFilter maps the boolean status flags to a *model.TaskState.
@@ -170,6 +358,32 @@ func validateListStatusFilters() error {
 	if len(active) > 1 {
 		return fmt.Errorf("conflicting status filters: %s (use only one at a time)", strings.Join(active, ", "))
 	}
+	if listWaiting && listNoWaiting {
+		return fmt.Errorf("conflicting status filters: --waiting, --no-waiting")
+	}
+	if listBlocked && listNoBlocked {
+		return fmt.Errorf("conflicting status filters: --blocked, --no-blocked")
+	}
+	return nil
+}
+
+// validatePriorityRange checks --min-priority and --max-priority, which can't
+// be combined with an exact priority.
+func validatePriorityRange() error {
+	if listMinPriority == 0 && listMaxPriority == 0 {
+		return nil
+	}
+	if resolvePriorityShorthand(listPriority, listP1, listP2, listP3, listP4) > 0 {
+		return fmt.Errorf("cannot combine --priority with --min-priority or --max-priority")
+	}
+	for _, p := range []int{listMinPriority, listMaxPriority} {
+		if p != 0 && (p < ops.MinPriority || p > ops.MaxPriority) {
+			return fmt.Errorf("priority range bounds must be between %d and %d, got %d", ops.MinPriority, ops.MaxPriority, p)
+		}
+	}
+	if listMaxPriority > 0 && listMinPriority > listMaxPriority {
+		return fmt.Errorf("--min-priority %d is greater than --max-priority %d", listMinPriority, listMaxPriority)
+	}
 	return nil
 }
 
@@ -207,7 +421,7 @@ func formatTaskState(state model.TaskState) string {
 }
 
 func formatPriority(p int) string {
-	return fmt.Sprintf("P%d", p)
+	return model.FormatPriority(p)
 }
 
 func formatTags(tags []string) string {
@@ -2,9 +2,13 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -14,6 +18,10 @@ var Version = "dev"
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		var statusErr *ops.ProjectStatusError
+		if errors.As(err, &statusErr) {
+			fmt.Fprintln(os.Stderr, "Use --allow-inactive to change it anyway, or reactivate the project with 'tk project edit <id> --status=active'.")
+		}
 		os.Exit(1)
 	}
 }
@@ -28,6 +36,8 @@ things you're waiting on.
 Tasks live in projects and can be blocked by other tasks or waits.
 Waits represent external conditions outside your control.`,
 	Version: Version,
+	// Apply settings from config before any subcommand runs
+	PersistentPreRunE: applyConfig,
 	// Show help when no subcommand is provided
 	Run: func(cmd *cobra.Command, args []string) {
 		cmd.Help()
@@ -41,3 +51,58 @@ func init() {
 	// Set version template
 	rootCmd.SetVersionTemplate("tk version {{.Version}}\n")
 }
+
+// allowInactive lifts the read-only policy for paused and done projects.
+var allowInactive bool
+
+// allowInactiveFlag registers --allow-inactive on a command that changes
+// items through openStore.
+func allowInactiveFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&allowInactive, "allow-inactive", false, "allow changes to items in paused or done projects")
+}
+
+// openStore opens the store in the current directory. Every command uses it
+// except tk validate and tk doctor, which have to load broken projects to
+// report and repair them. With strict_load configured, projects with
+// structural problems are refused. With --allow-inactive, which only commands
+// that change items register, items in paused and done projects can be
+// changed.
+func openStore() (ops.Store, error) {
+	s, err := storage.Open(".")
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return ops.WithOptions(s, ops.StoreOptions{
+		StrictLoad:    cfg.StrictLoad,
+		AllowInactive: allowInactive,
+	}), nil
+}
+
+// applyConfig loads the settings that apply to every command (date_format,
+// priority_labels, timezone) from .tkconfig.yaml.
+// A missing .tk/ directory or unreadable config is ignored here; commands
+// that need storage report those errors themselves. A bad date_format only
+// affects display, so it is reported as a warning and the default is kept.
+func applyConfig(cmd *cobra.Command, args []string) error {
+	s, err := storage.Open(".")
+	if err != nil {
+		return nil
+	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil
+	}
+	if err := model.SetDateFormat(cfg.DateFormat); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v; using %s\n", err, model.DefaultDateFormat)
+		model.SetDateFormat("")
+	}
+	if err := model.SetTimezone(cfg.Timezone); err != nil {
+		return err
+	}
+	return model.SetPriorityLabels(cfg.PriorityLabels)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <into-id> <from-id>",
+	Short: "Merge a duplicate task into another",
+	Long: `Merge one task into another within the same project.
+
+The second task is folded into the first:
+- Tags and blockers are combined
+- Notes are appended to the first task's notes
+- Items blocked by the second task become blocked by the first
+- The second task is dropped with a "merged into" reason
+
+Examples:
+  tk merge BY-02 BY-03`,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runMerge,
+	ValidArgsFunction: completeTaskIDs,
+}
+
+func init() {
+	allowInactiveFlag(mergeCmd)
+	rootCmd.AddCommand(mergeCmd)
+}
+
+func runMerge(cmd *cobra.Command, args []string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	if err := ops.MergeTasks(s, args[0], args[1]); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s merged into %s.\n", args[1], args[0])
+	return nil
+}
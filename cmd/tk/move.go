@@ -1,10 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -14,39 +14,52 @@ var moveCmd = &cobra.Command{
 	Long: `Move a task to a different project.
 
 The task cannot have blockers or dependents in the source project.
-The task will get a new ID in the destination project.
+The task will get a new ID in the destination project, unless --keep-id
+is given, in which case the numeric part of the ID is preserved (an error
+is returned if that number is already in use in the destination).
 
 Examples:
   tk move BY-07 --to=HH
-  tk move BY-07 --to=household`,
+  tk move BY-07 --to=household
+  tk move BY-07 --to=HH --keep-id`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runMove,
 	ValidArgsFunction: completeTaskIDs,
 }
 
-var moveTo string
+var (
+	moveTo     string
+	moveKeepID bool
+)
 
 func init() {
 	moveCmd.Flags().StringVar(&moveTo, "to", "", "destination project prefix or ID")
 	moveCmd.MarkFlagRequired("to")
 	moveCmd.RegisterFlagCompletionFunc("to", completeProjectIDs)
+	moveCmd.Flags().BoolVar(&moveKeepID, "keep-id", false, "preserve the numeric ID in the destination project")
+	allowInactiveFlag(moveCmd)
 	rootCmd.AddCommand(moveCmd)
 }
 
 func runMove(cmd *cobra.Command, args []string) error {
 	taskID := args[0]
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
 
 	destPf, err := ops.ResolveProject(s, moveTo)
 	if err != nil {
-		return fmt.Errorf("destination project %q not found", moveTo)
+		// Ambiguous references keep their own message listing the matches
+		var notFound *ops.NotFoundError
+		if errors.As(err, &notFound) {
+			return fmt.Errorf("destination %w", err)
+		}
+		return err
 	}
 
-	if err := ops.MoveTask(s, taskID, destPf.Prefix); err != nil {
+	if err := ops.MoveTask(s, taskID, destPf.Prefix, moveKeepID); err != nil {
 		return err
 	}
 
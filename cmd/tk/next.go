@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Show the one ready task to do next",
+	Long: `Show the single most important ready task, in the same format as
+'tk show'.
+
+The task is the first of 'tk ready --sort=score --limit 1': the ready task
+with the highest score, combining priority, due-date urgency, and how many
+open items it blocks (weighted by score_weights in .tkconfig.yaml).
+
+Without -p, all active projects are considered, as with 'tk ready'.
+
+Examples:
+  tk next
+  tk next -p backyard`,
+	Args: cobra.NoArgs,
+	RunE: runNext,
+}
+
+var nextProject string
+
+func init() {
+	nextCmd.Flags().StringVarP(&nextProject, "project", "p", "", "limit to a project (prefix or ID)")
+	nextCmd.RegisterFlagCompletionFunc("project", completeProjectIDs)
+	rootCmd.AddCommand(nextCmd)
+}
+
+func runNext(cmd *cobra.Command, args []string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	ops.AutoCheck(s)
+
+	next, err := ops.NextTask(s, nextProject)
+	if err != nil {
+		return err
+	}
+	if next == nil {
+		fmt.Println("Nothing ready.")
+		return nil
+	}
+	return showTask(s, next.Task.ID)
+}
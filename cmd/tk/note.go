@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +25,7 @@ Examples:
 }
 
 func init() {
+	allowInactiveFlag(noteCmd)
 	rootCmd.AddCommand(noteCmd)
 }
 
@@ -33,7 +33,7 @@ func runNote(cmd *cobra.Command, args []string) error {
 	taskID := args[0]
 	text := strings.Join(args[1:], " ")
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jacksmith/tk/internal/cli"
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/jacksmith/tk/internal/ops"
+)
+
+// pickInput is where interactive pickers read the selection from.
+var pickInput io.Reader = os.Stdin
+
+// pickTask lists open tasks whose state is accepted by eligible and prompts
+// for one of them. A nil eligible accepts every open task.
+func pickTask(s ops.Store, eligible func(model.TaskState) bool) (string, error) {
+	results, err := ops.ListTasks(s, ops.TaskFilter{})
+	if err != nil {
+		return "", err
+	}
+
+	var options []cli.PickOption
+	for _, r := range results {
+		if eligible != nil && !eligible(r.State) {
+			continue
+		}
+		options = append(options, taskPickOption(r))
+	}
+	if len(options) == 0 {
+		return "", fmt.Errorf("no eligible tasks to pick from")
+	}
+
+	return cli.Pick(pickInput, os.Stdout, options)
+}
+
+// pickAnyItem lists open tasks and open waits and prompts for one of them.
+func pickAnyItem(s ops.Store) (string, error) {
+	tasks, err := ops.ListTasks(s, ops.TaskFilter{})
+	if err != nil {
+		return "", err
+	}
+	waits, err := ops.ListWaits(s, ops.WaitFilter{})
+	if err != nil {
+		return "", err
+	}
+
+	var options []cli.PickOption
+	for _, r := range tasks {
+		options = append(options, taskPickOption(r))
+	}
+	for _, r := range waits {
+		options = append(options, cli.PickOption{
+			ID:    r.Wait.ID,
+			Label: fmt.Sprintf("%s %s", formatWaitState(r.State), r.Wait.DisplayText()),
+		})
+	}
+	if len(options) == 0 {
+		return "", fmt.Errorf("no open items to pick from")
+	}
+
+	return cli.Pick(pickInput, os.Stdout, options)
+}
+
+// resolvePickedID returns the ID argument, or prompts with pick when no ID
+// was given or --pick was set.
+func resolvePickedID(args []string, pickFlag bool, pick func() (string, error)) (string, error) {
+	if len(args) > 0 {
+		if pickFlag {
+			return "", fmt.Errorf("cannot combine --pick with an explicit ID")
+		}
+		return args[0], nil
+	}
+	return pick()
+}
+
+func taskPickOption(r ops.TaskResult) cli.PickOption {
+	return cli.PickOption{
+		ID:    r.Task.ID,
+		Label: fmt.Sprintf("%s %s %s", formatTaskState(r.State), formatPriority(r.Task.Priority), r.Task.Title),
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "List open tasks in an order that respects their blockers",
+	Long: `List a project's open tasks in an order you can work through from top
+to bottom: every task comes after the tasks blocking it, so finishing them
+in order never hits an incomplete blocker. Where the order is free, higher
+priority comes first, then lower ID.
+
+Waits don't affect the order, since there is nothing to do for them; a
+task held up by a wait is listed as waiting.
+
+Without -p, the default_project from .tkconfig.yaml is used.
+
+Examples:
+  tk plan -p BY
+  tk plan -p backyard`,
+	Args: cobra.NoArgs,
+	RunE: runPlan,
+}
+
+var planProject string
+
+func init() {
+	planCmd.Flags().StringVarP(&planProject, "project", "p", "", "project (prefix or ID)")
+	planCmd.RegisterFlagCompletionFunc("project", completeProjectIDs)
+	rootCmd.AddCommand(planCmd)
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	ops.AutoCheck(s)
+
+	results, err := ops.PlanTasks(s, planProject)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		fmt.Println("No open tasks.")
+		return nil
+	}
+
+	printTaskTable(results)
+	return nil
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/jacksmith/tk/internal/cli"
 	"github.com/jacksmith/tk/internal/model"
@@ -22,8 +24,12 @@ The project can be specified by its ID (e.g., "backyard") or prefix (e.g., "BY")
 Output shows counts of open tasks (broken down by ready, blocked, waiting),
 done tasks, dropped tasks, and waits.
 
+Use --history to add a table of tasks completed per month, from the first
+month with a completion to the last.
+
 Subcommands:
   new      Create a new project
+  copy     Copy a project's tasks and waits under a new prefix
   edit     Edit an existing project
   delete   Delete a project`,
 	Args:              cobra.ExactArgs(1),
@@ -39,6 +45,10 @@ var projectNewCmd = &cobra.Command{
 The --prefix and --name flags are required. The positional id argument is
 optional; if omitted, the project id is derived from the prefix (lowercased).
 
+A prefix one letter away from an existing one (BV when BY exists) makes
+mistyped IDs land in the wrong project, so it prints a warning. With
+--strict it is an error instead.
+
 Examples:
   tk project new --prefix=BY --name="Backyard Redo"
   tk project new backyard --prefix=BY --name="Backyard Redo"
@@ -47,6 +57,24 @@ Examples:
 	RunE: runProjectNew,
 }
 
+var projectCopyCmd = &cobra.Command{
+	Use:   "copy <id> <new-id>",
+	Short: "Copy a project under a new prefix",
+	Long: `Copy a project's tasks, waits, and dependencies into a new project.
+
+Items keep their numbers under the new prefix (BY-07 becomes BZ-07), and
+blockers within the project point at the copies. Blockers in other projects
+are left as they are. Use --reset-status to reopen everything, for example
+to reuse last year's project as a template.
+
+Examples:
+  tk project copy backyard backyard-2026 --prefix=BZ
+  tk project copy backyard backyard-2026 --prefix=BZ --name="Backyard 2026" --reset-status`,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runProjectCopy,
+	ValidArgsFunction: completeProjectIDs,
+}
+
 var projectEditCmd = &cobra.Command{
 	Use:   "edit <id>",
 	Short: "Edit a project",
@@ -57,13 +85,33 @@ Use flags to change specific fields, or -i to edit in $EDITOR.
 Examples:
   tk project edit backyard --name="New Name"
   tk project edit backyard --status=paused
+  tk project edit backyard --default-assignee=alice
+  tk project edit backyard --notes="Waiting on permits until spring"
   tk project edit backyard --prefix=NW    # triggers ID migration
+  tk project edit p1 --id=backyard        # rename the project ID
   tk project edit backyard -i`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runProjectEdit,
 	ValidArgsFunction: completeProjectIDs,
 }
 
+var projectCompleteCmd = &cobra.Command{
+	Use:   "complete <id>",
+	Short: "Mark a project done",
+	Long: `Mark a project done once all its tasks and waits are resolved.
+
+If any tasks or waits are still open, nothing changes and they are listed.
+Use --force to drop them (with reason "project completed") and close the
+project anyway.
+
+Examples:
+  tk project complete backyard
+  tk project complete backyard --force`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runProjectComplete,
+	ValidArgsFunction: completeProjectIDs,
+}
+
 var projectDeleteCmd = &cobra.Command{
 	Use:   "delete <id>",
 	Short: "Delete a project",
@@ -82,31 +130,58 @@ var (
 	projectNewPrefix      string
 	projectNewName        string
 	projectNewDescription string
+	projectNewStrict      bool
 
-	projectEditName        string
-	projectEditDescription string
-	projectEditStatus      string
-	projectEditPrefix      string
-	projectEditInteractive bool
+	projectCopyPrefix      string
+	projectCopyName        string
+	projectCopyResetStatus bool
+
+	projectEditName            string
+	projectEditDescription     string
+	projectEditStatus          string
+	projectEditPrefix          string
+	projectEditID              string
+	projectEditDefaultAssignee string
+	projectEditNotes           string
+	projectEditInteractive     bool
+
+	projectCompleteForce bool
 
 	projectDeleteForce bool
+
+	projectHistory bool
 )
 
 func init() {
+	projectCmd.Flags().BoolVar(&projectHistory, "history", false, "show tasks completed per month")
+
 	projectNewCmd.Flags().StringVar(&projectNewPrefix, "prefix", "", "project prefix (2-3 uppercase letters)")
 	projectNewCmd.Flags().StringVar(&projectNewName, "name", "", "project display name")
 	projectNewCmd.Flags().StringVar(&projectNewDescription, "description", "", "project description")
+	projectNewCmd.Flags().BoolVar(&projectNewStrict, "strict", false, "refuse a prefix one letter away from an existing one")
 	projectNewCmd.MarkFlagRequired("prefix")
 	projectNewCmd.MarkFlagRequired("name")
 	projectCmd.AddCommand(projectNewCmd)
 
+	projectCopyCmd.Flags().StringVar(&projectCopyPrefix, "prefix", "", "prefix for the copy (2-3 uppercase letters)")
+	projectCopyCmd.Flags().StringVar(&projectCopyName, "name", "", "display name for the copy (default: the source's name)")
+	projectCopyCmd.Flags().BoolVar(&projectCopyResetStatus, "reset-status", false, "reopen done and dropped tasks and waits")
+	projectCopyCmd.MarkFlagRequired("prefix")
+	projectCmd.AddCommand(projectCopyCmd)
+
 	projectEditCmd.Flags().StringVar(&projectEditName, "name", "", "set project name")
 	projectEditCmd.Flags().StringVar(&projectEditDescription, "description", "", "set project description")
 	projectEditCmd.Flags().StringVar(&projectEditStatus, "status", "", "set project status (active/paused/done)")
 	projectEditCmd.Flags().StringVar(&projectEditPrefix, "prefix", "", "change project prefix (triggers ID migration)")
+	projectEditCmd.Flags().StringVar(&projectEditID, "id", "", "change project ID (updates default_project if it refers to this project)")
+	projectEditCmd.Flags().StringVar(&projectEditDefaultAssignee, "default-assignee", "", "set default assignee for new tasks (empty to clear)")
+	projectEditCmd.Flags().StringVar(&projectEditNotes, "notes", "", "set project notes (empty to clear)")
 	projectEditCmd.Flags().BoolVarP(&projectEditInteractive, "interactive", "i", false, "edit in $EDITOR")
 	projectCmd.AddCommand(projectEditCmd)
 
+	projectCompleteCmd.Flags().BoolVar(&projectCompleteForce, "force", false, "drop open tasks and waits")
+	projectCmd.AddCommand(projectCompleteCmd)
+
 	projectDeleteCmd.Flags().BoolVar(&projectDeleteForce, "force", false, "confirm deletion")
 	projectCmd.AddCommand(projectDeleteCmd)
 
@@ -114,12 +189,16 @@ func init() {
 }
 
 func runProject(cmd *cobra.Command, args []string) error {
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
 
-	summary, err := ops.GetProjectSummary(s, args[0])
+	pf, err := ops.LookupProject(s, args[0])
+	if err != nil {
+		return err
+	}
+	summary, err := ops.GetProjectSummary(s, pf.Prefix)
 	if err != nil {
 		return err
 	}
@@ -129,10 +208,13 @@ func runProject(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s\n", summary.Project.Description)
 	}
 	fmt.Printf("Status: %s\n", summary.Project.Status)
+	if summary.Project.DefaultAssignee != "" {
+		fmt.Printf("Default assignee: %s\n", summary.Project.DefaultAssignee)
+	}
 	fmt.Println()
 
+	fmt.Print(cli.CountOrNo(summary.OpenCount, "open task"))
 	if summary.OpenCount > 0 {
-		fmt.Printf("%d open", summary.OpenCount)
 		var details []string
 		if summary.ReadyCount > 0 {
 			details = append(details, fmt.Sprintf("%d ready", summary.ReadyCount))
@@ -146,8 +228,6 @@ func runProject(cmd *cobra.Command, args []string) error {
 		if len(details) > 0 {
 			fmt.Printf(" (%s)", strings.Join(details, ", "))
 		}
-	} else {
-		fmt.Printf("0 open")
 	}
 	fmt.Printf(", %d done", summary.DoneCount)
 	if summary.DroppedCount > 0 {
@@ -156,39 +236,129 @@ func runProject(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	if summary.OpenWaits > 0 || summary.DoneWaits > 0 || summary.DroppedWaits > 0 {
-		fmt.Printf("%d waits", summary.OpenWaits)
+		fmt.Print(cli.CountOrNo(summary.OpenWaits, "open wait"))
 		if summary.DoneWaits > 0 {
 			fmt.Printf(" (%d resolved)", summary.DoneWaits)
 		}
 		fmt.Println()
 	}
 
+	if summary.Project.Notes != "" {
+		fmt.Println()
+		fmt.Println("Notes:")
+		for _, line := range strings.Split(summary.Project.Notes, "\n") {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+
+	if projectHistory {
+		pf, err := s.LoadProject(summary.Project.Prefix)
+		if err != nil {
+			return err
+		}
+		printCompletionHistory(pf)
+	}
+
 	return nil
 }
 
+// printCompletionHistory prints a table of tasks completed per month. Months
+// with no completions between the first and last are included so gaps in
+// progress show up.
+func printCompletionHistory(pf *model.ProjectFile) {
+	counts := make(map[string]int)
+	var first, last time.Time
+	for _, t := range pf.Tasks {
+		if t.Status != model.TaskStatusDone || t.DoneAt == nil {
+			continue
+		}
+		doneAt := t.DoneAt.In(model.Timezone())
+		month := time.Date(doneAt.Year(), doneAt.Month(), 1, 0, 0, 0, 0, model.Timezone())
+		counts[month.Format("2006-01")]++
+		if first.IsZero() || month.Before(first) {
+			first = month
+		}
+		if month.After(last) {
+			last = month
+		}
+	}
+
+	fmt.Println()
+	if len(counts) == 0 {
+		fmt.Println("No completed tasks yet.")
+		return
+	}
+
+	fmt.Println("Completed per month:")
+	table := cli.NewTable()
+	for month := first; !month.After(last); month = month.AddDate(0, 1, 0) {
+		n := counts[month.Format("2006-01")]
+		table.AddRow("  "+month.Format("2006-01"), fmt.Sprintf("%d", n), strings.Repeat("#", n))
+	}
+	table.Render(os.Stdout)
+}
+
 func runProjectNew(cmd *cobra.Command, args []string) error {
 	projectID := ""
 	if len(args) > 0 {
 		projectID = args[0]
 	}
 
-	s, err := storage.Open(".")
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	similar, err := ops.SimilarPrefixes(s, projectNewPrefix)
 	if err != nil {
 		return err
 	}
+	if len(similar) > 0 && projectNewStrict {
+		return fmt.Errorf("prefix %q is too close to existing %s", strings.ToUpper(projectNewPrefix), strings.Join(similar, ", "))
+	}
 
 	if err := ops.CreateProject(s, projectID, projectNewPrefix, projectNewName, projectNewDescription); err != nil {
 		return err
 	}
 
 	fmt.Printf("Created project %s (%s).\n", projectNewPrefix, projectNewName)
+	if len(similar) > 0 {
+		fmt.Printf("Warning: prefix %s is one letter away from %s; IDs are easy to mistype.\n",
+			strings.ToUpper(projectNewPrefix), strings.Join(similar, ", "))
+	}
+	return nil
+}
+
+func runProjectCopy(cmd *cobra.Command, args []string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	src, err := ops.ResolveProject(s, args[0])
+	if err != nil {
+		return err
+	}
+
+	pf, err := ops.CopyProject(s, src.Prefix, ops.CopyOptions{
+		ID:          args[1],
+		Prefix:      projectCopyPrefix,
+		Name:        projectCopyName,
+		ResetStatus: projectCopyResetStatus,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Copied %s to %s (%s, %s).\n", src.Prefix, pf.Prefix,
+		cli.Count(len(pf.Tasks), "task"), cli.Count(len(pf.Waits), "wait"))
 	return nil
 }
 
 func runProjectEdit(cmd *cobra.Command, args []string) error {
 	projectRef := args[0]
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -211,6 +381,22 @@ func runProjectEdit(cmd *cobra.Command, args []string) error {
 		prefix = strings.ToUpper(projectEditPrefix)
 	}
 
+	if cmd.Flags().Changed("id") && !strings.EqualFold(projectEditID, pf.ID) {
+		// default_project may name the old ID, so the config is opened too
+		cw, err := storage.Open(".")
+		if err != nil {
+			return err
+		}
+		configUpdated, err := ops.RenameProjectID(s, cw, prefix, projectEditID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Project ID changed from %s to %s.\n", pf.ID, strings.ToLower(strings.TrimSpace(projectEditID)))
+		if configUpdated {
+			fmt.Println("Updated default_project in .tkconfig.yaml.")
+		}
+	}
+
 	changes := ops.ProjectChanges{}
 	hasChanges := false
 
@@ -232,6 +418,14 @@ func runProjectEdit(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("invalid status: %s (expected active/paused/done)", projectEditStatus)
 		}
 	}
+	if cmd.Flags().Changed("default-assignee") {
+		changes.DefaultAssignee = &projectEditDefaultAssignee
+		hasChanges = true
+	}
+	if cmd.Flags().Changed("notes") {
+		changes.Notes = &projectEditNotes
+		hasChanges = true
+	}
 
 	if hasChanges {
 		if err := ops.EditProject(s, prefix, changes); err != nil {
@@ -239,7 +433,7 @@ func runProjectEdit(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if !hasChanges && !cmd.Flags().Changed("prefix") {
+	if !hasChanges && !cmd.Flags().Changed("prefix") && !cmd.Flags().Changed("id") {
 		return fmt.Errorf("no changes specified")
 	}
 
@@ -248,16 +442,20 @@ func runProjectEdit(cmd *cobra.Command, args []string) error {
 }
 
 type editableProject struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description,omitempty"`
-	Status      string `yaml:"status"`
+	Name            string `yaml:"name"`
+	Description     string `yaml:"description,omitempty"`
+	Status          string `yaml:"status"`
+	DefaultAssignee string `yaml:"default_assignee,omitempty"`
+	Notes           string `yaml:"notes,omitempty"`
 }
 
 func runProjectEditInteractive(s ops.Store, pf *model.ProjectFile) error {
 	editable := editableProject{
-		Name:        pf.Name,
-		Description: pf.Description,
-		Status:      string(pf.Status),
+		Name:            pf.Name,
+		Description:     pf.Description,
+		Status:          string(pf.Status),
+		DefaultAssignee: pf.DefaultAssignee,
+		Notes:           pf.Notes,
 	}
 
 	content, err := yaml.Marshal(&editable)
@@ -285,6 +483,12 @@ func runProjectEditInteractive(s ops.Store, pf *model.ProjectFile) error {
 	if newEditable.Description != pf.Description {
 		changes.Description = &newEditable.Description
 	}
+	if newEditable.DefaultAssignee != pf.DefaultAssignee {
+		changes.DefaultAssignee = &newEditable.DefaultAssignee
+	}
+	if newEditable.Notes != pf.Notes {
+		changes.Notes = &newEditable.Notes
+	}
 	if newEditable.Status != string(pf.Status) {
 		status := model.ProjectStatus(newEditable.Status)
 		switch status {
@@ -303,10 +507,33 @@ func runProjectEditInteractive(s ops.Store, pf *model.ProjectFile) error {
 	return nil
 }
 
+func runProjectComplete(cmd *cobra.Command, args []string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	pf, err := ops.ResolveProject(s, args[0])
+	if err != nil {
+		return err
+	}
+
+	dropped, err := ops.CompleteProject(s, pf.Prefix, projectCompleteForce)
+	if err != nil {
+		return err
+	}
+
+	if len(dropped) > 0 {
+		fmt.Printf("Dropped %s: %s\n", cli.Count(len(dropped), "open item"), strings.Join(dropped, ", "))
+	}
+	fmt.Printf("Project %s marked done.\n", pf.Prefix)
+	return nil
+}
+
 func runProjectDelete(cmd *cobra.Command, args []string) error {
 	projectRef := args[0]
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -332,18 +559,10 @@ func runProjectDelete(cmd *cobra.Command, args []string) error {
 		if openTasks > 0 || openWaits > 0 {
 			parts := []string{}
 			if openTasks > 0 {
-				noun := "task"
-				if openTasks != 1 {
-					noun = "tasks"
-				}
-				parts = append(parts, fmt.Sprintf("%d open %s", openTasks, noun))
+				parts = append(parts, cli.Count(openTasks, "open task"))
 			}
 			if openWaits > 0 {
-				noun := "wait"
-				if openWaits != 1 {
-					noun = "waits"
-				}
-				parts = append(parts, fmt.Sprintf("%d open %s", openWaits, noun))
+				parts = append(parts, cli.Count(openWaits, "open wait"))
 			}
 			return fmt.Errorf("project %s has %s (use --force to delete anyway)", pf.Prefix, strings.Join(parts, " and "))
 		}
@@ -3,11 +3,11 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/jacksmith/tk/internal/cli"
 	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -17,23 +17,36 @@ var projectsCmd = &cobra.Command{
 	Long: `List all projects in the tk repository.
 
 By default, only active projects are shown.
-Use --all to include paused and done projects.`,
+Use --all to include paused and done projects.
+
+--tree shows how projects depend on each other, derived from blockers that
+point into another project. Each project is listed under the projects it
+waits on, with the number of its items blocked from there; projects that
+depend on nothing are at the top level.`,
 	RunE: runProjects,
 }
 
-var projectsAll bool
+var (
+	projectsAll  bool
+	projectsTree bool
+)
 
 func init() {
 	projectsCmd.Flags().BoolVar(&projectsAll, "all", false, "include paused and done projects")
+	projectsCmd.Flags().BoolVar(&projectsTree, "tree", false, "show which projects depend on which")
 	rootCmd.AddCommand(projectsCmd)
 }
 
 func runProjects(cmd *cobra.Command, args []string) error {
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
 
+	if projectsTree {
+		return runProjectsTree(s)
+	}
+
 	infos, err := ops.ListProjectInfos(s, projectsAll)
 	if err != nil {
 		return err
@@ -52,6 +65,67 @@ func runProjects(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runProjectsTree prints the project-level dependency graph as a tree, each
+// project nested under the projects it depends on.
+func runProjectsTree(s ops.Store) error {
+	projects, err := ops.LoadActiveProjects(s, projectsAll)
+	if err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		fmt.Println("No projects found.")
+		return nil
+	}
+
+	byPrefix := make(map[string]*model.ProjectFile)
+	for _, pf := range projects {
+		byPrefix[pf.Prefix] = pf
+	}
+
+	// dependents maps a project to the shown projects blocked by it
+	dependents := make(map[string][]ops.ProjectDependency)
+	hasDeps := make(map[string]bool)
+	for _, dep := range ops.ProjectDependencies(projects) {
+		if byPrefix[dep.DependsOn] == nil {
+			continue
+		}
+		dependents[dep.DependsOn] = append(dependents[dep.DependsOn], dep)
+		hasDeps[dep.Project] = true
+	}
+
+	printed := make(map[string]bool)
+	onPath := make(map[string]bool)
+	var printNode func(prefix, suffix string, depth int)
+	printNode = func(prefix, suffix string, depth int) {
+		pf := byPrefix[prefix]
+		indent := strings.Repeat("  ", depth)
+		if onPath[prefix] {
+			fmt.Printf("%s%s  %s (cycle)\n", indent, pf.Prefix, pf.Name)
+			return
+		}
+		fmt.Printf("%s%s  %s%s\n", indent, pf.Prefix, pf.Name, suffix)
+		printed[prefix] = true
+		onPath[prefix] = true
+		for _, dep := range dependents[prefix] {
+			printNode(dep.Project, fmt.Sprintf(" (%s blocked)", cli.Count(dep.Count, "item")), depth+1)
+		}
+		delete(onPath, prefix)
+	}
+
+	for _, pf := range projects {
+		if !hasDeps[pf.Prefix] {
+			printNode(pf.Prefix, "", 0)
+		}
+	}
+	// Projects caught in a cycle have no root to hang from
+	for _, pf := range projects {
+		if !printed[pf.Prefix] {
+			printNode(pf.Prefix, "", 0)
+		}
+	}
+	return nil
+}
+
 func formatProjectStatus(status model.ProjectStatus) string {
 	switch status {
 	case model.ProjectStatusActive:
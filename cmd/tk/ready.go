@@ -1,6 +1,10 @@
 package main
 
 import (
+	"fmt"
+	"sort"
+
+	"github.com/jacksmith/tk/internal/ops"
 	"github.com/spf13/cobra"
 )
 
@@ -11,16 +15,100 @@ var readyCmd = &cobra.Command{
 
 This is an alias for 'tk list --ready'.
 
-Ready tasks are open tasks with no incomplete blockers.`,
+Ready tasks are open tasks with no incomplete blockers.
+
+With ready_includes_soon set in .tkconfig.yaml (a number of days), tasks
+whose only open blockers are time waits, or manual waits with a check-after
+date, falling within that many days are listed too. They keep their
+"waiting" state so they stand out.
+
+Use --limit N to plan your next few tasks: the ready list is sorted by
+priority, then due date (tasks without one last), then creation time, and
+only the first N are shown. The order is deterministic, so the same tasks
+are shown until you complete them.
+
+Use --sort=score to rank tasks by a weighted score instead, combining
+priority, due-date urgency (rising over the two weeks before the due date),
+and how many open items each task blocks. The weights are set with
+score_weights in .tkconfig.yaml. --limit then keeps the top N by score.
+
+Use --by-project to group the ready tasks under a header per project, with
+a count, so small projects aren't buried under big ones. Groups are sorted
+by prefix, and --limit then keeps the top N of each project.
+
+Use --json to print the tasks as a JSON array sorted by ID, as with
+'tk list --json'.
+
+Examples:
+  tk ready
+  tk ready --limit 3
+  tk ready --sort=score --limit 5
+  tk ready --by-project --limit 2
+  tk ready --json`,
 	RunE: runReady,
 }
 
+var (
+	readyLimit     int
+	readySort      string
+	readyByProject bool
+)
+
 func init() {
+	readyCmd.Flags().IntVar(&readyLimit, "limit", 0, "show only the top N tasks by priority, due date, and age")
+	readyCmd.Flags().StringVar(&readySort, "sort", "", "order tasks by: score")
+	readyCmd.Flags().BoolVar(&readyByProject, "by-project", false, "group tasks under a header per project")
+	readyCmd.Flags().BoolVar(&listJSON, "json", false, "output tasks as a JSON array")
+	readyCmd.MarkFlagsMutuallyExclusive("by-project", "json")
+
 	rootCmd.AddCommand(readyCmd)
 }
 
 func runReady(cmd *cobra.Command, args []string) error {
+	if readyLimit < 0 {
+		return fmt.Errorf("--limit must be positive, got %d", readyLimit)
+	}
+	if readySort != "" && readySort != "score" {
+		return fmt.Errorf("invalid sort: %s (expected score)", readySort)
+	}
+
 	// Set the ready flag and delegate to list
 	listReady = true
 	return runList(cmd, args)
 }
+
+// printReadyByProject prints ready tasks grouped by project, sorted by
+// prefix. Within a group tasks keep their order; --limit caps each group.
+func printReadyByProject(s ops.Store, results []ops.TaskResult) error {
+	if len(results) == 0 {
+		fmt.Println("No tasks found.")
+		return nil
+	}
+
+	groups := make(map[string][]ops.TaskResult)
+	for _, r := range results {
+		groups[r.Project] = append(groups[r.Project], r)
+	}
+	prefixes := make([]string, 0, len(groups))
+	for prefix := range groups {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	for i, prefix := range prefixes {
+		pf, err := s.LoadProject(prefix)
+		if err != nil {
+			return err
+		}
+		tasks := groups[prefix]
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s: %s (%d ready)\n", pf.Prefix, pf.Name, len(tasks))
+		if readyLimit > 0 && len(tasks) > readyLimit {
+			tasks = tasks[:readyLimit]
+		}
+		printTaskTable(tasks)
+	}
+	return nil
+}
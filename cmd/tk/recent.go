@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jacksmith/tk/internal/cli"
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var recentCmd = &cobra.Command{
+	Use:   "recent",
+	Short: "List recently completed tasks",
+	Long: `List tasks completed within the last hour, most recent first.
+
+Use this after a batch of tk done to catch a task completed by mistake.
+--since widens or narrows the window. --reopen reopens the most recently
+completed task, undoing the last tk done.
+
+Examples:
+  tk recent
+  tk recent --since=30m
+  tk recent -p BY --since=24h
+  tk recent --since=2d
+  tk recent --reopen`,
+	Args: cobra.NoArgs,
+	RunE: runRecent,
+}
+
+var (
+	recentProject string
+	recentSince   string
+	recentReopen  bool
+)
+
+func init() {
+	recentCmd.Flags().StringVarP(&recentProject, "project", "p", "", "filter by project (prefix or ID)")
+	recentCmd.RegisterFlagCompletionFunc("project", completeProjectIDs)
+	recentCmd.Flags().StringVar(&recentSince, "since", "1h", "how far back to look (e.g. 30m, 2h, 1d, 2w)")
+	recentCmd.Flags().BoolVar(&recentReopen, "reopen", false, "reopen the most recently completed task")
+	allowInactiveFlag(recentCmd)
+	rootCmd.AddCommand(recentCmd)
+}
+
+// parseRecentSince reads --since as a Go duration (30m, 1h30m), falling back
+// to the day and week units the other commands accept (1d, 2w).
+func parseRecentSince(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	if d, err := cli.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid duration %q: use e.g. 30m, 2h, 1d, or 2w", s)
+}
+
+func runRecent(cmd *cobra.Command, args []string) error {
+	window, err := parseRecentSince(recentSince)
+	if err != nil {
+		return err
+	}
+	if window <= 0 {
+		return fmt.Errorf("--since must be positive, got %s", recentSince)
+	}
+
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	doneState := model.TaskStateDone
+	since := time.Now().Add(-window)
+	results, err := ops.ListTasks(s, ops.TaskFilter{
+		Project:   recentProject,
+		State:     &doneState,
+		DoneAfter: &since,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		fmt.Printf("No tasks completed in the last %s.\n", recentSince)
+		return nil
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Task.DoneAt.After(*results[j].Task.DoneAt)
+	})
+
+	if recentReopen {
+		return reopenRecent(results[0].Task.ID)
+	}
+
+	table := cli.NewTable()
+	if width := cli.TerminalWidth(); width > 0 {
+		table.FitColumn(2, width)
+	} else {
+		table.SetMaxWidth(2, cli.DefaultMaxTitleWidth)
+	}
+	for _, r := range results {
+		table.AddRow(
+			r.Task.ID,
+			model.FormatDateTime(*r.Task.DoneAt),
+			r.Task.Title,
+		)
+	}
+	table.Render(os.Stdout)
+	fmt.Printf("\nReopen with 'tk reopen <id>', or 'tk recent --reopen' for %s.\n", results[0].Task.ID)
+	return nil
+}
+
+// reopenRecent reopens a task listed by tk recent, going through the same
+// store wrapper as tk reopen so --allow-inactive applies.
+func reopenRecent(taskID string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	result, err := ops.ReopenTask(s, taskID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s reopened.\n", taskID)
+	if len(result.InconsistentDependents) > 0 {
+		fmt.Printf("Warning: done items depend on %s: %s\n", taskID, strings.Join(result.InconsistentDependents, ", "))
+	}
+	return nil
+}
@@ -2,9 +2,9 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -14,7 +14,8 @@ var reopenCmd = &cobra.Command{
 	Long: `Reopen a task that was previously completed or dropped.
 
 Sets the status back to open and clears done_at, dropped_at, and drop_reason.
-Does not affect dependent items.
+Does not affect dependent items, but warns about dependents that are already
+done, since they now depend on an open task.
 
 Examples:
   tk reopen BY-07`,
@@ -24,21 +25,26 @@ Examples:
 }
 
 func init() {
+	allowInactiveFlag(reopenCmd)
 	rootCmd.AddCommand(reopenCmd)
 }
 
 func runReopen(cmd *cobra.Command, args []string) error {
 	taskID := args[0]
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
 
-	if err := ops.ReopenTask(s, taskID); err != nil {
+	result, err := ops.ReopenTask(s, taskID)
+	if err != nil {
 		return err
 	}
 
 	fmt.Printf("%s reopened.\n", taskID)
+	if len(result.InconsistentDependents) > 0 {
+		fmt.Printf("Warning: done items depend on %s: %s\n", taskID, strings.Join(result.InconsistentDependents, ", "))
+	}
 	return nil
 }
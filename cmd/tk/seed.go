@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jacksmith/tk/internal/cli"
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var seedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Fill a project with synthetic tasks for benchmarks and demos",
+	Long: `Fill a project with plausible synthetic tasks, waits, and dependencies.
+
+Meant for benchmarking list, graph, and check on realistic amounts of data,
+and for demos. The same --seed always produces the same items, so runs are
+reproducible. Seed into a scratch directory: the items are real and are
+saved to the project.
+
+Examples:
+  tk seed --tasks=500
+  tk seed -p BY --tasks=2000 --seed=7`,
+	Args:   cobra.NoArgs,
+	Hidden: true,
+	RunE:   runSeed,
+}
+
+var (
+	seedProject string
+	seedTasks   int
+	seedSeed    int64
+)
+
+func init() {
+	seedCmd.Flags().StringVarP(&seedProject, "project", "p", "", "project prefix or ID")
+	seedCmd.Flags().IntVar(&seedTasks, "tasks", 100, "number of tasks to create")
+	seedCmd.Flags().Int64Var(&seedSeed, "seed", 1, "random seed")
+	seedCmd.RegisterFlagCompletionFunc("project", completeProjectIDs)
+	allowInactiveFlag(seedCmd)
+	rootCmd.AddCommand(seedCmd)
+}
+
+func runSeed(cmd *cobra.Command, args []string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	pf, err := ops.ResolveProject(s, seedProject)
+	if err != nil {
+		return err
+	}
+
+	result, err := ops.Seed(s, pf.Prefix, ops.SeedOptions{Tasks: seedTasks, Seed: seedSeed})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Seeded %s with %s, %s, and %s.\n", pf.Prefix,
+		cli.Count(result.Tasks, "task"), cli.Count(result.Waits, "wait"), cli.Count(result.Blockers, "blocker"))
+	return nil
+}
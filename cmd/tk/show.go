@@ -8,41 +8,67 @@ import (
 	"github.com/jacksmith/tk/internal/cli"
 	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
 var showCmd = &cobra.Command{
-	Use:   "show <id>",
+	Use:   "show [id]",
 	Short: "Show task or wait details",
 	Long: `Show full details for a task or wait.
 
 The ID can be a task ID (e.g., BY-07) or a wait ID (e.g., BY-03W).
-IDs are case-insensitive.
+IDs are case-insensitive. Given a project ID or prefix instead, show points
+you at 'tk project'.
 
-Shows all fields including blockers with their status.`,
-	Args:              cobra.ExactArgs(1),
+Shows all fields including blockers with their status. --deps-only prints
+just the item's state, blockers, and dependents, for stepping through a
+dependency graph one item at a time.
+
+Run without an ID (or with --pick) to choose from a numbered list of open
+tasks and waits.`,
+	Args:              cobra.MaximumNArgs(1),
 	RunE:              runShow,
 	ValidArgsFunction: completeAnyIDs,
 }
 
+var (
+	showPick     bool
+	showDepsOnly bool
+)
+
 func init() {
+	showCmd.Flags().BoolVar(&showPick, "pick", false, "choose the item from a numbered list")
+	showCmd.Flags().BoolVar(&showDepsOnly, "deps-only", false, "show only state, blockers, and dependents")
 	rootCmd.AddCommand(showCmd)
 }
 
 func runShow(cmd *cobra.Command, args []string) error {
-	id := args[0]
-
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
 
 	ops.AutoCheck(s)
 
+	id, err := resolvePickedID(args, showPick, func() (string, error) {
+		return pickAnyItem(s)
+	})
+	if err != nil {
+		return err
+	}
+
+	if showDepsOnly && (model.IsWaitID(id) || model.IsTaskID(id)) {
+		return showDeps(s, id)
+	}
 	if model.IsWaitID(id) {
 		return showWait(s, id)
 	}
+	if !model.IsTaskID(id) {
+		// A common slip is passing a project where an item is expected
+		if pf, err := ops.ResolveProject(s, id); err == nil {
+			return fmt.Errorf("%q is a project, not a task or wait; did you mean 'tk project %s'?", id, pf.ID)
+		}
+	}
 	return showTask(s, id)
 }
 
@@ -51,12 +77,16 @@ func showTask(s ops.Store, id string) error {
 	if err != nil {
 		return err
 	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return err
+	}
 
 	task := &result.Task
 
 	fmt.Printf("%s: %s\n", task.ID, task.Title)
 	fmt.Printf("Status:        %s (%s)\n", task.Status, result.State)
-	fmt.Printf("Priority:      %d (%s)\n", task.Priority, priorityLabel(task.Priority))
+	fmt.Printf("Priority:      %d (%s)\n", task.Priority, model.PriorityLabel(task.Priority))
 
 	if len(task.Tags) > 0 {
 		fmt.Printf("Tags:          %s\n", strings.Join(task.Tags, ", "))
@@ -71,34 +101,43 @@ func showTask(s ops.Store, id string) error {
 	}
 
 	if task.DueDate != nil {
-		fmt.Printf("Due:           %s\n", task.DueDate.Format("2006-01-02"))
+		if task.RemindBefore > 0 {
+			fmt.Printf("Due:           %s (remind %s before)\n", model.FormatDate(*task.DueDate), cli.Count(task.RemindBefore, "day"))
+		} else {
+			fmt.Printf("Due:           %s\n", model.FormatDate(*task.DueDate))
+		}
 	} else {
 		fmt.Printf("Due:           -\n")
 	}
 
+	if task.SnoozedUntil != nil && time.Now().Before(*task.SnoozedUntil) {
+		fmt.Printf("Snoozed until: %s\n", model.FormatDate(*task.SnoozedUntil))
+	}
+
 	fmt.Printf("Auto-complete: %s\n", boolToYesNo(task.AutoComplete))
+	if task.Source != "" {
+		fmt.Printf("Source:        %s\n", task.Source)
+	}
 
-	fmt.Printf("Created:       %s\n", task.Created.Format(time.RFC3339))
-	fmt.Printf("Updated:       %s\n", task.Updated.Format(time.RFC3339))
+	fmt.Printf("Created:       %s\n", model.FormatDateTime(task.Created))
+	fmt.Printf("Updated:       %s\n", model.FormatDateTime(task.Updated))
 	if task.DoneAt != nil {
-		fmt.Printf("Done at:       %s\n", task.DoneAt.Format(time.RFC3339))
+		fmt.Printf("Done at:       %s\n", model.FormatDateTime(*task.DoneAt))
 	}
 	if task.DroppedAt != nil {
-		fmt.Printf("Dropped at:    %s\n", task.DroppedAt.Format(time.RFC3339))
+		fmt.Printf("Dropped at:    %s\n", model.FormatDateTime(*task.DroppedAt))
 	}
 	if task.DropReason != "" {
 		fmt.Printf("Drop reason:   %s\n", task.DropReason)
 	}
 
 	if len(task.BlockedBy) > 0 {
-		fmt.Println()
-		fmt.Println("Blocked by:")
-		for _, blockerID := range task.BlockedBy {
-			info := ops.GetBlockerInfo(pf, blockerID)
-			fmt.Printf("  %s %s %s\n", info.ID, formatStatusBracket(info.Status), info.DisplayText)
-		}
+		printBlockedBy(pf, task.BlockedBy, task.BlockReasons, cfg.DroppedUnblocks)
+		fmt.Println(formatDependencyProgress(ops.DependencyProgress(pf, task.ID, cfg.DroppedUnblocks)))
 	}
 
+	printDependents(pf, task.ID)
+
 	if task.Notes != "" {
 		fmt.Println()
 		fmt.Println("Notes:")
@@ -115,35 +154,45 @@ func showWait(s ops.Store, id string) error {
 	if err != nil {
 		return err
 	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return err
+	}
 
 	wait := &result.Wait
 
 	displayText := wait.DisplayText()
 	fmt.Printf("%s: %s\n", wait.ID, displayText)
-	fmt.Printf("Status:      %s (%s)\n", wait.Status, result.State)
+	fmt.Printf("Status:      %s (%s)\n", wait.Status, describeWaitState(pf, wait, result.State, cfg.DroppedUnblocks))
 	fmt.Printf("Type:        %s\n", wait.ResolutionCriteria.Type)
 
 	if wait.ResolutionCriteria.Type == model.ResolutionTypeManual {
 		fmt.Printf("Question:    %s\n", wait.ResolutionCriteria.Question)
 		if wait.ResolutionCriteria.CheckAfter != nil {
-			fmt.Printf("Check after: %s\n", wait.ResolutionCriteria.CheckAfter.Format(time.RFC3339))
+			fmt.Printf("Check after: %s\n", model.FormatDateTime(*wait.ResolutionCriteria.CheckAfter))
 		}
 	} else if wait.ResolutionCriteria.Type == model.ResolutionTypeTime {
 		if wait.ResolutionCriteria.After != nil {
-			fmt.Printf("After:       %s\n", wait.ResolutionCriteria.After.Format(time.RFC3339))
+			fmt.Printf("After:       %s\n", model.FormatDateTime(*wait.ResolutionCriteria.After))
 		}
 	}
 
 	if wait.Title != "" && wait.Title != displayText {
 		fmt.Printf("Title:       %s\n", wait.Title)
 	}
+	if wait.Tracking != "" {
+		fmt.Printf("Tracking:    %s\n", wait.Tracking)
+	}
+	if wait.Link != "" {
+		fmt.Printf("Link:        %s\n", cli.Hyperlink(wait.Link, wait.Link))
+	}
 
-	fmt.Printf("Created:     %s\n", wait.Created.Format(time.RFC3339))
+	fmt.Printf("Created:     %s\n", model.FormatDateTime(wait.Created))
 	if wait.DoneAt != nil {
-		fmt.Printf("Done at:     %s\n", wait.DoneAt.Format(time.RFC3339))
+		fmt.Printf("Done at:     %s\n", model.FormatDateTime(*wait.DoneAt))
 	}
 	if wait.DroppedAt != nil {
-		fmt.Printf("Dropped at:  %s\n", wait.DroppedAt.Format(time.RFC3339))
+		fmt.Printf("Dropped at:  %s\n", model.FormatDateTime(*wait.DroppedAt))
 	}
 
 	if wait.Resolution != "" {
@@ -154,14 +203,11 @@ func showWait(s ops.Store, id string) error {
 	}
 
 	if len(wait.BlockedBy) > 0 {
-		fmt.Println()
-		fmt.Println("Blocked by:")
-		for _, blockerID := range wait.BlockedBy {
-			info := ops.GetBlockerInfo(pf, blockerID)
-			fmt.Printf("  %s %s %s\n", info.ID, formatStatusBracket(info.Status), info.DisplayText)
-		}
+		printBlockedBy(pf, wait.BlockedBy, wait.BlockReasons, cfg.DroppedUnblocks)
 	}
 
+	printDependents(pf, wait.ID)
+
 	if wait.Notes != "" {
 		fmt.Println()
 		fmt.Println("Notes:")
@@ -173,6 +219,146 @@ func showWait(s ops.Store, id string) error {
 	return nil
 }
 
+// showDeps prints the compact dependency report for tk show --deps-only:
+// the item's state, its blockers with their status, and its dependents.
+func showDeps(s ops.Store, id string) error {
+	var (
+		pf        *model.ProjectFile
+		itemID    string
+		title     string
+		state     string
+		blockedBy []string
+		reasons   map[string]string
+	)
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if model.IsWaitID(id) {
+		result, wpf, err := ops.ShowWait(s, id)
+		if err != nil {
+			return err
+		}
+		w := &result.Wait
+		pf, itemID, title, state = wpf, w.ID, w.DisplayText(), describeWaitState(wpf, w, result.State, cfg.DroppedUnblocks)
+		blockedBy, reasons = w.BlockedBy, w.BlockReasons
+	} else {
+		result, tpf, err := ops.ShowTask(s, id)
+		if err != nil {
+			return err
+		}
+		t := &result.Task
+		pf, itemID, title, state = tpf, t.ID, t.Title, string(result.State)
+		blockedBy, reasons = t.BlockedBy, t.BlockReasons
+	}
+
+	fmt.Printf("%s: %s\n", itemID, title)
+	fmt.Printf("State: %s\n", state)
+
+	if len(blockedBy) == 0 && len(ops.Dependents(pf, itemID)) == 0 {
+		fmt.Println("No blockers or dependents.")
+		return nil
+	}
+	if len(blockedBy) > 0 {
+		printBlockedBy(pf, blockedBy, reasons, cfg.DroppedUnblocks)
+		if model.IsTaskID(itemID) {
+			fmt.Println(formatDependencyProgress(ops.DependencyProgress(pf, itemID, cfg.DroppedUnblocks)))
+		}
+	}
+	printDependents(pf, itemID)
+	return nil
+}
+
+// describeWaitState returns a wait's state for display, naming the blockers
+// a dormant wait is waiting on, e.g. "dormant until BY-01W resolves".
+func describeWaitState(pf *model.ProjectFile, w *model.Wait, state model.WaitState, droppedUnblocks bool) string {
+	if state != model.WaitStateDormant {
+		return string(state)
+	}
+	until := ops.DormantUntil(pf, w, droppedUnblocks)
+	switch len(until) {
+	case 0:
+		return string(state)
+	case 1:
+		return fmt.Sprintf("%s until %s resolves", state, until[0])
+	default:
+		return fmt.Sprintf("%s until %s resolve", state, strings.Join(until, ", "))
+	}
+}
+
+// printBlockedBy prints the "Blocked by:" section with each blocker's status
+// and reason, followed by the one-line readiness summary.
+func printBlockedBy(pf *model.ProjectFile, blockedBy []string, reasons map[string]string, droppedUnblocks bool) {
+	fmt.Println()
+	fmt.Println("Blocked by:")
+	for _, blockerID := range blockedBy {
+		info := ops.GetBlockerInfo(pf, blockerID)
+		text := withBlockReason(info.DisplayText, reasons[blockerID])
+		fmt.Printf("  %s %s %s\n", info.ID, formatStatusBracket(info.Status), text)
+	}
+	fmt.Println(formatBlockerSummary(ops.SummarizeBlockers(pf, blockedBy, droppedUnblocks)))
+}
+
+// printDependents prints the "Blocking:" section listing the items directly
+// blocked by id, so a blocker relationship shows up on both of its ends.
+func printDependents(pf *model.ProjectFile, id string) {
+	dependents := ops.Dependents(pf, id)
+	if len(dependents) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Blocking:")
+	for _, depID := range dependents {
+		info := ops.GetBlockerInfo(pf, depID)
+		text := withBlockReason(info.DisplayText, blockReasons(pf, depID)[id])
+		fmt.Printf("  %s %s %s\n", info.ID, formatStatusBracket(info.Status), text)
+	}
+}
+
+// blockReasons returns the blocker reasons recorded on the task or wait id.
+func blockReasons(pf *model.ProjectFile, id string) map[string]string {
+	for i := range pf.Tasks {
+		if strings.EqualFold(pf.Tasks[i].ID, id) {
+			return pf.Tasks[i].BlockReasons
+		}
+	}
+	for i := range pf.Waits {
+		if strings.EqualFold(pf.Waits[i].ID, id) {
+			return pf.Waits[i].BlockReasons
+		}
+	}
+	return nil
+}
+
+// withBlockReason appends the reason for a blocker relationship, if any,
+// to the display text of the item on its other end.
+func withBlockReason(text, reason string) string {
+	if reason == "" {
+		return text
+	}
+	return fmt.Sprintf("%s (reason: %s)", text, reason)
+}
+
+// formatBlockerSummary renders a one-line readiness statement, e.g.
+// "2 of 3 blockers resolved; waiting on TP-03W".
+func formatBlockerSummary(summary ops.BlockerSummary) string {
+	line := fmt.Sprintf("%d of %s resolved", summary.Resolved, cli.Count(summary.Total, "blocker"))
+	if len(summary.Unresolved) > 0 {
+		line += "; waiting on " + strings.Join(summary.Unresolved, ", ")
+	}
+	return line
+}
+
+// formatDependencyProgress renders how much of the transitive dependency
+// tree is resolved, e.g. "dependencies 4/7 resolved (57%)".
+func formatDependencyProgress(summary ops.BlockerSummary) string {
+	percent := 0
+	if summary.Total > 0 {
+		percent = summary.Resolved * 100 / summary.Total
+	}
+	return fmt.Sprintf("dependencies %d/%d resolved (%d%%)", summary.Resolved, summary.Total, percent)
+}
+
 func formatStatusBracket(status string) string {
 	switch status {
 	case "done":
@@ -186,21 +372,6 @@ func formatStatusBracket(status string) string {
 	}
 }
 
-func priorityLabel(p int) string {
-	switch p {
-	case 1:
-		return "urgent"
-	case 2:
-		return "high"
-	case 3:
-		return "medium"
-	case 4:
-		return "backlog"
-	default:
-		return "unknown"
-	}
-}
-
 func boolToYesNo(b bool) string {
 	if b {
 		return "yes"
@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/jacksmith/tk/internal/cli"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -27,16 +27,25 @@ var untagCmd = &cobra.Command{
 	Short: "Remove a tag from a task",
 	Long: `Remove a tag from a task. Shortcut for --remove-tag.
 
+Use --prefix instead of a tag to remove every tag starting with it.
+
 Examples:
   tk untag BY-07 weekend
-  tk untag BY-07 urgent`,
-	Args:              cobra.ExactArgs(2),
+  tk untag BY-07 urgent
+  tk untag BY-07 --prefix=sprint-`,
+	Args:              cobra.RangeArgs(1, 2),
 	RunE:              runUntag,
 	ValidArgsFunction: completeTaskIDsThenTags,
 }
 
+var untagPrefix string
+
 func init() {
+	untagCmd.Flags().StringVar(&untagPrefix, "prefix", "", "remove all tags starting with this prefix")
+
+	allowInactiveFlag(tagCmd)
 	rootCmd.AddCommand(tagCmd)
+	allowInactiveFlag(untagCmd)
 	rootCmd.AddCommand(untagCmd)
 }
 
@@ -44,7 +53,7 @@ func runTag(cmd *cobra.Command, args []string) error {
 	taskID := args[0]
 	tag := strings.ToLower(strings.TrimSpace(args[1]))
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -65,9 +74,19 @@ func runTag(cmd *cobra.Command, args []string) error {
 
 func runUntag(cmd *cobra.Command, args []string) error {
 	taskID := args[0]
+
+	if untagPrefix != "" {
+		if len(args) > 1 {
+			return fmt.Errorf("cannot combine a tag with --prefix")
+		}
+		return runUntagPrefix(taskID, strings.ToLower(strings.TrimSpace(untagPrefix)))
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("requires a tag or --prefix")
+	}
 	tag := strings.ToLower(strings.TrimSpace(args[1]))
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -85,3 +104,23 @@ func runUntag(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Removed tag %q from %s.\n", tag, taskID)
 	return nil
 }
+
+func runUntagPrefix(taskID, prefix string) error {
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	removed, err := ops.RemoveTagsWithPrefix(s, taskID, prefix)
+	if err != nil {
+		return err
+	}
+
+	if len(removed) == 0 {
+		fmt.Printf("%s has no tags starting with %q.\n", taskID, prefix)
+		return nil
+	}
+
+	fmt.Printf("Removed %s from %s: %s\n", cli.Count(len(removed), "tag"), taskID, strings.Join(removed, ", "))
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/jacksmith/tk/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var uncheckCmd = &cobra.Command{
+	Use:   "uncheck <id>",
+	Short: "Reopen a resolved time wait and push back its date",
+	Long: `Revert a time wait that 'tk check' resolved when its date passed but the
+thing it stood for didn't happen, such as a delivery that didn't arrive.
+
+The wait is reopened (its resolution is cleared) and its date is moved to
+the end of the day --days from now, or to --until. Warns about done items
+that depend on the wait, since they were completed assuming it was over.
+
+Examples:
+  tk uncheck BY-03W --days=3
+  tk uncheck BY-03W --until=2026-01-20`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runUncheck,
+	ValidArgsFunction: completeWaitIDs,
+}
+
+var (
+	uncheckDays  int
+	uncheckUntil string
+)
+
+func init() {
+	uncheckCmd.Flags().IntVar(&uncheckDays, "days", 0, "push the date N days from now")
+	uncheckCmd.Flags().StringVar(&uncheckUntil, "until", "", "push the date to YYYY-MM-DD")
+	uncheckCmd.MarkFlagsMutuallyExclusive("days", "until")
+	allowInactiveFlag(uncheckCmd)
+	rootCmd.AddCommand(uncheckCmd)
+}
+
+func runUncheck(cmd *cobra.Command, args []string) error {
+	waitID := args[0]
+
+	if uncheckDays <= 0 && uncheckUntil == "" {
+		return fmt.Errorf("either --days or --until must be specified")
+	}
+
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	var until time.Time
+	if uncheckDays > 0 {
+		until = model.EndOfDay(time.Now().AddDate(0, 0, uncheckDays))
+	} else {
+		t, err := model.ParseDate(uncheckUntil)
+		if err != nil {
+			return fmt.Errorf("invalid date format (expected YYYY-MM-DD): %v", err)
+		}
+		until = model.EndOfDay(t)
+	}
+
+	result, err := ops.UncheckWait(s, waitID, until)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s reopened, now until %s.\n", waitID, model.FormatDate(until))
+	if len(result.InconsistentDependents) > 0 {
+		fmt.Printf("Warning: done items depend on %s: %s\n", waitID, strings.Join(result.InconsistentDependents, ", "))
+	}
+	return nil
+}
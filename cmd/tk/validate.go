@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
 	"os"
 	"strings"
@@ -22,15 +23,29 @@ Checks for:
 - Duplicate IDs
 - Invalid ID formats
 - Missing required fields
-
-Use --fix to auto-repair fixable issues (removes orphan references).`,
+- Tags that are not lowercase
+- Time waits more than a week past their date that were never checked
+  (run 'tk check' to resolve them)
+- A project next_id at or below an existing item number, which would
+  make new items reuse IDs
+
+Use --fix to auto-repair fixable issues (removes orphan references,
+lowercases tags, raises next_id).
+
+Cycles cannot be fixed automatically. Use --suggest-cycle-break to get,
+for each cycle, the one blocker to remove to break it (the edge on the
+item changed most recently), and remove it after confirming.`,
 	RunE: runValidate,
 }
 
-var validateFix bool
+var (
+	validateFix               bool
+	validateSuggestCycleBreak bool
+)
 
 func init() {
 	validateCmd.Flags().BoolVar(&validateFix, "fix", false, "auto-repair fixable issues")
+	validateCmd.Flags().BoolVar(&validateSuggestCycleBreak, "suggest-cycle-break", false, "propose a blocker to remove for each cycle and apply it on confirmation")
 	rootCmd.AddCommand(validateCmd)
 }
 
@@ -40,9 +55,15 @@ func runValidate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if validateFix && validateSuggestCycleBreak {
+		return fmt.Errorf("--fix and --suggest-cycle-break cannot be combined")
+	}
 	if validateFix {
 		return runValidateAndFix(s)
 	}
+	if validateSuggestCycleBreak {
+		return runSuggestCycleBreak(s)
+	}
 	return runValidateOnly(s)
 }
 
@@ -57,7 +78,7 @@ func runValidateOnly(s *storage.Storage) error {
 		return nil
 	}
 
-	fmt.Printf("Found %d issue(s):\n\n", len(errors))
+	fmt.Printf("Found %s:\n\n", cli.Count(len(errors), "issue"))
 
 	for _, e := range errors {
 		typeStr := formatValidationErrorType(e.Type)
@@ -84,7 +105,7 @@ func runValidateAndFix(s *storage.Storage) error {
 		return nil
 	}
 
-	fmt.Printf("Found %d issue(s). Attempting to fix...\n\n", len(errors))
+	fmt.Printf("Found %s. Attempting to fix...\n\n", cli.Count(len(errors), "issue"))
 
 	// Apply fixes
 	fixes, err := ops.ValidateAndFix(s)
@@ -124,6 +145,46 @@ func runValidateAndFix(s *storage.Storage) error {
 	return nil
 }
 
+func runSuggestCycleBreak(s *storage.Storage) error {
+	breaks, err := ops.SuggestCycleBreaks(s)
+	if err != nil {
+		return err
+	}
+
+	if len(breaks) == 0 {
+		fmt.Println(cli.Green("No dependency cycles found."))
+		return nil
+	}
+
+	in := bufio.NewReader(pickInput)
+	kept := 0
+	for i, b := range breaks {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s %s\n", cli.Red("[cycle]"), strings.Join(b.Cycle, " → "))
+		fmt.Printf("  Suggest removing: %s blocked by %s\n", b.Item, b.Blocker)
+
+		ok, err := cli.Confirm(in, os.Stdout, "  Remove it?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			kept++
+			continue
+		}
+		if err := ops.BreakCycle(s, b); err != nil {
+			return err
+		}
+		fmt.Printf("  %s is no longer blocked by %s.\n", b.Item, b.Blocker)
+	}
+
+	if kept > 0 {
+		return fmt.Errorf("%s left in place", cli.Count(kept, "cycle"))
+	}
+	return nil
+}
+
 func formatValidationErrorType(t ops.ValidationErrorType) string {
 	switch t {
 	case ops.ValidationErrorOrphanBlocker:
@@ -138,6 +199,14 @@ func formatValidationErrorType(t ops.ValidationErrorType) string {
 		return cli.Red("[missing]")
 	case ops.ValidationErrorInvalidPriority:
 		return cli.Red("[priority]")
+	case ops.ValidationErrorTagCase:
+		return cli.Yellow("[tag-case]")
+	case ops.ValidationErrorStaleWait:
+		return cli.Yellow("[stale-wait]")
+	case ops.ValidationErrorNextID:
+		return cli.Red("[next-id]")
+	case ops.ValidationErrorConfig:
+		return cli.Yellow("[config]")
 	default:
 		return fmt.Sprintf("[%s]", t)
 	}
@@ -10,9 +10,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jacksmith/tk/internal/graph"
 	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -72,7 +72,7 @@ type vizData struct {
 }
 
 func runViz(cmd *cobra.Command, args []string) error {
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -81,7 +81,7 @@ func runViz(cmd *cobra.Command, args []string) error {
 
 	var projects []*model.ProjectFile
 	if vizProject != "" {
-		pf, err := ops.ResolveProject(s, vizProject)
+		pf, err := ops.LookupProject(s, vizProject)
 		if err != nil {
 			return err
 		}
@@ -94,12 +94,17 @@ func runViz(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return err
+	}
+
 	now := time.Now()
 	gd := vizData{}
 	nodeSet := make(map[string]bool)
 
 	for _, pf := range projects {
-		blockerStates := ops.ComputeBlockerStates(pf)
+		blockerStates := ops.ComputeBlockerStates(pf, cfg.DroppedUnblocks)
 
 		for _, t := range pf.Tasks {
 			state := model.ComputeTaskState(&t, blockerStates)
@@ -133,11 +138,12 @@ func runViz(cmd *cobra.Command, args []string) error {
 			})
 		}
 
+		g := graph.BuildGraph(pf)
 		for _, t := range pf.Tasks {
 			if !nodeSet[t.ID] {
 				continue
 			}
-			for _, blockerID := range t.BlockedBy {
+			for _, blockerID := range g.BlockedBy(t.ID) {
 				if !nodeSet[blockerID] {
 					continue
 				}
@@ -153,7 +159,7 @@ func runViz(cmd *cobra.Command, args []string) error {
 			if !nodeSet[w.ID] {
 				continue
 			}
-			for _, blockerID := range w.BlockedBy {
+			for _, blockerID := range g.BlockedBy(w.ID) {
 				if !nodeSet[blockerID] {
 					continue
 				}
@@ -2,13 +2,13 @@ package main
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/jacksmith/tk/internal/cli"
 	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -34,11 +34,17 @@ var waitAddCmd = &cobra.Command{
 For manual waits, use --question.
 For time waits, use --after.
 
+A wait with open blockers is dormant: it isn't actionable until they
+resolve. Use --depends-on-wait to chain a wait behind other waits, e.g. a
+follow-up question that only makes sense once a delivery has arrived.
+
 Examples:
   tk wait add -p BY --question="Did the fabric arrive?"
   tk wait add "Fabric delivery" -p BY --question="Did the fabric arrive?"
   tk wait add -p BY --question="Did the PCBs arrive?" --check-after=2026-01-10
   tk wait add -p BY --question="Did the PCBs arrive?" --blocked-by=BY-05
+  tk wait add -p BY --question="Did the install get scheduled?" --depends-on-wait=BY-03W
+  tk wait add "Parts delivery" -p BY --question="Did the parts arrive?" --tracking=1Z999 --link=https://example.com/track/1Z999
   tk wait add -p BY --after=2026-01-15
   tk wait add "After Jan 15" -p BY --after=2026-01-15T14:00:00`,
 	Args: cobra.MaximumNArgs(1),
@@ -56,7 +62,8 @@ Examples:
   tk wait edit BY-03W --title="New title"
   tk wait edit BY-03W --question="Updated question?"
   tk wait edit BY-03W --check-after=2026-01-20
-  tk wait edit BY-03W --notes="Tracking: 123456"
+  tk wait edit BY-03W --notes="Left at front desk"
+  tk wait edit BY-03W --tracking=123456 --link=https://example.com/track/123456
   tk wait edit BY-03W -i`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runWaitEdit,
@@ -71,9 +78,15 @@ var waitResolveCmd = &cobra.Command{
 For manual waits, this marks the question as answered.
 For time waits, this allows early resolution.
 
+Use --chain when one event clears a nested set of waits: the open waits the
+wait depends on, directly or through other waits, are resolved first
+(innermost first) with the same resolution. Only waits are resolved; if an
+open task stands in the chain, nothing changes.
+
 Examples:
   tk wait resolve BY-03W
-  tk wait resolve BY-03W --resolution="Arrived damaged, returning"`,
+  tk wait resolve BY-03W --resolution="Arrived damaged, returning"
+  tk wait resolve BY-05W --chain --resolution="Permit office called back"`,
 	Args:              cobra.ExactArgs(1),
 	RunE:              runWaitResolve,
 	ValidArgsFunction: completeWaitIDs,
@@ -114,14 +127,35 @@ Examples:
 	ValidArgsFunction: completeWaitIDs,
 }
 
+var waitConvertCmd = &cobra.Command{
+	Use:   "convert <id>",
+	Short: "Change a wait's type",
+	Long: `Convert an open wait between a manual wait and a time wait.
+
+Converting to a time wait requires --after; the question and check-after
+date are cleared. Converting to a manual wait clears the after date and
+takes --question (required if the wait has no title) and an optional
+--check-after.
+
+Examples:
+  tk wait convert BY-03W --to=time --after=2026-02-01
+  tk wait convert BY-04W --to=manual --question="Did the part arrive?"`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runWaitConvert,
+	ValidArgsFunction: completeWaitIDs,
+}
+
 var (
 	// wait add flags
-	waitAddProject    string
-	waitAddQuestion   string
-	waitAddAfter      string
-	waitAddCheckAfter string
-	waitAddNotes      string
-	waitAddBlockedBy  string
+	waitAddProject       string
+	waitAddQuestion      string
+	waitAddAfter         string
+	waitAddCheckAfter    string
+	waitAddNotes         string
+	waitAddTracking      string
+	waitAddLink          string
+	waitAddBlockedBy     string
+	waitAddDependsOnWait string
 
 	// wait edit flags
 	waitEditTitle         string
@@ -130,6 +164,8 @@ var (
 	waitEditCheckAfter    string
 	waitEditClearCheckAfter bool
 	waitEditNotes         string
+	waitEditTracking      string
+	waitEditLink          string
 	waitEditBlockedBy     string
 	waitEditAddBlockedBy  []string
 	waitEditRemoveBlockedBy []string
@@ -137,6 +173,7 @@ var (
 
 	// wait resolve flags
 	waitResolveResolution string
+	waitResolveChain      bool
 
 	// wait drop flags
 	waitDropReason     string
@@ -146,6 +183,12 @@ var (
 	// wait defer flags
 	waitDeferDays  int
 	waitDeferUntil string
+
+	// wait convert flags
+	waitConvertTo         string
+	waitConvertAfter      string
+	waitConvertQuestion   string
+	waitConvertCheckAfter string
 )
 
 func init() {
@@ -155,8 +198,13 @@ func init() {
 	waitAddCmd.Flags().StringVar(&waitAddAfter, "after", "", "date/time for time wait (YYYY-MM-DD or RFC3339)")
 	waitAddCmd.Flags().StringVar(&waitAddCheckAfter, "check-after", "", "check after date (YYYY-MM-DD or RFC3339)")
 	waitAddCmd.Flags().StringVar(&waitAddNotes, "notes", "", "wait notes")
+	waitAddCmd.Flags().StringVar(&waitAddTracking, "tracking", "", "tracking number or external reference")
+	waitAddCmd.Flags().StringVar(&waitAddLink, "link", "", "URL for following the wait")
 	waitAddCmd.Flags().StringVar(&waitAddBlockedBy, "blocked-by", "", "comma-separated blocker IDs")
+	waitAddCmd.Flags().StringVar(&waitAddDependsOnWait, "depends-on-wait", "", "comma-separated wait IDs; the new wait stays dormant until they resolve")
 	waitAddCmd.RegisterFlagCompletionFunc("project", completeProjectIDs)
+	waitAddCmd.RegisterFlagCompletionFunc("depends-on-wait", completeWaitIDs)
+	allowInactiveFlag(waitAddCmd)
 	waitCmd.AddCommand(waitAddCmd)
 
 	// wait edit command
@@ -166,34 +214,50 @@ func init() {
 	waitEditCmd.Flags().StringVar(&waitEditCheckAfter, "check-after", "", "set check after date")
 	waitEditCmd.Flags().BoolVar(&waitEditClearCheckAfter, "clear-check-after", false, "clear check after date")
 	waitEditCmd.Flags().StringVar(&waitEditNotes, "notes", "", "set notes")
+	waitEditCmd.Flags().StringVar(&waitEditTracking, "tracking", "", "set tracking number")
+	waitEditCmd.Flags().StringVar(&waitEditLink, "link", "", "set link URL")
 	waitEditCmd.Flags().StringVar(&waitEditBlockedBy, "blocked-by", "", "replace blockers (comma-separated)")
 	waitEditCmd.Flags().StringArrayVar(&waitEditAddBlockedBy, "add-blocked-by", nil, "add a blocker")
 	waitEditCmd.Flags().StringArrayVar(&waitEditRemoveBlockedBy, "remove-blocked-by", nil, "remove a blocker")
 	waitEditCmd.Flags().BoolVarP(&waitEditInteractive, "interactive", "i", false, "edit in $EDITOR")
 	waitEditCmd.RegisterFlagCompletionFunc("add-blocked-by", completeAnyIDs)
 	waitEditCmd.RegisterFlagCompletionFunc("remove-blocked-by", completeAnyIDs)
+	allowInactiveFlag(waitEditCmd)
 	waitCmd.AddCommand(waitEditCmd)
 
 	// wait resolve command
 	waitResolveCmd.Flags().StringVar(&waitResolveResolution, "resolution", "", "resolution description")
+	waitResolveCmd.Flags().BoolVar(&waitResolveChain, "chain", false, "also resolve the open waits this wait depends on")
+	allowInactiveFlag(waitResolveCmd)
 	waitCmd.AddCommand(waitResolveCmd)
 
 	// wait drop command
 	waitDropCmd.Flags().StringVar(&waitDropReason, "reason", "", "reason for dropping")
 	waitDropCmd.Flags().BoolVar(&waitDropDropDeps, "drop-deps", false, "also drop dependent items")
 	waitDropCmd.Flags().BoolVar(&waitDropRemoveDeps, "remove-deps", false, "unlink from dependent items")
+	allowInactiveFlag(waitDropCmd)
 	waitCmd.AddCommand(waitDropCmd)
 
 	// wait defer command
 	waitDeferCmd.Flags().IntVar(&waitDeferDays, "days", 0, "defer for N days")
 	waitDeferCmd.Flags().StringVar(&waitDeferUntil, "until", "", "defer until date (YYYY-MM-DD)")
+	allowInactiveFlag(waitDeferCmd)
 	waitCmd.AddCommand(waitDeferCmd)
 
+	// wait convert command
+	waitConvertCmd.Flags().StringVar(&waitConvertTo, "to", "", "new wait type: time or manual")
+	waitConvertCmd.Flags().StringVar(&waitConvertAfter, "after", "", "date/time for time wait (YYYY-MM-DD or RFC3339)")
+	waitConvertCmd.Flags().StringVar(&waitConvertQuestion, "question", "", "question for manual wait")
+	waitConvertCmd.Flags().StringVar(&waitConvertCheckAfter, "check-after", "", "check after date for manual wait (YYYY-MM-DD or RFC3339)")
+	waitConvertCmd.MarkFlagRequired("to")
+	allowInactiveFlag(waitConvertCmd)
+	waitCmd.AddCommand(waitConvertCmd)
+
 	rootCmd.AddCommand(waitCmd)
 }
 
 func runWaitAdd(cmd *cobra.Command, args []string) error {
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -220,8 +284,10 @@ func runWaitAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	opts := ops.WaitOptions{
-		Title: title,
-		Notes: waitAddNotes,
+		Title:    title,
+		Notes:    waitAddNotes,
+		Tracking: waitAddTracking,
+		Link:     waitAddLink,
 	}
 
 	// Parse blockers
@@ -231,6 +297,17 @@ func runWaitAdd(cmd *cobra.Command, args []string) error {
 			opts.BlockedBy[i] = strings.TrimSpace(id)
 		}
 	}
+	// --depends-on-wait is --blocked-by restricted to waits, for chaining
+	// a wait behind another one
+	if waitAddDependsOnWait != "" {
+		for _, id := range strings.Split(waitAddDependsOnWait, ",") {
+			id = strings.TrimSpace(id)
+			if !model.IsWaitID(id) {
+				return fmt.Errorf("--depends-on-wait takes wait IDs, got %s (use --blocked-by for tasks)", id)
+			}
+			opts.BlockedBy = append(opts.BlockedBy, id)
+		}
+	}
 
 	if waitAddQuestion != "" {
 		opts.Type = model.ResolutionTypeManual
@@ -267,7 +344,7 @@ func runWaitAdd(cmd *cobra.Command, args []string) error {
 func runWaitEdit(cmd *cobra.Command, args []string) error {
 	waitID := args[0]
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -319,6 +396,16 @@ func runWaitEdit(cmd *cobra.Command, args []string) error {
 		hasChanges = true
 	}
 
+	if cmd.Flags().Changed("tracking") {
+		changes.Tracking = &waitEditTracking
+		hasChanges = true
+	}
+
+	if cmd.Flags().Changed("link") {
+		changes.Link = &waitEditLink
+		hasChanges = true
+	}
+
 	// Handle blockers
 	if err := handleWaitBlockerChanges(s, waitID, &changes, cmd, &hasChanges); err != nil {
 		return err
@@ -365,7 +452,7 @@ func handleWaitBlockerChanges(s ops.Store, waitID string, changes *ops.WaitChang
 			}
 		}
 		if wait == nil {
-			return fmt.Errorf("wait %s not found", waitID)
+			return &ops.NotFoundError{Kind: "wait", ItemID: waitID}
 		}
 
 		blockerSet := make(map[string]bool)
@@ -385,6 +472,7 @@ func handleWaitBlockerChanges(s ops.Store, waitID string, changes *ops.WaitChang
 		for b := range blockerSet {
 			newBlockers = append(newBlockers, b)
 		}
+		sort.Strings(newBlockers)
 
 		changes.BlockedBy = &newBlockers
 		*hasChanges = true
@@ -401,6 +489,8 @@ type editableWait struct {
 	After      string   `yaml:"after,omitempty"`
 	CheckAfter string   `yaml:"check_after,omitempty"`
 	Notes      string   `yaml:"notes,omitempty"`
+	Tracking   string   `yaml:"tracking,omitempty"`
+	Link       string   `yaml:"link,omitempty"`
 	BlockedBy  []string `yaml:"blocked_by,omitempty"`
 }
 
@@ -423,7 +513,7 @@ func runWaitEditInteractive(s ops.Store, waitID string) error {
 		}
 	}
 	if wait == nil {
-		return fmt.Errorf("wait %s not found", waitID)
+		return &ops.NotFoundError{Kind: "wait", ItemID: waitID}
 	}
 
 	editable := editableWait{
@@ -431,6 +521,8 @@ func runWaitEditInteractive(s ops.Store, waitID string) error {
 		Type:      string(wait.ResolutionCriteria.Type),
 		Question:  wait.ResolutionCriteria.Question,
 		Notes:     wait.Notes,
+		Tracking:  wait.Tracking,
+		Link:      wait.Link,
 		BlockedBy: wait.BlockedBy,
 	}
 	if wait.ResolutionCriteria.After != nil {
@@ -445,7 +537,7 @@ func runWaitEditInteractive(s ops.Store, waitID string) error {
 		return fmt.Errorf("failed to marshal wait: %w", err)
 	}
 
-	header := fmt.Sprintf("# Editing wait %s\n# Note: 'type' cannot be changed.\n# Save and close editor to apply changes.\n\n", waitID)
+	header := fmt.Sprintf("# Editing wait %s\n# Note: 'type' cannot be changed here; use 'tk wait convert'.\n# Save and close editor to apply changes.\n\n", waitID)
 	content = append([]byte(header), content...)
 
 	edited, err := cli.EditInEditor(content, ".yaml")
@@ -468,6 +560,12 @@ func runWaitEditInteractive(s ops.Store, waitID string) error {
 	if newEditable.Notes != wait.Notes {
 		changes.Notes = &newEditable.Notes
 	}
+	if newEditable.Tracking != wait.Tracking {
+		changes.Tracking = &newEditable.Tracking
+	}
+	if newEditable.Link != wait.Link {
+		changes.Link = &newEditable.Link
+	}
 
 	oldAfter := ""
 	if wait.ResolutionCriteria.After != nil {
@@ -520,11 +618,20 @@ func runWaitEditInteractive(s ops.Store, waitID string) error {
 func runWaitResolve(cmd *cobra.Command, args []string) error {
 	waitID := args[0]
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
 
+	if waitResolveChain {
+		resolved, err := ops.ResolveWaitChain(s, waitID, waitResolveResolution)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Resolved %s: %s\n", cli.Count(len(resolved), "wait"), strings.Join(resolved, ", "))
+		return nil
+	}
+
 	if err := ops.ResolveWait(s, waitID, waitResolveResolution); err != nil {
 		return err
 	}
@@ -536,7 +643,7 @@ func runWaitResolve(cmd *cobra.Command, args []string) error {
 func runWaitDrop(cmd *cobra.Command, args []string) error {
 	waitID := args[0]
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -556,33 +663,70 @@ func runWaitDefer(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("either --days or --until must be specified")
 	}
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
 
 	var until time.Time
 	if waitDeferDays > 0 {
-		target := time.Now().AddDate(0, 0, waitDeferDays)
-		until = time.Date(target.Year(), target.Month(), target.Day(), 23, 59, 59, 0, target.Location())
+		until = model.EndOfDay(time.Now().AddDate(0, 0, waitDeferDays))
 	} else {
-		t, err := time.Parse("2006-01-02", waitDeferUntil)
+		t, err := model.ParseDate(waitDeferUntil)
 		if err != nil {
 			return fmt.Errorf("invalid date format (expected YYYY-MM-DD): %v", err)
 		}
-		until = time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, time.Local)
+		until = model.EndOfDay(t)
 	}
 
 	if err := ops.DeferWait(s, waitID, until); err != nil {
 		return err
 	}
 
-	fmt.Printf("%s deferred until %s.\n", waitID, until.Format("2006-01-02"))
+	fmt.Printf("%s deferred until %s.\n", waitID, model.FormatDate(until))
+	return nil
+}
+
+func runWaitConvert(cmd *cobra.Command, args []string) error {
+	waitID := args[0]
+
+	opts := ops.ConvertOptions{
+		Type:     model.ResolutionType(waitConvertTo),
+		Question: waitConvertQuestion,
+	}
+	if opts.Type != model.ResolutionTypeTime && opts.Type != model.ResolutionTypeManual {
+		return fmt.Errorf("invalid --to %q: expected time or manual", waitConvertTo)
+	}
+	if waitConvertAfter != "" {
+		t, err := parseDateTime(waitConvertAfter)
+		if err != nil {
+			return fmt.Errorf("invalid after date: %v", err)
+		}
+		opts.After = &t
+	}
+	if waitConvertCheckAfter != "" {
+		t, err := parseDateTime(waitConvertCheckAfter)
+		if err != nil {
+			return fmt.Errorf("invalid check-after date: %v", err)
+		}
+		opts.CheckAfter = &t
+	}
+
+	s, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	if err := ops.ConvertWaitType(s, waitID, opts); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s converted to a %s wait.\n", waitID, opts.Type)
 	return nil
 }
 
 // parseDateTime parses a date or datetime string.
-// Accepts YYYY-MM-DD (end of day) or RFC3339.
+// Accepts YYYY-MM-DD (end of day in the configured timezone) or RFC3339.
 func parseDateTime(s string) (time.Time, error) {
 	// Try RFC3339 first
 	if t, err := time.Parse(time.RFC3339, s); err == nil {
@@ -590,9 +734,9 @@ func parseDateTime(s string) (time.Time, error) {
 	}
 
 	// Try date only
-	if t, err := time.Parse("2006-01-02", s); err == nil {
+	if t, err := model.ParseDate(s); err == nil {
 		// End of day
-		return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, time.Local), nil
+		return model.EndOfDay(t), nil
 	}
 
 	return time.Time{}, fmt.Errorf("expected YYYY-MM-DD or RFC3339 format, got %q", s)
@@ -4,11 +4,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/jacksmith/tk/internal/cli"
 	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/ops"
-	"github.com/jacksmith/tk/internal/storage"
 	"github.com/spf13/cobra"
 )
 
@@ -29,6 +29,10 @@ Filter flags:
 
   -p, --project Limit to a specific project (by prefix or ID)
 
+Open waits show when they come due: "in 3d" or "2d overdue" for time waits
+and manual waits with a check_after date, and how long ago they were
+created ("12d old") for other manual waits.
+
 Waits are sorted by ID.`,
 	RunE: runWaits,
 }
@@ -58,7 +62,7 @@ func runWaits(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	s, err := storage.Open(".")
+	s, err := openStore()
 	if err != nil {
 		return err
 	}
@@ -90,14 +94,38 @@ func runWaits(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	now := time.Now()
 	table := cli.NewTable()
 	for _, r := range results {
-		table.AddRow(r.Wait.ID, formatWaitState(r.State), r.Wait.DisplayText())
+		table.AddRow(r.Wait.ID, formatWaitState(r.State), formatWaitTiming(&r.Wait, now), r.Wait.DisplayText())
 	}
 	table.Render(os.Stdout)
 	return nil
 }
 
+// formatWaitTiming describes an open wait relative to now: how soon its
+// time or check date comes up, or for a manual wait with no check date, how
+// long it has been pending. Closed waits get no annotation.
+func formatWaitTiming(w *model.Wait, now time.Time) string {
+	if w.Status != model.WaitStatusOpen {
+		return ""
+	}
+	due := w.ResolutionCriteria.After
+	if w.ResolutionCriteria.Type == model.ResolutionTypeManual {
+		due = w.ResolutionCriteria.CheckAfter
+	}
+	if due == nil {
+		if w.Created.IsZero() {
+			return ""
+		}
+		return cli.Age(w.Created, now)
+	}
+	if due.Before(now) {
+		return cli.Red(cli.Until(*due, now))
+	}
+	return cli.Until(*due, now)
+}
+
 func resolveWaitStateFilter() *model.WaitState {
 	var state model.WaitState
 	switch {
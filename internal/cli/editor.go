@@ -4,12 +4,13 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"strings"
+
+	"github.com/jacksmith/tk/internal/model"
 )
 
-// EditInEditor opens content in $EDITOR and returns modified content.
+// EditInEditor opens content in the user's editor and returns modified content.
 // The suffix is used for the temporary file (e.g., ".yaml" for syntax highlighting).
-// Returns error if EDITOR/VISUAL not set or editor exits non-zero.
+// Returns error if TK_EDITOR/VISUAL/EDITOR not set or editor exits non-zero.
 func EditInEditor(content []byte, suffix string) ([]byte, error) {
 	editor := getEditor()
 	if editor == "" {
@@ -48,8 +49,12 @@ func EditInEditor(content []byte, suffix string) ([]byte, error) {
 }
 
 // getEditor returns the editor command from environment.
-// Checks VISUAL first (for graphical editors), then EDITOR.
+// Checks TK_EDITOR first (tk-specific override), then VISUAL
+// (for graphical editors), then EDITOR.
 func getEditor() string {
+	if editor := os.Getenv("TK_EDITOR"); editor != "" {
+		return editor
+	}
 	if editor := os.Getenv("VISUAL"); editor != "" {
 		return editor
 	}
@@ -59,7 +64,10 @@ func getEditor() string {
 // runEditor executes the editor with the given file path.
 func runEditor(editor, path string) error {
 	// Split editor into command and args (e.g., "code --wait")
-	parts := strings.Fields(editor)
+	parts, err := model.SplitCommand(editor)
+	if err != nil {
+		return err
+	}
 	if len(parts) == 0 {
 		return fmt.Errorf("empty editor command")
 	}
@@ -33,6 +33,17 @@ func TestGetEditor(t *testing.T) {
 	assert.Equal(t, "", getEditor())
 }
 
+func TestGetEditorTKEditorOverride(t *testing.T) {
+	t.Setenv("TK_EDITOR", "nano")
+	t.Setenv("VISUAL", "code --wait")
+	t.Setenv("EDITOR", "vim")
+	assert.Equal(t, "nano", getEditor())
+
+	// Falls back to VISUAL when TK_EDITOR is empty
+	t.Setenv("TK_EDITOR", "")
+	assert.Equal(t, "code --wait", getEditor())
+}
+
 func TestEditInEditorNoEditor(t *testing.T) {
 	// Save original values
 	origVisual := os.Getenv("VISUAL")
@@ -89,6 +100,27 @@ func TestEditInEditorNonZeroExit(t *testing.T) {
 	assert.Contains(t, err.Error(), "editor exited with status")
 }
 
+func TestEditInEditorWithArgs(t *testing.T) {
+	t.Setenv("TK_EDITOR", "")
+	t.Setenv("VISUAL", "")
+
+	// Script writes its first argument into the file (its last argument)
+	script, err := os.CreateTemp("", "test-editor-*.sh")
+	require.NoError(t, err)
+	defer os.Remove(script.Name())
+
+	_, err = script.WriteString("#!/bin/sh\neval last=\\${$#}\necho \"$1\" > \"$last\"\n")
+	require.NoError(t, err)
+	script.Close()
+	os.Chmod(script.Name(), 0755)
+
+	t.Setenv("EDITOR", script.Name()+` "two words"`)
+
+	result, err := EditInEditor([]byte("original"), ".yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "two words\n", string(result))
+}
+
 func TestEditInEditorContentModified(t *testing.T) {
 	// Save original values
 	origVisual := os.Getenv("VISUAL")
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 
 	"golang.org/x/term"
@@ -77,14 +78,40 @@ func Gray(s string) string {
 	return colorGray + s + colorReset
 }
 
+// Hyperlink returns text as an OSC 8 terminal hyperlink to url if colors
+// are enabled, so supporting terminals make it clickable.
+func Hyperlink(url, text string) string {
+	if !colorEnabled {
+		return text
+	}
+	return "\033]8;;" + url + "\033\\" + text + "\033]8;;\033\\"
+}
+
 // DefaultMaxTitleWidth is the default maximum visible width for title columns.
 const DefaultMaxTitleWidth = 60
 
+// minFitWidth is the narrowest a column is shrunk to by FitColumn.
+const minFitWidth = 10
+
+// TerminalWidth returns the width of the terminal attached to stdout,
+// falling back to $COLUMNS. Returns 0 if the width is unknown.
+func TerminalWidth() int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		return cols
+	}
+	return 0
+}
+
 // Table formats columnar output with automatic column width calculation.
 type Table struct {
 	rows      [][]string
 	colWidths []int
 	maxWidths map[int]int // optional per-column max visible width
+	fitCol    int         // column shrunk to fit fitWidth
+	fitWidth  int         // total line width to fit (0 = no limit)
 }
 
 // NewTable creates a new empty table.
@@ -101,6 +128,14 @@ func (t *Table) SetMaxWidth(col, maxWidth int) {
 	t.maxWidths[col] = maxWidth
 }
 
+// FitColumn shrinks col at render time so that each line fits within
+// totalWidth visible characters, truncating with an ellipsis. The column is
+// never shrunk below a small minimum width. A totalWidth of 0 disables fitting.
+func (t *Table) FitColumn(col, totalWidth int) {
+	t.fitCol = col
+	t.fitWidth = totalWidth
+}
+
 // AddRow adds a row to the table.
 func (t *Table) AddRow(cols ...string) {
 	// Expand colWidths if needed
@@ -125,6 +160,10 @@ func (t *Table) AddRow(cols ...string) {
 
 // Render writes the table to w with columns separated by two spaces.
 func (t *Table) Render(w io.Writer) {
+	if t.fitWidth > 0 && t.fitCol < len(t.colWidths) {
+		t.applyFit()
+	}
+
 	for _, row := range t.rows {
 		var parts []string
 		for i, col := range row {
@@ -145,6 +184,29 @@ func (t *Table) Render(w io.Writer) {
 	}
 }
 
+// applyFit caps the fit column so the widest line fits within fitWidth.
+func (t *Table) applyFit() {
+	used := 2 * (len(t.colWidths) - 1) // column separators
+	for i, width := range t.colWidths {
+		if i != t.fitCol {
+			used += width
+		}
+	}
+
+	available := t.fitWidth - used
+	if available < minFitWidth {
+		available = minFitWidth
+	}
+	if maxW, ok := t.maxWidths[t.fitCol]; ok && maxW < available {
+		available = maxW
+	}
+
+	t.SetMaxWidth(t.fitCol, available)
+	if t.colWidths[t.fitCol] > available {
+		t.colWidths[t.fitCol] = available
+	}
+}
+
 // Truncate returns s truncated to maxWidth visible characters. If s exceeds
 // maxWidth, it is cut and "..." is appended (counted within the limit).
 // ANSI escape codes are preserved up to the truncation point with a reset appended.
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsTerminal(t *testing.T) {
@@ -47,6 +48,16 @@ func TestColorFunctions(t *testing.T) {
 	SetColorEnabled(true)
 }
 
+func TestHyperlink(t *testing.T) {
+	SetColorEnabled(true)
+	assert.Equal(t, "\033]8;;https://example.com\033\\site\033]8;;\033\\", Hyperlink("https://example.com", "site"))
+
+	SetColorEnabled(false)
+	assert.Equal(t, "site", Hyperlink("https://example.com", "site"))
+
+	SetColorEnabled(true)
+}
+
 func TestColorEnabled(t *testing.T) {
 	SetColorEnabled(true)
 	assert.True(t, ColorEnabled())
@@ -260,3 +271,61 @@ func TestTableUnevenRows(t *testing.T) {
 	assert.Contains(t, output, "a")
 	assert.Contains(t, output, "d")
 }
+
+func TestTableFitColumn(t *testing.T) {
+	table := NewTable()
+	table.FitColumn(1, 40)
+
+	table.AddRow("BY-01", strings.Repeat("x", 200), "[tag]")
+	table.AddRow("BY-02", "short", "[tag]")
+
+	var buf bytes.Buffer
+	table.Render(&buf)
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.LessOrEqual(t, visibleWidth(line), 40)
+		assert.True(t, strings.HasSuffix(line, "[tag]"))
+	}
+	assert.Contains(t, lines[0], "...")
+	assert.Contains(t, lines[1], "short")
+}
+
+func TestTableFitColumnMinimumWidth(t *testing.T) {
+	table := NewTable()
+	table.FitColumn(1, 5)
+
+	table.AddRow("BY-01", strings.Repeat("x", 50))
+
+	var buf bytes.Buffer
+	table.Render(&buf)
+
+	// The fit column never shrinks below the minimum
+	assert.Contains(t, buf.String(), strings.Repeat("x", minFitWidth-3)+"...")
+}
+
+func TestTableFitColumnRespectsMaxWidth(t *testing.T) {
+	table := NewTable()
+	table.SetMaxWidth(1, 10)
+	table.FitColumn(1, 200)
+
+	table.AddRow("BY-01", strings.Repeat("x", 50))
+
+	var buf bytes.Buffer
+	table.Render(&buf)
+
+	assert.Equal(t, "BY-01  "+strings.Repeat("x", 7)+"...\n", buf.String())
+}
+
+func TestTerminalWidthFromColumns(t *testing.T) {
+	// Test output is not a terminal, so $COLUMNS is used
+	t.Setenv("COLUMNS", "120")
+	assert.Equal(t, 120, TerminalWidth())
+
+	t.Setenv("COLUMNS", "")
+	assert.Equal(t, 0, TerminalWidth())
+
+	t.Setenv("COLUMNS", "bogus")
+	assert.Equal(t, 0, TerminalWidth())
+}
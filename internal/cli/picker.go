@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PickOption is a single selectable entry in an interactive picker.
+type PickOption struct {
+	ID    string
+	Label string
+}
+
+// Pick prints a numbered list of options to out and reads the selected
+// number from in. It returns the ID of the chosen option.
+func Pick(in io.Reader, out io.Writer, options []PickOption) (string, error) {
+	if len(options) == 0 {
+		return "", fmt.Errorf("nothing to pick from")
+	}
+
+	width := len(strconv.Itoa(len(options)))
+	for i, opt := range options {
+		fmt.Fprintf(out, "%*d) %s  %s\n", width, i+1, opt.ID, opt.Label)
+	}
+	fmt.Fprintf(out, "Select [1-%d]: ", len(options))
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read selection: %w", err)
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return "", fmt.Errorf("no selection made")
+	}
+
+	n, err := strconv.Atoi(line)
+	if err != nil || n < 1 || n > len(options) {
+		return "", fmt.Errorf("invalid selection: %q", line)
+	}
+
+	return options[n-1].ID, nil
+}
+
+// Confirm prints prompt followed by " [y/N]: " to out and reads a yes/no
+// answer from in. Anything other than y or yes (including no input) is a
+// no. To ask several questions in a row, pass the same *bufio.Reader each
+// time so input buffered for later answers is not lost.
+func Confirm(in io.Reader, out io.Writer, prompt string) (bool, error) {
+	fmt.Fprintf(out, "%s [y/N]: ", prompt)
+
+	br, ok := in.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(in)
+	}
+	line, err := br.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read answer: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
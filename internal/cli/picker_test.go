@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPick(t *testing.T) {
+	options := []PickOption{
+		{ID: "BY-01", Label: "First"},
+		{ID: "BY-02", Label: "Second"},
+	}
+
+	t.Run("returns selected ID", func(t *testing.T) {
+		var out bytes.Buffer
+		id, err := Pick(strings.NewReader("2\n"), &out, options)
+		require.NoError(t, err)
+		assert.Equal(t, "BY-02", id)
+		assert.Contains(t, out.String(), "1) BY-01  First")
+		assert.Contains(t, out.String(), "2) BY-02  Second")
+	})
+
+	t.Run("accepts input without trailing newline", func(t *testing.T) {
+		var out bytes.Buffer
+		id, err := Pick(strings.NewReader("1"), &out, options)
+		require.NoError(t, err)
+		assert.Equal(t, "BY-01", id)
+	})
+
+	t.Run("rejects out of range selection", func(t *testing.T) {
+		var out bytes.Buffer
+		_, err := Pick(strings.NewReader("3\n"), &out, options)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid selection")
+	})
+
+	t.Run("rejects non-numeric selection", func(t *testing.T) {
+		var out bytes.Buffer
+		_, err := Pick(strings.NewReader("abc\n"), &out, options)
+		require.Error(t, err)
+	})
+
+	t.Run("empty input is an error", func(t *testing.T) {
+		var out bytes.Buffer
+		_, err := Pick(strings.NewReader(""), &out, options)
+		require.Error(t, err)
+	})
+
+	t.Run("no options is an error", func(t *testing.T) {
+		var out bytes.Buffer
+		_, err := Pick(strings.NewReader("1\n"), &out, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nothing to pick")
+	})
+}
+
+func TestConfirm(t *testing.T) {
+	t.Run("yes answers", func(t *testing.T) {
+		for _, answer := range []string{"y\n", "YES\n", " yes "} {
+			var out bytes.Buffer
+			ok, err := Confirm(strings.NewReader(answer), &out, "Remove it?")
+			require.NoError(t, err)
+			assert.True(t, ok, "answer %q", answer)
+			assert.Equal(t, "Remove it? [y/N]: ", out.String())
+		}
+	})
+
+	t.Run("anything else is no", func(t *testing.T) {
+		for _, answer := range []string{"n\n", "\n", "", "sure\n"} {
+			var out bytes.Buffer
+			ok, err := Confirm(strings.NewReader(answer), &out, "Remove it?")
+			require.NoError(t, err)
+			assert.False(t, ok, "answer %q", answer)
+		}
+	})
+
+	t.Run("shared reader answers several prompts", func(t *testing.T) {
+		var out bytes.Buffer
+		in := bufio.NewReader(strings.NewReader("n\ny\n"))
+		first, err := Confirm(in, &out, "First?")
+		require.NoError(t, err)
+		second, err := Confirm(in, &out, "Second?")
+		require.NoError(t, err)
+		assert.False(t, first)
+		assert.True(t, second)
+	})
+}
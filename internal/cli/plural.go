@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Plural returns the form of noun that goes with a count of n: the noun
+// itself for exactly one, otherwise its regular English plural ("wait" ->
+// "waits", "dependency" -> "dependencies", "match" -> "matches"). For a
+// phrase such as "open task" only the last word changes.
+func Plural(n int, noun string) string {
+	if n == 1 || noun == "" {
+		return noun
+	}
+	switch {
+	case strings.HasSuffix(noun, "y") && !endsInVowelY(noun):
+		return noun[:len(noun)-1] + "ies"
+	case strings.HasSuffix(noun, "s"), strings.HasSuffix(noun, "x"),
+		strings.HasSuffix(noun, "ch"), strings.HasSuffix(noun, "sh"):
+		return noun + "es"
+	default:
+		return noun + "s"
+	}
+}
+
+// Count formats n with the matching form of noun, e.g. "1 wait", "2 waits".
+func Count(n int, noun string) string {
+	return fmt.Sprintf("%d %s", n, Plural(n, noun))
+}
+
+// CountOrNo is like Count but spells zero as "no", e.g. "no open tasks".
+func CountOrNo(n int, noun string) string {
+	if n == 0 {
+		return "no " + Plural(n, noun)
+	}
+	return Count(n, noun)
+}
+
+// endsInVowelY reports whether noun ends in a vowel followed by "y", as in
+// "day", which takes a plain "s".
+func endsInVowelY(noun string) bool {
+	if len(noun) < 2 {
+		return false
+	}
+	return strings.ContainsRune("aeiou", rune(noun[len(noun)-2]))
+}
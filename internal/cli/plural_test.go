@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlural(t *testing.T) {
+	tests := []struct {
+		n    int
+		noun string
+		want string
+	}{
+		{1, "wait", "wait"},
+		{0, "wait", "waits"},
+		{2, "wait", "waits"},
+		{2, "open task", "open tasks"},
+		{3, "dependency", "dependencies"},
+		{3, "day", "days"},
+		{2, "match", "matches"},
+		{2, "status", "statuses"},
+		{2, "", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Plural(tt.n, tt.noun), "Plural(%d, %q)", tt.n, tt.noun)
+	}
+}
+
+func TestCount(t *testing.T) {
+	assert.Equal(t, "1 wait", Count(1, "wait"))
+	assert.Equal(t, "2 waits", Count(2, "wait"))
+	assert.Equal(t, "0 waits", Count(0, "wait"))
+}
+
+func TestCountOrNo(t *testing.T) {
+	assert.Equal(t, "no open tasks", CountOrNo(0, "open task"))
+	assert.Equal(t, "1 open task", CountOrNo(1, "open task"))
+	assert.Equal(t, "4 open tasks", CountOrNo(4, "open task"))
+}
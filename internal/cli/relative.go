@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ParseDuration parses a compact duration as used on the command line: a
+// whole number of hours, days, or weeks, e.g. "24h", "3d", "2w".
+func ParseDuration(s string) (time.Duration, error) {
+	units := map[byte]time.Duration{'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	if len(s) >= 2 {
+		if unit, ok := units[s[len(s)-1]]; ok {
+			if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && n >= 0 {
+				return time.Duration(n) * unit, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid duration %q: use a number followed by h, d, or w (e.g. 3d)", s)
+}
+
+// Until describes how far t is from now in a compact unit: "in 3d" for a
+// time still ahead, "2d overdue" for one that has passed.
+func Until(t, now time.Time) string {
+	if d := t.Sub(now); d >= 0 {
+		return "in " + shortDuration(d)
+	}
+	return shortDuration(now.Sub(t)) + " overdue"
+}
+
+// Age describes how long ago t was in a compact unit, e.g. "5d old".
+func Age(t, now time.Time) string {
+	return shortDuration(now.Sub(t)) + " old"
+}
+
+// shortDuration renders d rounded to its largest sensible unit: minutes
+// under an hour, hours under two days, and days beyond that.
+func shortDuration(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", d.Round(time.Minute)/time.Minute)
+	case d < 2*day:
+		return fmt.Sprintf("%dh", d.Round(time.Hour)/time.Hour)
+	default:
+		return fmt.Sprintf("%dd", d.Round(day)/day)
+	}
+}
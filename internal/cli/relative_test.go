@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUntil(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		offset time.Duration
+		want   string
+	}{
+		{0, "in 0m"},
+		{20 * time.Minute, "in 20m"},
+		{5 * time.Hour, "in 5h"},
+		{30 * time.Hour, "in 30h"},
+		{3 * 24 * time.Hour, "in 3d"},
+		{7*24*time.Hour - time.Second, "in 7d"},
+		{-45 * time.Minute, "45m overdue"},
+		{-2 * 24 * time.Hour, "2d overdue"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Until(now.Add(tt.offset), now), "Until(now%+v)", tt.offset)
+	}
+}
+
+func TestAge(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, "3h old", Age(now.Add(-3*time.Hour), now))
+	assert.Equal(t, "12d old", Age(now.AddDate(0, 0, -12), now))
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"24h", 24 * time.Hour},
+		{"3d", 3 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"0d", 0},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.in)
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+
+	for _, bad := range []string{"", "d", "7", "3x", "-1d", "1.5d", "3 d"} {
+		_, err := ParseDuration(bad)
+		assert.Error(t, err, bad)
+	}
+}
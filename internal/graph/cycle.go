@@ -72,6 +72,19 @@ func (g *Graph) WouldCreateCycle(from, to string) bool {
 	return g.CheckCycle(from, to) != nil
 }
 
+// Path returns a chain of blocked_by edges leading from `from` to `to`,
+// starting with from and ending with to, or nil if `from` doesn't depend on
+// `to`, directly or transitively.
+//
+// Example: if BY-05 is blocked by BY-03 and BY-03 by BY-07, then
+// Path("BY-05", "BY-07") returns ["BY-05", "BY-03", "BY-07"].
+func (g *Graph) Path(from, to string) []string {
+	if from == to {
+		return nil
+	}
+	return g.findPath(from, to)
+}
+
 // AddEdge temporarily adds an edge for validation purposes.
 // This modifies the graph in place. Use with caution.
 // Returns a function to remove the edge.
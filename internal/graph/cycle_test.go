@@ -258,6 +258,36 @@ func TestWouldCreateCycle(t *testing.T) {
 	assert.False(t, g.WouldCreateCycle("TS-02", "TS-03"))
 }
 
+func TestPath(t *testing.T) {
+	p := &model.ProjectFile{
+		Project: model.Project{
+			ID:     "test",
+			Prefix: "TS",
+			Name:   "Test Project",
+			Status: model.ProjectStatusActive,
+		},
+		Tasks: []model.Task{
+			makeTask("TS-01"),
+			makeTask("TS-02", "TS-01"),
+			makeTask("TS-03", "TS-02", "TS-04W"),
+			makeTask("TS-05"),
+		},
+		Waits: []model.Wait{
+			makeWait("TS-04W"),
+		},
+	}
+
+	g := BuildGraph(p)
+
+	assert.Equal(t, []string{"TS-03", "TS-02", "TS-01"}, g.Path("TS-03", "TS-01"))
+	assert.Equal(t, []string{"TS-03", "TS-04W"}, g.Path("TS-03", "TS-04W"))
+
+	// Edges are only followed from blocked item to blocker
+	assert.Nil(t, g.Path("TS-01", "TS-03"))
+	assert.Nil(t, g.Path("TS-05", "TS-01"))
+	assert.Nil(t, g.Path("TS-01", "TS-01"))
+}
+
 func TestAddEdge(t *testing.T) {
 	p := &model.ProjectFile{
 		Project: model.Project{
@@ -56,29 +56,60 @@ func BuildGraph(p *model.ProjectFile) *Graph {
 	return g
 }
 
+// BuildProjectGraph constructs the dependency graph between projects. Each
+// project is a node named by its blocker reference (e.g. @BY), with an edge
+// to every project that one of its tasks or waits is blocked by as a whole.
+func BuildProjectGraph(projects []*model.ProjectFile) *Graph {
+	g := &Graph{
+		blockedBy: make(map[string][]string),
+		blocking:  make(map[string][]string),
+		nodes:     make(map[string]bool),
+	}
+
+	for _, p := range projects {
+		ref := model.FormatProjectRef(p.Prefix)
+		g.nodes[ref] = true
+		for _, blockerRef := range p.ProjectRefs() {
+			g.blockedBy[ref] = append(g.blockedBy[ref], blockerRef)
+			g.blocking[blockerRef] = append(g.blocking[blockerRef], ref)
+		}
+	}
+
+	return g
+}
+
 // BlockedBy returns the direct blockers of the given node.
 // Returns empty slice if the node doesn't exist or has no blockers.
+// The result is sorted for deterministic output.
 func (g *Graph) BlockedBy(id string) []string {
-	blockers := g.blockedBy[id]
-	if blockers == nil {
-		return []string{}
-	}
-	// Return a copy to prevent mutation
-	result := make([]string, len(blockers))
-	copy(result, blockers)
-	return result
+	return sortedCopy(g.blockedBy[id])
 }
 
 // Blocking returns the nodes directly blocked by the given node.
 // Returns empty slice if the node doesn't exist or blocks nothing.
+// The result is sorted for deterministic output.
 func (g *Graph) Blocking(id string) []string {
-	blocked := g.blocking[id]
-	if blocked == nil {
-		return []string{}
-	}
-	// Return a copy to prevent mutation
-	result := make([]string, len(blocked))
-	copy(result, blocked)
+	return sortedCopy(g.blocking[id])
+}
+
+// sortedCopy returns a copy of ids sorted by prefix and then ID number, so
+// TS-100 comes after TS-99, and callers can't mutate the graph's adjacency
+// lists. A task sorts before the wait with the same number, and project refs
+// like @BY, which have no number, come first. A nil input yields an empty
+// slice.
+func sortedCopy(ids []string) []string {
+	result := make([]string, len(ids))
+	copy(result, ids)
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if pa, pb := model.ExtractPrefix(a), model.ExtractPrefix(b); pa != pb {
+			return pa < pb
+		}
+		if na, nb := model.ExtractNumber(a), model.ExtractNumber(b); na != nb {
+			return na < nb
+		}
+		return a < b
+	})
 	return result
 }
 
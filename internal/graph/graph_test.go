@@ -473,3 +473,102 @@ func TestTransitiveBlocking_Sorted(t *testing.T) {
 	// Result should be sorted
 	assert.Equal(t, []string{"TS-02", "TS-03", "TS-04"}, g.TransitiveBlocking("TS-01"))
 }
+
+func TestBlockedBy_Sorted(t *testing.T) {
+	p := &model.ProjectFile{
+		Project: model.Project{
+			ID:     "test",
+			Prefix: "TS",
+			Name:   "Test Project",
+			Status: model.ProjectStatusActive,
+		},
+		Tasks: []model.Task{
+			makeTask("TS-01"),
+			makeTask("TS-02"),
+			makeTask("TS-03"),
+			makeTask("TS-04", "TS-03", "TS-01W", "TS-01", "TS-02"),
+		},
+		Waits: []model.Wait{
+			makeWait("TS-01W"),
+		},
+	}
+
+	g := BuildGraph(p)
+
+	// Result should be sorted regardless of blocked_by order
+	assert.Equal(t, []string{"TS-01", "TS-01W", "TS-02", "TS-03"}, g.BlockedBy("TS-04"))
+}
+
+func TestBlocking_Sorted(t *testing.T) {
+	p := &model.ProjectFile{
+		Project: model.Project{
+			ID:     "test",
+			Prefix: "TS",
+			Name:   "Test Project",
+			Status: model.ProjectStatusActive,
+		},
+		Tasks: []model.Task{
+			makeTask("TS-01"),
+			makeTask("TS-04", "TS-01"),
+			makeTask("TS-02", "TS-01"),
+			makeTask("TS-03", "TS-01"),
+		},
+		Waits: []model.Wait{
+			makeWait("TS-01W", "TS-01"),
+		},
+	}
+
+	g := BuildGraph(p)
+
+	// Waits are added after tasks, but the result is still sorted
+	assert.Equal(t, []string{"TS-01W", "TS-02", "TS-03", "TS-04"}, g.Blocking("TS-01"))
+}
+
+func TestBuildProjectGraph(t *testing.T) {
+	// BY waits on HM as a whole, and HM on OT
+	projects := []*model.ProjectFile{
+		{
+			Project: model.Project{Prefix: "BY"},
+			Tasks:   []model.Task{{ID: "BY-01", BlockedBy: []string{"@HM", "BY-02"}}, {ID: "BY-02"}},
+		},
+		{
+			Project: model.Project{Prefix: "HM"},
+			Waits:   []model.Wait{{ID: "HM-01W", BlockedBy: []string{"@OT"}}},
+		},
+		{Project: model.Project{Prefix: "OT"}},
+	}
+
+	g := BuildProjectGraph(projects)
+	assert.Equal(t, []string{"@HM"}, g.BlockedBy("@BY"))
+	assert.Equal(t, []string{"@HM", "@OT"}, g.TransitiveBlockedBy("@BY"))
+	assert.Equal(t, []string{"@BY"}, g.Blocking("@HM"))
+	assert.False(t, g.HasNode("BY-01"))
+
+	// OT waiting on BY would close the loop through HM
+	assert.Equal(t, []string{"@BY", "@HM", "@OT", "@BY"}, g.CheckCycle("@OT", "@BY"))
+	assert.Nil(t, g.CheckCycle("@BY", "@OT"))
+}
+
+func TestBlockedBy_SortedByNumber(t *testing.T) {
+	p := &model.ProjectFile{
+		Project: model.Project{
+			ID:     "test",
+			Prefix: "TS",
+			Name:   "Test Project",
+			Status: model.ProjectStatusActive,
+		},
+		Tasks: []model.Task{
+			makeTask("TS-99"),
+			makeTask("TS-100"),
+			makeTask("TS-101", "TS-100", "@OT", "TS-99", "TS-98W"),
+		},
+		Waits: []model.Wait{
+			makeWait("TS-98W"),
+		},
+	}
+
+	g := BuildGraph(p)
+
+	// TS-100 sorts after TS-99 even though it's smaller as a string
+	assert.Equal(t, []string{"@OT", "TS-98W", "TS-99", "TS-100"}, g.BlockedBy("TS-101"))
+}
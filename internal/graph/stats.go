@@ -0,0 +1,159 @@
+package graph
+
+import "sort"
+
+// Stats summarizes the structure of a dependency graph.
+type Stats struct {
+	Nodes      int // tasks and waits
+	Edges      int // blocked_by links between nodes in the graph
+	MaxDepth   int // links in the longest blocker chain
+	Roots      int // nodes nothing depends on
+	Leaves     int // blockers that have no blockers of their own
+	Components int // groups of nodes connected by blocked_by links
+}
+
+// Stats computes structural metrics for the graph. Blocker references to
+// items outside the graph (orphans, project references) are not counted as
+// edges. Cycles don't make the depth infinite: a chain stops when it would
+// revisit a node.
+func (g *Graph) Stats() Stats {
+	st := Stats{
+		Nodes:      len(g.nodes),
+		MaxDepth:   g.MaxDepth(),
+		Components: len(g.Components()),
+	}
+	for id := range g.nodes {
+		blockers := g.internalBlockers(id)
+		st.Edges += len(blockers)
+		if len(g.blocking[id]) == 0 {
+			st.Roots++
+		} else if len(blockers) == 0 {
+			st.Leaves++
+		}
+	}
+	return st
+}
+
+// MaxDepth returns the number of links in the longest chain of blockers in
+// the graph, or 0 if no node has a blocker.
+func (g *Graph) MaxDepth() int {
+	depth := make(map[string]int)
+	onPath := make(map[string]bool)
+	var visit func(id string) int
+	visit = func(id string) int {
+		if d, ok := depth[id]; ok {
+			return d
+		}
+		onPath[id] = true
+		d := 0
+		for _, blockerID := range g.internalBlockers(id) {
+			if onPath[blockerID] {
+				continue // back edge of a cycle
+			}
+			d = max(d, visit(blockerID)+1)
+		}
+		onPath[id] = false
+		depth[id] = d
+		return d
+	}
+
+	maxDepth := 0
+	for _, id := range g.Nodes() {
+		maxDepth = max(maxDepth, visit(id))
+	}
+	return maxDepth
+}
+
+// LongestPath returns the longest chain of blockers in the graph, ordered
+// from the deepest blocker to the item at the end of the chain, so the
+// items come in the order they have to be done. It returns nil for an empty
+// graph and a single node if no node has a blocker. Among chains of equal
+// length, the one ending at the lowest ID wins. Like MaxDepth, it breaks
+// cycles by not revisiting a node on the current chain.
+func (g *Graph) LongestPath() []string {
+	depth := make(map[string]int)
+	next := make(map[string]string) // the blocker continuing the longest chain below a node
+	onPath := make(map[string]bool)
+	var visit func(id string) int
+	visit = func(id string) int {
+		if d, ok := depth[id]; ok {
+			return d
+		}
+		onPath[id] = true
+		d := 0
+		for _, blockerID := range sortedCopy(g.internalBlockers(id)) {
+			if onPath[blockerID] {
+				continue // back edge of a cycle
+			}
+			if bd := visit(blockerID) + 1; bd > d {
+				d = bd
+				next[id] = blockerID
+			}
+		}
+		onPath[id] = false
+		depth[id] = d
+		return d
+	}
+
+	start, maxDepth := "", -1
+	for _, id := range g.Nodes() {
+		if d := visit(id); d > maxDepth {
+			start, maxDepth = id, d
+		}
+	}
+	if start == "" {
+		return nil
+	}
+
+	path := []string{start}
+	for id := start; next[id] != ""; id = next[id] {
+		path = append(path, next[id])
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Components returns the graph's connected components, treating blocked_by
+// links as undirected. Each component is sorted, and components are ordered
+// by their first node.
+func (g *Graph) Components() [][]string {
+	seen := make(map[string]bool)
+	var components [][]string
+	for _, start := range g.Nodes() {
+		if seen[start] {
+			continue
+		}
+		var component []string
+		queue := []string{start}
+		seen[start] = true
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			component = append(component, id)
+			neighbors := append(g.internalBlockers(id), g.blocking[id]...)
+			for _, next := range neighbors {
+				if g.nodes[next] && !seen[next] {
+					seen[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		sort.Strings(component)
+		components = append(components, component)
+	}
+	return components
+}
+
+// internalBlockers returns the direct blockers of id that are nodes of the
+// graph, skipping references to missing items.
+func (g *Graph) internalBlockers(id string) []string {
+	var blockers []string
+	for _, blockerID := range g.blockedBy[id] {
+		if g.nodes[blockerID] {
+			blockers = append(blockers, blockerID)
+		}
+	}
+	return blockers
+}
@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	// Chain TS-04 -> TS-03 -> TS-02 -> TS-01W, TS-05 also blocked by
+	// TS-02, TS-06 isolated, TS-07 blocked by a missing item
+	p := &model.ProjectFile{
+		Tasks: []model.Task{
+			makeTask("TS-02", "TS-01W"),
+			makeTask("TS-03", "TS-02"),
+			makeTask("TS-04", "TS-03"),
+			makeTask("TS-05", "TS-02"),
+			makeTask("TS-06"),
+			makeTask("TS-07", "TS-99"),
+		},
+		Waits: []model.Wait{makeWait("TS-01W")},
+	}
+
+	st := BuildGraph(p).Stats()
+
+	assert.Equal(t, Stats{
+		Nodes:      7,
+		Edges:      4,
+		MaxDepth:   3,
+		Roots:      4, // TS-04, TS-05, TS-06, TS-07
+		Leaves:     1, // TS-01W
+		Components: 3,
+	}, st)
+}
+
+func TestStats_Empty(t *testing.T) {
+	st := BuildGraph(&model.ProjectFile{}).Stats()
+	assert.Equal(t, Stats{}, st)
+}
+
+func TestMaxDepth_Cycle(t *testing.T) {
+	p := &model.ProjectFile{
+		Tasks: []model.Task{
+			makeTask("TS-01", "TS-03"),
+			makeTask("TS-02", "TS-01"),
+			makeTask("TS-03", "TS-02"),
+		},
+	}
+
+	// Terminates, and the longest simple chain has two links
+	assert.Equal(t, 2, BuildGraph(p).MaxDepth())
+}
+
+func TestLongestPath(t *testing.T) {
+	// Same shape as TestStats: the chain through TS-04 is the longest
+	p := &model.ProjectFile{
+		Tasks: []model.Task{
+			makeTask("TS-02", "TS-01W"),
+			makeTask("TS-03", "TS-02"),
+			makeTask("TS-04", "TS-03"),
+			makeTask("TS-05", "TS-02"),
+			makeTask("TS-06"),
+			makeTask("TS-07", "TS-99"),
+		},
+		Waits: []model.Wait{makeWait("TS-01W")},
+	}
+
+	assert.Equal(t, []string{"TS-01W", "TS-02", "TS-03", "TS-04"}, BuildGraph(p).LongestPath())
+}
+
+func TestLongestPath_TiesAndEdgeCases(t *testing.T) {
+	assert.Nil(t, BuildGraph(&model.ProjectFile{}).LongestPath())
+
+	// No links: any single node is a longest chain
+	p := &model.ProjectFile{Tasks: []model.Task{makeTask("TS-02"), makeTask("TS-01")}}
+	assert.Equal(t, []string{"TS-01"}, BuildGraph(p).LongestPath())
+
+	// Two chains of equal length: the one ending at the lower ID wins
+	p = &model.ProjectFile{
+		Tasks: []model.Task{
+			makeTask("TS-01"),
+			makeTask("TS-02"),
+			makeTask("TS-03", "TS-02", "TS-01"),
+			makeTask("TS-04", "TS-01"),
+		},
+	}
+	assert.Equal(t, []string{"TS-01", "TS-03"}, BuildGraph(p).LongestPath())
+
+	// Cycles terminate
+	p = &model.ProjectFile{
+		Tasks: []model.Task{
+			makeTask("TS-01", "TS-03"),
+			makeTask("TS-02", "TS-01"),
+			makeTask("TS-03", "TS-02"),
+		},
+	}
+	assert.Len(t, BuildGraph(p).LongestPath(), 3)
+}
+
+func TestComponents(t *testing.T) {
+	p := &model.ProjectFile{
+		Tasks: []model.Task{
+			makeTask("TS-01"),
+			makeTask("TS-02", "TS-01"),
+			makeTask("TS-03"),
+			makeTask("TS-04", "TS-03W"),
+		},
+		Waits: []model.Wait{makeWait("TS-03W")},
+	}
+
+	assert.Equal(t, [][]string{
+		{"TS-01", "TS-02"},
+		{"TS-03"},
+		{"TS-03W", "TS-04"},
+	}, BuildGraph(p).Components())
+}
@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TopoSort returns every node in an order where each comes after all of its
+// blockers. Whenever several nodes could come next, the one less orders first
+// is taken, so the result is stable; a nil less takes the smallest ID.
+// Blocker references to items outside the graph are ignored. If some nodes
+// can't be ordered because of a dependency cycle, it returns an error naming
+// them rather than a partial order.
+func (g *Graph) TopoSort(less func(a, b string) bool) ([]string, error) {
+	if less == nil {
+		less = func(a, b string) bool { return a < b }
+	}
+
+	// pending counts each node's blockers that are not in the order yet
+	pending := make(map[string]int)
+	var available []string
+	for _, id := range g.Nodes() {
+		pending[id] = len(g.internalBlockers(id))
+		if pending[id] == 0 {
+			available = append(available, id)
+		}
+	}
+
+	order := make([]string, 0, len(g.nodes))
+	for len(available) > 0 {
+		best := 0
+		for i := range available {
+			if less(available[i], available[best]) {
+				best = i
+			}
+		}
+		id := available[best]
+		available = append(available[:best], available[best+1:]...)
+		order = append(order, id)
+
+		for _, dependent := range g.blocking[id] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				available = append(available, dependent)
+			}
+		}
+	}
+
+	if len(order) < len(g.nodes) {
+		var stuck []string
+		for id, n := range pending {
+			if n > 0 {
+				stuck = append(stuck, id)
+			}
+		}
+		sort.Strings(stuck)
+		return nil, fmt.Errorf("dependency cycle: can't order %s", strings.Join(stuck, ", "))
+	}
+	return order, nil
+}
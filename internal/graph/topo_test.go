@@ -0,0 +1,51 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopoSort(t *testing.T) {
+	p := &model.ProjectFile{
+		Tasks: []model.Task{
+			makeTask("TS-01", "TS-03"),
+			makeTask("TS-02"),
+			makeTask("TS-03"),
+			makeTask("TS-04", "TS-01", "TS-02"),
+			makeTask("TS-05", "TS-99"), // missing blocker is ignored
+		},
+	}
+
+	order, err := BuildGraph(p).TopoSort(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TS-02", "TS-03", "TS-01", "TS-04", "TS-05"}, order)
+
+	// Ties follow less: here, highest ID first
+	order, err = BuildGraph(p).TopoSort(func(a, b string) bool { return a > b })
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TS-05", "TS-03", "TS-02", "TS-01", "TS-04"}, order)
+}
+
+func TestTopoSort_Empty(t *testing.T) {
+	order, err := BuildGraph(&model.ProjectFile{}).TopoSort(nil)
+	require.NoError(t, err)
+	assert.Empty(t, order)
+}
+
+func TestTopoSort_Cycle(t *testing.T) {
+	p := &model.ProjectFile{
+		Tasks: []model.Task{
+			makeTask("TS-01", "TS-02"),
+			makeTask("TS-02", "TS-01"),
+			makeTask("TS-03", "TS-02"),
+			makeTask("TS-04"),
+		},
+	}
+
+	_, err := BuildGraph(p).TopoSort(nil)
+	require.Error(t, err)
+	assert.Equal(t, "dependency cycle: can't order TS-01, TS-02, TS-03", err.Error())
+}
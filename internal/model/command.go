@@ -0,0 +1,62 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SplitCommand splits a configured command line into words, honoring single
+// quotes, double quotes, and backslash escapes so that programs and
+// arguments containing spaces can be configured (e.g.,
+// "'/opt/My Editor/bin/edit' --wait"). It is used for the editor and hook
+// commands.
+func SplitCommand(command string) ([]string, error) {
+	var words []string
+	var current strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+
+	for _, r := range command {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else if r == '\\' && quote == '"' {
+				escaped = true
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+			inWord = true
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in command: %s", command)
+	}
+	if escaped {
+		current.WriteRune('\\')
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+
+	return words, nil
+}
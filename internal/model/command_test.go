@@ -0,0 +1,40 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		expected []string
+	}{
+		{"single word", "vim", []string{"vim"}},
+		{"with args", "code --wait", []string{"code", "--wait"}},
+		{"extra whitespace", "  emacs   -nw  ", []string{"emacs", "-nw"}},
+		{"double quoted path", `"/opt/My Editor/edit" --wait`, []string{"/opt/My Editor/edit", "--wait"}},
+		{"single quoted path", `'/opt/My Editor/edit' -w`, []string{"/opt/My Editor/edit", "-w"}},
+		{"escaped space", `/opt/My\ Editor/edit`, []string{"/opt/My Editor/edit"}},
+		{"quoted arg", `subl -n --command "goto line"`, []string{"subl", "-n", "--command", "goto line"}},
+		{"empty quotes", `edit ""`, []string{"edit", ""}},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := SplitCommand(tt.command)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, parts)
+		})
+	}
+}
+
+func TestSplitCommandUnterminatedQuote(t *testing.T) {
+	_, err := SplitCommand(`"/opt/My Editor/edit --wait`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unterminated quote")
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// dateLayout is the layout of calendar dates on the command line and in
+// project files.
+const dateLayout = "2006-01-02"
+
+// timezone is the zone in which calendar dates are interpreted: the day a
+// date-only defer ends, when "today" starts, and which day an instant falls
+// on.
+var timezone = time.Local
+
+// SetTimezone sets the zone used to interpret calendar dates, by IANA name
+// (e.g. "America/New_York"). An empty name restores the machine's local
+// zone.
+func SetTimezone(name string) error {
+	if name == "" {
+		timezone = time.Local
+		return nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q: use an IANA name like %q", name, "America/New_York")
+	}
+	timezone = loc
+	return nil
+}
+
+// Timezone returns the zone used to interpret calendar dates.
+func Timezone() *time.Location {
+	return timezone
+}
+
+// ParseDate parses a YYYY-MM-DD date as the start of that day in the
+// configured timezone.
+func ParseDate(s string) (time.Time, error) {
+	return time.ParseInLocation(dateLayout, s, timezone)
+}
+
+// StartOfDay returns midnight at the start of the day t falls on in the
+// configured timezone.
+func StartOfDay(t time.Time) time.Time {
+	t = t.In(timezone)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, timezone)
+}
+
+// EndOfDay returns the last second of the day t falls on in the configured
+// timezone.
+func EndOfDay(t time.Time) time.Time {
+	t = t.In(timezone)
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, timezone)
+}
+
+// Day returns the YYYY-MM-DD date t falls on in the configured timezone.
+func Day(t time.Time) string {
+	return t.In(timezone).Format(dateLayout)
+}
+
+// DueDay returns the calendar date of a due date. Due dates are stored
+// without a zone and load as midnight UTC, so they are read as written
+// rather than converted, which could move them to the previous day.
+func DueDay(due time.Time) string {
+	return due.Format(dateLayout)
+}
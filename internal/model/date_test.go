@@ -0,0 +1,59 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimezoneDates(t *testing.T) {
+	defer SetTimezone("")
+
+	for _, zone := range []string{"UTC", "America/Los_Angeles", "Pacific/Auckland", "Pacific/Kiritimati"} {
+		t.Run(zone, func(t *testing.T) {
+			require.NoError(t, SetTimezone(zone))
+			loc := Timezone()
+			assert.Equal(t, zone, loc.String())
+
+			d, err := ParseDate("2026-01-15")
+			require.NoError(t, err)
+			assert.Equal(t, time.Date(2026, time.January, 15, 0, 0, 0, 0, loc), d)
+			assert.Equal(t, time.Date(2026, time.January, 15, 23, 59, 59, 0, loc), EndOfDay(d))
+			assert.Equal(t, d, StartOfDay(EndOfDay(d)))
+			assert.Equal(t, "2026-01-15", Day(d))
+			assert.Equal(t, "2026-01-15", Day(EndOfDay(d)))
+
+			// A due date loaded from a project file is midnight UTC; it
+			// stays on its calendar day whatever the zone
+			stored := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+			assert.Equal(t, "2026-01-15", DueDay(stored))
+			assert.Equal(t, "2026-01-15", DueDay(d))
+		})
+	}
+
+	t.Run("instants fall on the zone's day", func(t *testing.T) {
+		// 2026-01-15 20:00 UTC is the morning of the 16th in Auckland and
+		// midday on the 15th in Los Angeles
+		instant := time.Date(2026, time.January, 15, 20, 0, 0, 0, time.UTC)
+		require.NoError(t, SetTimezone("Pacific/Auckland"))
+		assert.Equal(t, "2026-01-16", Day(instant))
+		require.NoError(t, SetTimezone("America/Los_Angeles"))
+		assert.Equal(t, "2026-01-15", Day(instant))
+		assert.Equal(t, "2026-01-15T12:00:00-08:00", FormatDateTime(instant))
+	})
+
+	t.Run("empty restores local", func(t *testing.T) {
+		require.NoError(t, SetTimezone(""))
+		assert.Equal(t, time.Local, Timezone())
+	})
+
+	t.Run("unknown zone is rejected", func(t *testing.T) {
+		require.NoError(t, SetTimezone("UTC"))
+		err := SetTimezone("Mars/Olympus_Mons")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid timezone")
+		assert.Equal(t, "UTC", Timezone().String(), "invalid zone should not replace the current one")
+	})
+}
@@ -0,0 +1,30 @@
+package model
+
+import "fmt"
+
+// NotFoundError indicates that a task, wait, or project lookup found
+// nothing. Callers can match it with errors.As rather than on the message.
+type NotFoundError struct {
+	Kind   string // "task", "wait", "project", "blocker", or "item"
+	ItemID string // the ID or reference that was looked up
+	By     string // what ItemID is when named in the message, e.g. "prefix" (optional)
+}
+
+func (e *NotFoundError) Error() string {
+	if e.By != "" {
+		return fmt.Sprintf("%s with %s %q not found", e.Kind, e.By, e.ItemID)
+	}
+	return fmt.Sprintf("%s %s not found", e.Kind, e.ItemID)
+}
+
+// ProjectStatusError indicates that an item change was refused because its
+// project is paused or done.
+type ProjectStatusError struct {
+	Operation string // what was attempted
+	Project   string // project name
+	Status    string // current status
+}
+
+func (e *ProjectStatusError) Error() string {
+	return fmt.Sprintf("cannot %s %s project %q", e.Operation, e.Status, e.Project)
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotFoundError(t *testing.T) {
+	err := &NotFoundError{Kind: "task", ItemID: "BY-999"}
+	assert.Equal(t, "task BY-999 not found", err.Error())
+
+	err = &NotFoundError{Kind: "wait", ItemID: "BY-03W"}
+	assert.Equal(t, "wait BY-03W not found", err.Error())
+
+	err = &NotFoundError{Kind: "project", ItemID: "backyard"}
+	assert.Equal(t, "project backyard not found", err.Error())
+
+	err = &NotFoundError{Kind: "project", ItemID: "XX", By: "prefix"}
+	assert.Equal(t, `project with prefix "XX" not found`, err.Error())
+}
+
+func TestProjectStatusError(t *testing.T) {
+	err := &ProjectStatusError{Operation: "modify", Project: "backyard", Status: "done"}
+	assert.Equal(t, `cannot modify done project "backyard"`, err.Error())
+}
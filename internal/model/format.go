@@ -0,0 +1,104 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultDateFormat is the Go time layout used to display dates unless a
+// date_format is configured.
+const DefaultDateFormat = "2006-01-02"
+
+// dateFormat is the layout used by FormatDate and FormatDateTime.
+var dateFormat = DefaultDateFormat
+
+// SetDateFormat sets the Go time layout used for displaying dates. An empty
+// layout restores the default. Layouts with no date fields are rejected.
+func SetDateFormat(layout string) error {
+	if layout == "" {
+		dateFormat = DefaultDateFormat
+		return nil
+	}
+	// A layout with no date fields formats any date as itself
+	sample := time.Date(2031, time.November, 28, 0, 0, 0, 0, time.UTC)
+	if sample.Format(layout) == layout {
+		return fmt.Errorf("invalid date_format %q: no date fields (use a Go layout like %q)", layout, DefaultDateFormat)
+	}
+	dateFormat = layout
+	return nil
+}
+
+// FormatDate renders the calendar date of t for display.
+func FormatDate(t time.Time) string {
+	return t.Format(dateFormat)
+}
+
+// FormatDateTime renders t for display in the configured timezone: as
+// RFC 3339 by default, or as the configured date followed by the time of day
+// and zone. Machine-readable output such as tk dump formats timestamps itself
+// and doesn't follow date_format.
+func FormatDateTime(t time.Time) string {
+	t = t.In(timezone)
+	if dateFormat == DefaultDateFormat {
+		return t.Format(time.RFC3339)
+	}
+	return t.Format(dateFormat + " 15:04:05 MST")
+}
+
+// defaultPriorityLabels describe priorities when no labels are configured.
+var defaultPriorityLabels = map[int]string{
+	1: "urgent",
+	2: "high",
+	3: "medium",
+	4: "backlog",
+}
+
+// priorityLabels are user-configured priority names. When set, they replace
+// the "P1".."P4" shorthand in list output.
+var priorityLabels map[int]string
+
+// SetPriorityLabels sets display labels for priorities 1-4. Priorities
+// without a label keep the default rendering; nil clears all labels. Labels
+// are single words, so whitespace-separated output like tk list
+// --format=oneline stays parseable.
+func SetPriorityLabels(labels map[int]string) error {
+	cleaned := make(map[int]string, len(labels))
+	for p, label := range labels {
+		if p < 1 || p > 4 {
+			return fmt.Errorf("invalid priority_labels key %d: priorities are 1-4", p)
+		}
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return fmt.Errorf("invalid priority_labels: label for priority %d is empty", p)
+		}
+		if strings.IndexFunc(label, unicode.IsSpace) >= 0 {
+			return fmt.Errorf("invalid priority_labels: label %q for priority %d contains whitespace", label, p)
+		}
+		cleaned[p] = label
+	}
+	priorityLabels = cleaned
+	return nil
+}
+
+// PriorityLabel returns the descriptive name of a priority, preferring a
+// configured label.
+func PriorityLabel(p int) string {
+	if label, ok := priorityLabels[p]; ok {
+		return label
+	}
+	if label, ok := defaultPriorityLabels[p]; ok {
+		return label
+	}
+	return "unknown"
+}
+
+// FormatPriority renders a priority for compact listings: its configured
+// label if there is one, otherwise "P1".."P4".
+func FormatPriority(p int) string {
+	if label, ok := priorityLabels[p]; ok {
+		return label
+	}
+	return fmt.Sprintf("P%d", p)
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatDate(t *testing.T) {
+	defer SetDateFormat("")
+
+	date := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+	moment := time.Date(2026, time.January, 15, 14, 30, 0, 0, time.Local)
+
+	t.Run("default format", func(t *testing.T) {
+		require.NoError(t, SetDateFormat(""))
+		assert.Equal(t, "2026-01-15", FormatDate(date))
+		assert.Equal(t, moment.Format(time.RFC3339), FormatDateTime(moment))
+	})
+
+	t.Run("custom format", func(t *testing.T) {
+		require.NoError(t, SetDateFormat("Jan 2, 2006"))
+		assert.Equal(t, "Jan 15, 2026", FormatDate(date))
+		assert.Equal(t, "Jan 15, 2026 14:30:00 "+moment.Format("MST"), FormatDateTime(moment))
+	})
+
+	t.Run("layout without date fields is rejected", func(t *testing.T) {
+		require.NoError(t, SetDateFormat("02/01/2006"))
+		err := SetDateFormat("YYYY-MM-DD")
+		require.Error(t, err)
+		assert.Equal(t, "15/01/2026", FormatDate(date), "invalid layout should not replace the current one")
+	})
+
+	t.Run("wait display text uses the format", func(t *testing.T) {
+		require.NoError(t, SetDateFormat("Jan 2, 2006"))
+		w := Wait{ResolutionCriteria: ResolutionCriteria{Type: ResolutionTypeTime, After: &date}}
+		assert.Equal(t, "Until Jan 15, 2026", w.DisplayText())
+	})
+}
+
+func TestPriorityLabels(t *testing.T) {
+	defer SetPriorityLabels(nil)
+
+	require.NoError(t, SetPriorityLabels(nil))
+	assert.Equal(t, "P1", FormatPriority(1))
+	assert.Equal(t, "urgent", PriorityLabel(1))
+	assert.Equal(t, "unknown", PriorityLabel(7))
+
+	require.NoError(t, SetPriorityLabels(map[int]string{1: "critical", 4: " low "}))
+	assert.Equal(t, "critical", FormatPriority(1))
+	assert.Equal(t, "critical", PriorityLabel(1))
+	assert.Equal(t, "low", FormatPriority(4))
+	assert.Equal(t, "P2", FormatPriority(2), "unlabeled priorities keep the default")
+	assert.Equal(t, "high", PriorityLabel(2))
+
+	assert.Error(t, SetPriorityLabels(map[int]string{5: "someday"}))
+	assert.Error(t, SetPriorityLabels(map[int]string{2: ""}))
+	assert.Error(t, SetPriorityLabels(map[int]string{3: "nice to have"}))
+	assert.Equal(t, "critical", FormatPriority(1), "invalid labels should not replace the current ones")
+}
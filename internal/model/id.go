@@ -8,6 +8,14 @@ import (
 	"strings"
 )
 
+// ProjectRefMarker starts a blocker reference to a whole project (@BY),
+// which is resolved once the project has no open tasks or waits.
+const ProjectRefMarker = "@"
+
+// WaitSuffix follows the number in a wait ID (BY-03W) and is what tells a
+// wait ID apart from a task ID. It is matched case-insensitively.
+const WaitSuffix = "W"
+
 var (
 	// ErrInvalidID is returned when an ID cannot be parsed.
 	ErrInvalidID = errors.New("invalid ID format")
@@ -16,12 +24,31 @@ var (
 	taskIDRegex = regexp.MustCompile(`^([A-Za-z]{2,3})-(\d+)$`)
 
 	// waitIDRegex matches wait IDs like BY-03W, by-3w
-	waitIDRegex = regexp.MustCompile(`^([A-Za-z]{2,3})-(\d+)[Ww]$`)
+	waitIDRegex *regexp.Regexp
 
 	// anyIDRegex matches both task and wait IDs
-	anyIDRegex = regexp.MustCompile(`^([A-Za-z]{2,3})-(\d+)([Ww])?$`)
+	anyIDRegex *regexp.Regexp
+
+	// projectRefRegex matches whole-project blocker references like @BY
+	projectRefRegex = regexp.MustCompile(`^` + ProjectRefMarker + `([A-Za-z]{2,3})$`)
+
+	// waitSuffix is the suffix in effect; tests swap it via setWaitSuffix.
+	waitSuffix string
 )
 
+func init() {
+	setWaitSuffix(WaitSuffix)
+}
+
+// setWaitSuffix makes suffix the wait ID suffix, rebuilding the patterns
+// that parse wait IDs so formatting and parsing stay in step.
+func setWaitSuffix(suffix string) {
+	quoted := regexp.QuoteMeta(suffix)
+	waitIDRegex = regexp.MustCompile(`^([A-Za-z]{2,3})-(\d+)(?i:` + quoted + `)$`)
+	anyIDRegex = regexp.MustCompile(`^([A-Za-z]{2,3})-(\d+)((?i:` + quoted + `))?$`)
+	waitSuffix = suffix
+}
+
 // ParseTaskID parses a task ID string and returns the prefix and number.
 // Accepts various formats: BY-07, by-7, BY-007 all parse to prefix="BY", num=7.
 // Returns ErrInvalidID if the format is invalid.
@@ -88,10 +115,9 @@ func FormatTaskID(prefix string, num int, maxNum int) string {
 }
 
 // FormatWaitID formats a wait ID with appropriate zero-padding.
-// Uses the same padding rules as FormatTaskID, with W suffix.
+// Uses the same padding rules as FormatTaskID, followed by WaitSuffix.
 func FormatWaitID(prefix string, num int, maxNum int) string {
-	width := digitWidth(maxNum)
-	return fmt.Sprintf("%s-%0*dW", strings.ToUpper(prefix), width, num)
+	return FormatTaskID(prefix, num, maxNum) + strings.ToUpper(waitSuffix)
 }
 
 // NormalizeID normalizes an ID to uppercase canonical form.
@@ -167,3 +193,25 @@ func IsTaskID(id string) bool {
 	_, _, isWait, err := ParseAnyID(id)
 	return err == nil && !isWait
 }
+
+// FormatProjectRef formats a blocker reference to the whole project with
+// the given prefix, e.g. "@BY".
+func FormatProjectRef(prefix string) string {
+	return ProjectRefMarker + strings.ToUpper(prefix)
+}
+
+// ParseProjectRef returns the uppercased project prefix of a whole-project
+// blocker reference like @BY, and false if id is not one.
+func ParseProjectRef(id string) (prefix string, ok bool) {
+	matches := projectRefRegex.FindStringSubmatch(id)
+	if matches == nil {
+		return "", false
+	}
+	return strings.ToUpper(matches[1]), true
+}
+
+// IsProjectRef returns true if the ID is a whole-project blocker reference.
+func IsProjectRef(id string) bool {
+	_, ok := ParseProjectRef(id)
+	return ok
+}
@@ -457,6 +457,47 @@ func TestIsTaskID(t *testing.T) {
 	assert.False(t, IsTaskID("invalid"))
 }
 
+func TestParseProjectRef(t *testing.T) {
+	prefix, ok := ParseProjectRef("@by")
+	assert.True(t, ok)
+	assert.Equal(t, "BY", prefix)
+	assert.Equal(t, "@BY", FormatProjectRef("by"))
+
+	for _, id := range []string{"BY", "@B", "@ABCD", "@BY-01", "BY-01", ""} {
+		assert.False(t, IsProjectRef(id), id)
+	}
+
+	// Project references are neither task nor wait IDs
+	assert.False(t, IsTaskID("@BY"))
+	assert.False(t, IsWaitID("@BY"))
+	assert.Equal(t, "", ExtractPrefix("@BY"))
+}
+
+func TestWaitSuffixChange(t *testing.T) {
+	setWaitSuffix("WT")
+	defer setWaitSuffix(WaitSuffix)
+
+	id := FormatWaitID("by", 3, 99)
+	assert.Equal(t, "BY-03WT", id)
+
+	prefix, num, err := ParseWaitID(id)
+	assert.NoError(t, err)
+	assert.Equal(t, "BY", prefix)
+	assert.Equal(t, 3, num)
+
+	assert.True(t, IsWaitID(id))
+	assert.True(t, IsWaitID("by-3wt"))
+	assert.False(t, IsTaskID(id))
+	assert.Equal(t, "BY", ExtractPrefix(id))
+	assert.Equal(t, 3, ExtractNumber(id))
+	assert.Equal(t, id, NormalizeID("by-3wt", 0))
+
+	// The old suffix is no longer a wait ID, and task IDs are unaffected
+	assert.False(t, IsWaitID("BY-03W"))
+	assert.Equal(t, "", ExtractPrefix("BY-03W"))
+	assert.True(t, IsTaskID("BY-07"))
+}
+
 func TestDigitWidth_LargeNumbers(t *testing.T) {
 	// Test the fallback path for very large numbers (>= 10000)
 	tests := []struct {
@@ -25,10 +25,34 @@ func LoadProject(path string) (*ProjectFile, error) {
 	return &pf, nil
 }
 
+// ParseProject decodes a project from YAML data. JSON is accepted as well,
+// since it is a subset of YAML.
+func ParseProject(data []byte) (*ProjectFile, error) {
+	var pf ProjectFile
+	if err := yaml.Unmarshal(data, &pf); err != nil {
+		return nil, err
+	}
+	return &pf, nil
+}
+
+// MarshalProject encodes a project as YAML in the same layout SaveProject
+// writes, without carrying over any comments.
+func MarshalProject(p *ProjectFile) ([]byte, error) {
+	sortTasks(p.Tasks)
+	sortWaits(p.Waits)
+
+	node, err := buildProjectNode(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build YAML: %w", err)
+	}
+	return yaml.Marshal(node)
+}
+
 // SaveProject saves a project file to the given path.
 // Tasks and waits are sorted by numeric ID.
 // Null fields are omitted.
 // Multi-line strings use block scalar style.
+// Comments in an existing file at path are carried over (see carryComments).
 func SaveProject(path string, p *ProjectFile) error {
 	// Sort tasks and waits by numeric ID before saving
 	sortTasks(p.Tasks)
@@ -39,9 +63,18 @@ func SaveProject(path string, p *ProjectFile) error {
 	if err != nil {
 		return fmt.Errorf("failed to build YAML: %w", err)
 	}
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{node}}
+
+	// Keep comments from hand-edits of the previous version of the file
+	if data, err := os.ReadFile(path); err == nil {
+		var old yaml.Node
+		if yaml.Unmarshal(data, &old) == nil && old.Kind == yaml.DocumentNode && len(old.Content) > 0 {
+			carryComments(&old, doc)
+		}
+	}
 
 	// Encode to YAML
-	data, err := yaml.Marshal(node)
+	data, err := yaml.Marshal(doc)
 	if err != nil {
 		return fmt.Errorf("failed to encode project: %w", err)
 	}
@@ -53,6 +86,82 @@ func SaveProject(path string, p *ProjectFile) error {
 	return nil
 }
 
+// carryComments copies comments from a previously saved document onto a
+// freshly built one. Top-level keys and item fields are matched by key, and
+// task/wait list items are matched by id, so comments follow the item they
+// annotate even when other items are added, removed, or reordered. Comments
+// on anything that no longer exists are dropped.
+func carryComments(old, doc *yaml.Node) {
+	doc.HeadComment = old.HeadComment
+	doc.FootComment = old.FootComment
+
+	oldRoot, newRoot := old.Content[0], doc.Content[0]
+	if oldRoot.Kind != yaml.MappingNode {
+		return
+	}
+	// A top comment not separated from the first key by a blank line is
+	// attached to that key; keep it at the top of the file either way.
+	if doc.HeadComment == "" && len(oldRoot.Content) > 0 {
+		doc.HeadComment = oldRoot.Content[0].HeadComment
+		oldRoot.Content[0].HeadComment = ""
+	}
+	carryMappingComments(oldRoot, newRoot)
+}
+
+// carryMappingComments copies key and value comments between two mappings.
+func carryMappingComments(from, to *yaml.Node) {
+	for i := 0; i+1 < len(to.Content); i += 2 {
+		key, value := to.Content[i], to.Content[i+1]
+		oldKey, oldValue := mappingEntry(from, key.Value)
+		if oldKey == nil {
+			continue
+		}
+		key.HeadComment = oldKey.HeadComment
+		key.LineComment = oldKey.LineComment
+		key.FootComment = oldKey.FootComment
+		value.LineComment = oldValue.LineComment
+		if value.Kind == yaml.SequenceNode && oldValue.Kind == yaml.SequenceNode {
+			carryItemComments(oldValue, value)
+		}
+	}
+}
+
+// carryItemComments copies comments between list items that share an id.
+func carryItemComments(from, to *yaml.Node) {
+	oldItems := make(map[string]*yaml.Node)
+	for _, item := range from.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		if _, id := mappingEntry(item, "id"); id != nil {
+			oldItems[strings.ToUpper(id.Value)] = item
+		}
+	}
+	for _, item := range to.Content {
+		_, id := mappingEntry(item, "id")
+		if id == nil {
+			continue
+		}
+		oldItem, ok := oldItems[strings.ToUpper(id.Value)]
+		if !ok {
+			continue
+		}
+		item.HeadComment = oldItem.HeadComment
+		item.FootComment = oldItem.FootComment
+		carryMappingComments(oldItem, item)
+	}
+}
+
+// mappingEntry returns the key and value nodes for key in a mapping node.
+func mappingEntry(mapping *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i], mapping.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
 // sortTasks sorts tasks by their numeric ID.
 func sortTasks(tasks []Task) {
 	sort.Slice(tasks, func(i, j int) bool {
@@ -81,6 +190,12 @@ func buildProjectNode(p *ProjectFile) (*yaml.Node, error) {
 		addStringField(doc, "description", p.Description)
 	}
 	addStringField(doc, "status", string(p.Status))
+	if p.DefaultAssignee != "" {
+		addStringField(doc, "default_assignee", p.DefaultAssignee)
+	}
+	if p.Notes != "" {
+		addMultilineStringField(doc, "notes", p.Notes)
+	}
 	addIntField(doc, "next_id", p.NextID)
 	addTimeField(doc, "created", p.Created)
 
@@ -130,6 +245,7 @@ func buildTaskNode(t *Task) (*yaml.Node, error) {
 
 	if len(t.BlockedBy) > 0 {
 		addStringSliceField(node, "blocked_by", t.BlockedBy)
+		addBlockReasonsField(node, t.BlockedBy, t.BlockReasons)
 	}
 	if len(t.Tags) > 0 {
 		addStringSliceField(node, "tags", t.Tags)
@@ -143,9 +259,18 @@ func buildTaskNode(t *Task) (*yaml.Node, error) {
 	if t.DueDate != nil {
 		addDateField(node, "due_date", *t.DueDate)
 	}
+	if t.RemindBefore > 0 {
+		addIntField(node, "remind_before", t.RemindBefore)
+	}
+	if t.SnoozedUntil != nil {
+		addTimeField(node, "snoozed_until", *t.SnoozedUntil)
+	}
 	if t.AutoComplete {
 		addBoolField(node, "auto_complete", t.AutoComplete)
 	}
+	if t.Source != "" {
+		addStringField(node, "source", string(t.Source))
+	}
 
 	addTimeField(node, "created", t.Created)
 	addTimeField(node, "updated", t.Updated)
@@ -192,10 +317,17 @@ func buildWaitNode(w *Wait) (*yaml.Node, error) {
 
 	if len(w.BlockedBy) > 0 {
 		addStringSliceField(node, "blocked_by", w.BlockedBy)
+		addBlockReasonsField(node, w.BlockedBy, w.BlockReasons)
 	}
 	if w.Notes != "" {
 		addMultilineStringField(node, "notes", w.Notes)
 	}
+	if w.Tracking != "" {
+		addStringField(node, "tracking", w.Tracking)
+	}
+	if w.Link != "" {
+		addStringField(node, "link", w.Link)
+	}
 	if w.Resolution != "" {
 		addStringField(node, "resolution", w.Resolution)
 	}
@@ -265,11 +397,35 @@ func addStringSliceField(node *yaml.Node, key string, values []string) {
 	)
 }
 
+// addBlockReasonsField writes the reasons for the given blockers in
+// blocked_by order. Reasons for blockers that are no longer listed are
+// dropped, so removing a blocker by any route also removes its reason.
+func addBlockReasonsField(node *yaml.Node, blockedBy []string, reasons map[string]string) {
+	mapNode := &yaml.Node{Kind: yaml.MappingNode}
+	for _, id := range blockedBy {
+		if reason := reasons[id]; reason != "" {
+			addStringField(mapNode, id, reason)
+		}
+	}
+	if len(mapNode.Content) == 0 {
+		return
+	}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: "block_reasons"},
+		mapNode,
+	)
+}
+
 func addMultilineStringField(node *yaml.Node, key, value string) {
 	// Use literal block scalar style for multi-line strings
-	style := yaml.LiteralStyle
-	if !containsNewline(value) {
-		style = 0 // Use default style for single-line
+	var style yaml.Style
+	if containsNewline(value) {
+		style = yaml.LiteralStyle
+		if !literalSafe(value) {
+			// Block scalars cannot represent this value faithfully;
+			// double quotes round-trip any string exactly.
+			style = yaml.DoubleQuotedStyle
+		}
 	}
 	node.Content = append(node.Content,
 		&yaml.Node{Kind: yaml.ScalarNode, Value: key},
@@ -280,3 +436,18 @@ func addMultilineStringField(node *yaml.Node, key, value string) {
 func containsNewline(s string) bool {
 	return strings.Contains(s, "\n")
 }
+
+// literalSafe reports whether a multi-line string survives a round-trip
+// through a literal block scalar. Leading whitespace or newlines would be
+// taken as indentation (corrupting the file), and a value made only of
+// newlines collapses to an empty string.
+func literalSafe(s string) bool {
+	if s == "" || strings.Trim(s, "\n") == "" {
+		return false
+	}
+	switch s[0] {
+	case ' ', '\t', '\n':
+		return false
+	}
+	return true
+}
@@ -122,6 +122,7 @@ func TestSaveProject_RoundTrip(t *testing.T) {
 				Status:   TaskStatusDone,
 				Priority: 2,
 				Tags:     []string{"shopping"},
+				Source:   TaskSourceCLI,
 				Created:  now,
 				Updated:  now,
 				DoneAt:   &later,
@@ -173,11 +174,13 @@ func TestSaveProject_RoundTrip(t *testing.T) {
 	assert.Equal(t, original.Tasks[0].Title, loaded.Tasks[0].Title)
 	assert.Equal(t, original.Tasks[0].Status, loaded.Tasks[0].Status)
 	assert.Equal(t, original.Tasks[0].Tags, loaded.Tasks[0].Tags)
+	assert.Equal(t, original.Tasks[0].Source, loaded.Tasks[0].Source)
 	require.NotNil(t, loaded.Tasks[0].DoneAt)
 
 	assert.Equal(t, original.Tasks[1].ID, loaded.Tasks[1].ID)
 	assert.Equal(t, original.Tasks[1].BlockedBy, loaded.Tasks[1].BlockedBy)
 	assert.Equal(t, original.Tasks[1].Notes, loaded.Tasks[1].Notes)
+	assert.Empty(t, loaded.Tasks[1].Source)
 	require.NotNil(t, loaded.Tasks[1].DueDate)
 
 	require.Len(t, loaded.Waits, 1)
@@ -285,6 +288,88 @@ func TestSaveProject_NullFieldsOmitted(t *testing.T) {
 	assert.Contains(t, content, "priority: 3")
 }
 
+func TestSaveProject_PreservesComments(t *testing.T) {
+	original := `# Kitchen remodel
+# Budget is tracked in the shared sheet.
+
+id: kitchen
+prefix: KT
+name: Kitchen
+status: active
+next_id: 3
+created: 2025-12-02T10:30:00Z
+tasks:
+  # Waiting on the contractor's quote before this can start
+  - id: KT-01
+    title: Demo cabinets
+    status: open
+    priority: 2 # bumped after inspection
+    created: 2025-12-02T10:30:00Z
+    updated: 2025-12-02T10:30:00Z
+  # Obsolete, will be dropped
+  - id: KT-02
+    title: Old plan
+    status: open
+    priority: 3
+    created: 2025-12-02T10:30:00Z
+    updated: 2025-12-02T10:30:00Z
+`
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "KT.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(original), 0644))
+
+	pf, err := LoadProject(path)
+	require.NoError(t, err)
+
+	// Remove one task, add another, and change a field on the first
+	now := time.Date(2025, 12, 3, 9, 0, 0, 0, time.UTC)
+	pf.Tasks = []Task{
+		{ID: "KT-03", Title: "Order tiles", Status: TaskStatusOpen, Priority: 3, Created: now, Updated: now},
+		pf.Tasks[0],
+	}
+	pf.Tasks[1].Title = "Demo upper cabinets"
+	pf.NextID = 4
+	require.NoError(t, SaveProject(path, pf))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	content := string(data)
+
+	assert.True(t, strings.HasPrefix(content, "# Kitchen remodel\n# Budget is tracked in the shared sheet.\n"),
+		"top-of-file comment should be kept, got:\n%s", content)
+	assert.Contains(t, content, "# Waiting on the contractor's quote before this can start\n    - id: KT-01")
+	assert.Contains(t, content, "priority: 2 # bumped after inspection")
+	assert.Contains(t, content, "title: Demo upper cabinets")
+	assert.NotContains(t, content, "Obsolete", "comments on removed items are dropped")
+
+	// Saving again is stable
+	reloaded, err := LoadProject(path)
+	require.NoError(t, err)
+	require.NoError(t, SaveProject(path, reloaded))
+	again, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(again))
+}
+
+func TestSaveProject_PreservesTopCommentWithoutBlankLine(t *testing.T) {
+	now := time.Date(2025, 12, 2, 10, 30, 0, 0, time.UTC)
+	pf := &ProjectFile{Project: Project{ID: "test", Prefix: "TS", Name: "Test", Status: ProjectStatusActive, NextID: 1, Created: now}}
+
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "TS.yaml")
+	require.NoError(t, SaveProject(path, pf))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, append([]byte("# hand-written header\n"), data...), 0644))
+
+	require.NoError(t, SaveProject(path, pf))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "# hand-written header\n"), "got:\n%s", data)
+	assert.Equal(t, 1, strings.Count(string(data), "hand-written header"))
+}
+
 func TestSaveProject_MultilineBlockScalar(t *testing.T) {
 	now := time.Date(2025, 12, 2, 10, 30, 0, 0, time.UTC)
 
@@ -323,6 +408,82 @@ func TestSaveProject_MultilineBlockScalar(t *testing.T) {
 	assert.Contains(t, content, "notes: |")
 }
 
+func TestSaveProject_NotesWhitespaceRoundTrip(t *testing.T) {
+	now := time.Date(2025, 12, 2, 10, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		name  string
+		notes string
+	}{
+		{"single line", "Just a note"},
+		{"leading space", " leading"},
+		{"trailing space", "trailing "},
+		{"only spaces", "  "},
+		{"multi-line", "line one\nline two"},
+		{"trailing newline", "line one\nline two\n"},
+		{"multiple trailing newlines", "line one\nline two\n\n\n"},
+		{"leading newline", "\nstarts with newline"},
+		{"indented first line", "  indented\nsecond"},
+		{"indented later line", "first\n  indented\n"},
+		{"trailing spaces on line", "trailing space \nnext"},
+		{"trailing whitespace after newline", "ends\n "},
+		{"tab inside line", "tab\there"},
+		{"leading tab", "\ttab start\nnext"},
+		{"tab-indented line", "first\n\ttabbed"},
+		{"blank lines inside", "a\n\n\nb"},
+		{"only newline", "\n"},
+		{"carriage returns", "crlf\r\nline"},
+		{"yaml-like content", "# not a comment\n- not a list\nkey: value"},
+	}
+
+	tmpDir := t.TempDir()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pf := &ProjectFile{
+				Project: Project{
+					ID:      "test",
+					Prefix:  "TS",
+					Name:    "Test",
+					Status:  ProjectStatusActive,
+					NextID:  2,
+					Created: now,
+				},
+				Tasks: []Task{
+					{
+						ID:       "TS-01",
+						Title:    "Task",
+						Status:   TaskStatusOpen,
+						Priority: 3,
+						Notes:    tc.notes,
+						Created:  now,
+						Updated:  now,
+					},
+				},
+				Waits: []Wait{
+					{
+						ID:     "TS-02W",
+						Status: WaitStatusOpen,
+						ResolutionCriteria: ResolutionCriteria{
+							Type:     ResolutionTypeManual,
+							Question: "Ready?",
+						},
+						Notes:   tc.notes,
+						Created: now,
+					},
+				},
+			}
+
+			path := filepath.Join(tmpDir, "TS.yaml")
+			require.NoError(t, SaveProject(path, pf))
+
+			loaded, err := LoadProject(path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.notes, loaded.Tasks[0].Notes)
+			assert.Equal(t, tc.notes, loaded.Waits[0].Notes)
+		})
+	}
+}
+
 func TestSaveProject_TimeWait(t *testing.T) {
 	now := time.Date(2025, 12, 2, 10, 30, 0, 0, time.UTC)
 	afterTime := time.Date(2026, 1, 15, 23, 59, 59, 0, time.UTC)
@@ -403,6 +564,57 @@ func TestSaveProject_TaskWithAutoComplete(t *testing.T) {
 	assert.True(t, loaded.Tasks[0].AutoComplete)
 }
 
+func TestSaveProject_BlockReasons(t *testing.T) {
+	now := time.Date(2025, 12, 2, 10, 30, 0, 0, time.UTC)
+
+	pf := &ProjectFile{
+		Project: Project{
+			ID:      "test",
+			Prefix:  "TS",
+			Name:    "Test",
+			Status:  ProjectStatusActive,
+			NextID:  4,
+			Created: now,
+		},
+		Tasks: []Task{
+			{ID: "TS-01", Title: "Blocker", Status: TaskStatusOpen, Priority: 3, Created: now, Updated: now},
+			{ID: "TS-02", Title: "Other", Status: TaskStatusOpen, Priority: 3, Created: now, Updated: now},
+			{
+				ID:        "TS-03",
+				Title:     "Blocked",
+				Status:    TaskStatusOpen,
+				Priority:  3,
+				BlockedBy: []string{"TS-01"},
+				BlockReasons: map[string]string{
+					"TS-01": "needs their output",
+					"TS-02": "no longer a blocker",
+				},
+				Created: now,
+				Updated: now,
+			},
+		},
+	}
+
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "TS.yaml")
+	require.NoError(t, SaveProject(path, pf))
+
+	// blocked_by keeps its plain list form; reasons for blockers that are
+	// no longer listed are not written
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, "blocked_by: [TS-01]")
+	assert.Contains(t, content, "TS-01: needs their output")
+	assert.NotContains(t, content, "no longer a blocker")
+
+	loaded, err := LoadProject(path)
+	require.NoError(t, err)
+	require.Len(t, loaded.Tasks, 3)
+	assert.Equal(t, map[string]string{"TS-01": "needs their output"}, loaded.Tasks[2].BlockReasons)
+	assert.Nil(t, loaded.Tasks[0].BlockReasons)
+}
+
 func TestSaveProject_EmptyLists(t *testing.T) {
 	now := time.Date(2025, 12, 2, 10, 30, 0, 0, time.UTC)
 
@@ -30,6 +30,14 @@ const (
 	WaitStatusDropped WaitStatus = "dropped"
 )
 
+// TaskSource records how a task was created.
+type TaskSource string
+
+const (
+	TaskSourceCLI    TaskSource = "cli"
+	TaskSourceImport TaskSource = "import"
+)
+
 // ResolutionType represents the type of resolution criteria for a wait.
 type ResolutionType string
 
@@ -40,62 +48,114 @@ const (
 
 // ResolutionCriteria defines how a wait can be resolved.
 type ResolutionCriteria struct {
-	Type       ResolutionType `yaml:"type"`
-	Question   string         `yaml:"question,omitempty"`    // For manual waits
-	After      *time.Time     `yaml:"after,omitempty"`       // For time waits
-	CheckAfter *time.Time     `yaml:"check_after,omitempty"` // For manual waits (optional)
+	Type       ResolutionType `yaml:"type" json:"type"`
+	Question   string         `yaml:"question,omitempty" json:"question,omitempty"`       // For manual waits
+	After      *time.Time     `yaml:"after,omitempty" json:"after,omitempty"`             // For time waits
+	CheckAfter *time.Time     `yaml:"check_after,omitempty" json:"check_after,omitempty"` // For manual waits (optional)
 }
 
 // Project represents a container for related tasks.
 type Project struct {
-	ID          string        `yaml:"id"`
-	Prefix      string        `yaml:"prefix"`
-	Name        string        `yaml:"name"`
-	Description string        `yaml:"description,omitempty"`
-	Status      ProjectStatus `yaml:"status"`
-	NextID      int           `yaml:"next_id"`
-	Created     time.Time     `yaml:"created"`
+	ID              string        `yaml:"id" json:"id"`
+	Prefix          string        `yaml:"prefix" json:"prefix"`
+	Name            string        `yaml:"name" json:"name"`
+	Description     string        `yaml:"description,omitempty" json:"description,omitempty"`
+	Status          ProjectStatus `yaml:"status" json:"status"`
+	DefaultAssignee string        `yaml:"default_assignee,omitempty" json:"default_assignee,omitempty"`
+	Notes           string        `yaml:"notes,omitempty" json:"notes,omitempty"` // running project-level context
+	NextID          int           `yaml:"next_id" json:"next_id"`
+	Created         time.Time     `yaml:"created" json:"created"`
 }
 
 // Task represents a unit of work that can be completed.
 type Task struct {
-	ID           string     `yaml:"id"`
-	Title        string     `yaml:"title"`
-	Status       TaskStatus `yaml:"status"`
-	Priority     int        `yaml:"priority"`
-	BlockedBy    []string   `yaml:"blocked_by,omitempty"`
-	Tags         []string   `yaml:"tags,omitempty"`
-	Notes        string     `yaml:"notes,omitempty"`
-	Assignee     string     `yaml:"assignee,omitempty"`
-	DueDate      *time.Time `yaml:"due_date,omitempty"`
-	AutoComplete bool       `yaml:"auto_complete,omitempty"`
-	Created      time.Time  `yaml:"created"`
-	Updated      time.Time  `yaml:"updated"`
-	DoneAt       *time.Time `yaml:"done_at,omitempty"`
-	DroppedAt    *time.Time `yaml:"dropped_at,omitempty"`
-	DropReason   string     `yaml:"drop_reason,omitempty"`
+	ID           string            `yaml:"id" json:"id"`
+	Title        string            `yaml:"title" json:"title"`
+	Status       TaskStatus        `yaml:"status" json:"status"`
+	Priority     int               `yaml:"priority" json:"priority"`
+	BlockedBy    []string          `yaml:"blocked_by,omitempty" json:"blocked_by,omitempty"`
+	BlockReasons map[string]string `yaml:"block_reasons,omitempty" json:"block_reasons,omitempty"` // why each blocker applies, keyed by blocker ID
+	Tags         []string          `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Notes        string            `yaml:"notes,omitempty" json:"notes,omitempty"`
+	Assignee     string            `yaml:"assignee,omitempty" json:"assignee,omitempty"`
+	DueDate      *time.Time        `yaml:"due_date,omitempty" json:"due_date,omitempty"`
+	RemindBefore int               `yaml:"remind_before,omitempty" json:"remind_before,omitempty"` // days before DueDate to start surfacing
+	SnoozedUntil *time.Time        `yaml:"snoozed_until,omitempty" json:"snoozed_until,omitempty"` // hidden from default lists until then
+	AutoComplete bool              `yaml:"auto_complete,omitempty" json:"auto_complete,omitempty"`
+	Source       TaskSource        `yaml:"source,omitempty" json:"source,omitempty"`
+	Created      time.Time         `yaml:"created" json:"created"`
+	Updated      time.Time         `yaml:"updated" json:"updated"`
+	DoneAt       *time.Time        `yaml:"done_at,omitempty" json:"done_at,omitempty"`
+	DroppedAt    *time.Time        `yaml:"dropped_at,omitempty" json:"dropped_at,omitempty"`
+	DropReason   string            `yaml:"drop_reason,omitempty" json:"drop_reason,omitempty"`
 }
 
 // Wait represents an external condition that blocks one or more tasks.
 type Wait struct {
-	ID                 string             `yaml:"id"`
-	Title              string             `yaml:"title,omitempty"`
-	Status             WaitStatus         `yaml:"status"`
-	ResolutionCriteria ResolutionCriteria `yaml:"resolution_criteria"`
-	BlockedBy          []string           `yaml:"blocked_by,omitempty"`
-	Notes              string             `yaml:"notes,omitempty"`
-	Resolution         string             `yaml:"resolution,omitempty"`
-	Created            time.Time          `yaml:"created"`
-	DoneAt             *time.Time         `yaml:"done_at,omitempty"`
-	DroppedAt          *time.Time         `yaml:"dropped_at,omitempty"`
-	DropReason         string             `yaml:"drop_reason,omitempty"`
+	ID                 string             `yaml:"id" json:"id"`
+	Title              string             `yaml:"title,omitempty" json:"title,omitempty"`
+	Status             WaitStatus         `yaml:"status" json:"status"`
+	ResolutionCriteria ResolutionCriteria `yaml:"resolution_criteria" json:"resolution_criteria"`
+	BlockedBy          []string           `yaml:"blocked_by,omitempty" json:"blocked_by,omitempty"`
+	BlockReasons       map[string]string  `yaml:"block_reasons,omitempty" json:"block_reasons,omitempty"` // why each blocker applies, keyed by blocker ID
+	Notes              string             `yaml:"notes,omitempty" json:"notes,omitempty"`
+	Tracking           string             `yaml:"tracking,omitempty" json:"tracking,omitempty"`
+	Link               string             `yaml:"link,omitempty" json:"link,omitempty"`
+	Resolution         string             `yaml:"resolution,omitempty" json:"resolution,omitempty"`
+	Created            time.Time          `yaml:"created" json:"created"`
+	DoneAt             *time.Time         `yaml:"done_at,omitempty" json:"done_at,omitempty"`
+	DroppedAt          *time.Time         `yaml:"dropped_at,omitempty" json:"dropped_at,omitempty"`
+	DropReason         string             `yaml:"drop_reason,omitempty" json:"drop_reason,omitempty"`
 }
 
 // ProjectFile represents a complete project file with all tasks and waits.
 type ProjectFile struct {
 	Project `yaml:",inline"`
-	Tasks   []Task `yaml:"tasks,omitempty"`
-	Waits   []Wait `yaml:"waits,omitempty"`
+	Tasks   []Task `yaml:"tasks,omitempty" json:"tasks,omitempty"`
+	Waits   []Wait `yaml:"waits,omitempty" json:"waits,omitempty"`
+
+	// ProjectBlockers records, for each whole-project blocker reference
+	// (@BY) in the file, whether that project is finished. Storage fills it
+	// in on load; it is never saved.
+	ProjectBlockers BlockerStatus `yaml:"-" json:"-"`
+}
+
+// HasOpenItems reports whether any task or wait in the project is still
+// open, i.e. not done or dropped.
+func (pf *ProjectFile) HasOpenItems() bool {
+	for _, t := range pf.Tasks {
+		if t.Status == TaskStatusOpen {
+			return true
+		}
+	}
+	for _, w := range pf.Waits {
+		if w.Status == WaitStatusOpen {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectRefs returns the whole-project blocker references used by any task
+// or wait in the file, uppercased and without duplicates.
+func (pf *ProjectFile) ProjectRefs() []string {
+	seen := make(map[string]bool)
+	var refs []string
+	add := func(blockedBy []string) {
+		for _, id := range blockedBy {
+			if prefix, ok := ParseProjectRef(id); ok && !seen[prefix] {
+				seen[prefix] = true
+				refs = append(refs, FormatProjectRef(prefix))
+			}
+		}
+	}
+	for _, t := range pf.Tasks {
+		add(t.BlockedBy)
+	}
+	for _, w := range pf.Waits {
+		add(w.BlockedBy)
+	}
+	return refs
 }
 
 // DisplayText returns the text to display for a wait in list views.
@@ -106,7 +166,7 @@ func (w *Wait) DisplayText() string {
 		return w.Title
 	}
 	if w.ResolutionCriteria.Type == ResolutionTypeTime && w.ResolutionCriteria.After != nil {
-		return "Until " + w.ResolutionCriteria.After.Format("2006-01-02")
+		return "Until " + FormatDate(*w.ResolutionCriteria.After)
 	}
 	if w.ResolutionCriteria.Type == ResolutionTypeManual {
 		return w.ResolutionCriteria.Question
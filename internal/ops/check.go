@@ -1,6 +1,7 @@
 package ops
 
 import (
+	"sort"
 	"time"
 
 	"github.com/jacksmith/tk/internal/model"
@@ -9,11 +10,19 @@ import (
 // CheckResult contains the results of running auto-resolution check.
 type CheckResult struct {
 	// ResolvedWaits lists waits that were auto-resolved (time waits that passed).
-	ResolvedWaits []string
+	ResolvedWaits []string `json:"resolved_waits"`
 	// Unblocked lists tasks/waits that are now unblocked.
-	Unblocked []string
+	Unblocked []string `json:"unblocked"`
+	// NowReady lists open tasks that moved into the ready state during this
+	// check. Unlike Unblocked, tasks that were already ready are excluded.
+	NowReady []string `json:"now_ready"`
 	// AutoCompleted lists tasks that were auto-completed as a cascade.
-	AutoCompleted []string
+	AutoCompleted []string `json:"auto_completed"`
+}
+
+// Changed reports whether the check resolved or unblocked anything.
+func (r *CheckResult) Changed() bool {
+	return len(r.ResolvedWaits) > 0 || len(r.Unblocked) > 0 || len(r.AutoCompleted) > 0
 }
 
 // RunCheck auto-resolves time-based waits that have passed their 'after' date.
@@ -23,9 +32,15 @@ func RunCheck(s Store) (*CheckResult, error) {
 }
 
 // RunCheckAt runs the check using the specified time (useful for testing).
+// Projects listed in ignored_projects are skipped.
 func RunCheckAt(s Store, now time.Time) (*CheckResult, error) {
 	result := &CheckResult{}
 
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	// Get all projects
 	prefixes, err := s.ListProjects()
 	if err != nil {
@@ -33,6 +48,14 @@ func RunCheckAt(s Store, now time.Time) (*CheckResult, error) {
 	}
 
 	for _, prefix := range prefixes {
+		pf, err := s.LoadProject(prefix)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.IsProjectIgnored(pf.Prefix, pf.ID) {
+			continue
+		}
+
 		projectResult, err := runCheckOnProject(s, prefix, now)
 		if err != nil {
 			return nil, err
@@ -40,14 +63,29 @@ func RunCheckAt(s Store, now time.Time) (*CheckResult, error) {
 
 		result.ResolvedWaits = append(result.ResolvedWaits, projectResult.ResolvedWaits...)
 		result.Unblocked = append(result.Unblocked, projectResult.Unblocked...)
+		result.NowReady = append(result.NowReady, projectResult.NowReady...)
 		result.AutoCompleted = append(result.AutoCompleted, projectResult.AutoCompleted...)
 	}
 
 	return result, nil
 }
 
+// RunCheckProject runs the check on a single project, which may be one
+// listed in ignored_projects.
+func RunCheckProject(s Store, projectRef string) (*CheckResult, error) {
+	pf, err := ResolveProject(s, projectRef)
+	if err != nil {
+		return nil, err
+	}
+	return runCheckOnProject(s, pf.Prefix, time.Now())
+}
+
 // runCheckOnProject runs the check on a single project.
 func runCheckOnProject(s Store, prefix string, now time.Time) (*CheckResult, error) {
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
 	pf, err := s.LoadProject(prefix)
 	if err != nil {
 		return nil, err
@@ -57,7 +95,8 @@ func runCheckOnProject(s Store, prefix string, now time.Time) (*CheckResult, err
 	modified := false
 
 	// Build initial blocker states
-	blockerStates := ComputeBlockerStates(pf)
+	blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
+	wasReady := readyTaskSet(pf, blockerStates)
 
 	// Find time waits that are ready to resolve
 	for i := range pf.Waits {
@@ -115,6 +154,13 @@ func runCheckOnProject(s Store, prefix string, now time.Time) (*CheckResult, err
 		if len(autoCompleted) > 0 {
 			modified = true
 		}
+
+		for id := range readyTaskSet(pf, blockerStates) {
+			if !wasReady[id] {
+				result.NowReady = append(result.NowReady, id)
+			}
+		}
+		sort.Strings(result.NowReady)
 	}
 
 	if modified {
@@ -123,9 +169,27 @@ func runCheckOnProject(s Store, prefix string, now time.Time) (*CheckResult, err
 		}
 	}
 
+	for _, waitID := range result.ResolvedWaits {
+		runResolveHooks(s, waitID, "")
+	}
+	for _, taskID := range result.AutoCompleted {
+		runHooks(s, HookEventTaskDone, taskID)
+	}
+
 	return result, nil
 }
 
+// readyTaskSet returns the IDs of tasks currently in the ready state.
+func readyTaskSet(pf *model.ProjectFile, blockerStates model.BlockerStatus) map[string]bool {
+	ready := make(map[string]bool)
+	for i := range pf.Tasks {
+		if model.ComputeTaskState(&pf.Tasks[i], blockerStates) == model.TaskStateReady {
+			ready[pf.Tasks[i].ID] = true
+		}
+	}
+	return ready
+}
+
 // findNewlyUnblocked finds items that have all blockers resolved.
 func findNewlyUnblocked(pf *model.ProjectFile, blockerStates model.BlockerStatus) []string {
 	var unblocked []string
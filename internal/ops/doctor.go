@@ -0,0 +1,173 @@
+package ops
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jacksmith/tk/internal/model"
+)
+
+// StaleManualWaitDays is how long past its check-after date an actionable
+// manual wait may stay open before doctor reports it as forgotten.
+const StaleManualWaitDays = 7
+
+// DoctorReport is the outcome of a store-wide health check.
+type DoctorReport struct {
+	Projects int               // projects checked
+	Fixes    []ValidationFix   // repairs applied (with fix)
+	Issues   []ValidationError // problems remaining after any repairs
+}
+
+// Doctor checks the whole store: every project's data (as Validate does),
+// the config's references to projects and hook events, and manual waits
+// whose check-after date is long past. With fix, it first applies the
+// repairs ValidateAndFix makes and clears a default_project that names no
+// project through cw, then reports what remains.
+func Doctor(s Store, cw ConfigWriter, fix bool) (*DoctorReport, error) {
+	prefixes, err := s.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	report := &DoctorReport{Projects: len(prefixes)}
+
+	if fix {
+		fixes, err := ValidateAndFix(s)
+		if err != nil {
+			return nil, err
+		}
+		configFixes, err := fixConfig(s, cw)
+		if err != nil {
+			return nil, err
+		}
+		report.Fixes = append(fixes, configFixes...)
+	}
+
+	issues, err := Validate(s)
+	if err != nil {
+		return nil, err
+	}
+	configIssues, err := ValidateConfig(s)
+	if err != nil {
+		return nil, err
+	}
+	stale, err := staleManualWaits(s, prefixes)
+	if err != nil {
+		return nil, err
+	}
+	report.Issues = append(append(issues, configIssues...), stale...)
+	return report, nil
+}
+
+// ValidateConfig checks .tkconfig.yaml for settings that refer to projects
+// or hook events that don't exist, or are out of range. Issues are reported
+// against the config key.
+func ValidateConfig(s Store) ([]ValidationError, error) {
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var errors []ValidationError
+	add := func(key, format string, args ...any) {
+		errors = append(errors, ValidationError{
+			Type:    ValidationErrorConfig,
+			ItemID:  key,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if cfg.DefaultProject != "" {
+		if _, err := s.LoadProjectByID(cfg.DefaultProject); err != nil {
+			add("default_project", "no project has ID %q", cfg.DefaultProject)
+		}
+	}
+	for _, ref := range cfg.IgnoredProjects {
+		if !s.ProjectExists(strings.ToUpper(ref)) {
+			if _, err := s.LoadProjectByID(ref); err != nil {
+				add("ignored_projects", "%q matches no project prefix or ID", ref)
+			}
+		}
+	}
+	if err := ValidatePriority(cfg.DefaultPriority); err != nil {
+		add("default_priority", "%v", err)
+	}
+	if cfg.MaxAutoCascade < 0 {
+		add("max_auto_cascade", "%d is negative (use 0 for no limit)", cfg.MaxAutoCascade)
+	}
+	for _, h := range cfg.Hooks {
+		switch HookEvent(h.Event) {
+		case HookEventTaskAdd, HookEventTaskDone, HookEventWaitResolve:
+		default:
+			add("hooks", "unknown event %q (use %s, %s, or %s)", h.Event, HookEventTaskAdd, HookEventTaskDone, HookEventWaitResolve)
+		}
+		if strings.TrimSpace(h.Command) == "" {
+			add("hooks", "%s hook has no command", h.Event)
+		}
+	}
+
+	return errors, nil
+}
+
+// fixConfig clears a default_project that names no project, the one config
+// problem that can be repaired without guessing what was meant.
+func fixConfig(s Store, cw ConfigWriter) ([]ValidationFix, error) {
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.DefaultProject == "" {
+		return nil, nil
+	}
+	if _, err := s.LoadProjectByID(cfg.DefaultProject); err == nil {
+		return nil, nil
+	}
+	if err := cw.SetConfigValue("default_project", ""); err != nil {
+		return nil, err
+	}
+	return []ValidationFix{{
+		Type:        ValidationErrorConfig,
+		ItemID:      "default_project",
+		Description: fmt.Sprintf("cleared default_project %q, which names no project", cfg.DefaultProject),
+	}}, nil
+}
+
+// staleManualWaits reports open manual waits in active projects whose
+// check-after date passed more than StaleManualWaitDays ago, which have
+// probably been forgotten. Dormant waits are skipped.
+func staleManualWaits(s Store, prefixes []string) ([]ValidationError, error) {
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	var errors []ValidationError
+	now := time.Now()
+	staleBefore := now.AddDate(0, 0, -StaleManualWaitDays)
+	for _, prefix := range prefixes {
+		pf, err := s.LoadProject(prefix)
+		if err != nil {
+			return nil, err
+		}
+		if pf.Status != model.ProjectStatusActive {
+			continue
+		}
+		blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
+		for _, w := range pf.Waits {
+			checkAfter := w.ResolutionCriteria.CheckAfter
+			if w.Status != model.WaitStatusOpen || w.ResolutionCriteria.Type != model.ResolutionTypeManual ||
+				checkAfter == nil || !checkAfter.Before(staleBefore) {
+				continue
+			}
+			if model.ComputeWaitState(&w, blockerStates, now) == model.WaitStateDormant {
+				continue
+			}
+			days := int(now.Sub(*checkAfter).Hours() / 24)
+			errors = append(errors, ValidationError{
+				Type:    ValidationErrorStaleWait,
+				ItemID:  w.ID,
+				Message: fmt.Sprintf("manual wait was due for a check %d days ago (%s); resolve or defer it", days, model.FormatDate(*checkAfter)),
+			})
+		}
+	}
+	return errors, nil
+}
@@ -0,0 +1,117 @@
+package ops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jacksmith/tk/internal/model"
+)
+
+// HistoryEvent is one entry in an item's reconstructed timeline.
+type HistoryEvent struct {
+	Time        time.Time
+	Description string
+}
+
+// ItemHistory is the timeline of a task or wait, oldest event first.
+type ItemHistory struct {
+	ID     string
+	Title  string
+	Events []HistoryEvent
+}
+
+// GetItemHistory reconstructs a best-effort timeline for a task or wait from
+// its stored timestamps: creation, the last edit, completion or dropping,
+// and when each of its blockers was resolved. tk keeps no audit log, so
+// earlier edits and blockers that have since been removed don't appear.
+func GetItemHistory(s Store, id string) (*ItemHistory, error) {
+	pf, err := loadItemProject(s, id)
+	if err != nil {
+		return nil, err
+	}
+	id = model.NormalizeID(id, pf.NextID-1)
+
+	var h *ItemHistory
+	if model.IsWaitID(id) {
+		w := findWait(pf, id)
+		if w == nil {
+			return nil, &NotFoundError{Kind: "wait", ItemID: id}
+		}
+		h = &ItemHistory{ID: w.ID, Title: w.DisplayText()}
+		h.add(w.Created, "created")
+		h.addBlockers(pf, w.BlockedBy, w.Created)
+		if w.DoneAt != nil {
+			if w.Resolution != "" {
+				h.add(*w.DoneAt, "resolved: "+w.Resolution)
+			} else {
+				h.add(*w.DoneAt, "resolved")
+			}
+		}
+		if w.DroppedAt != nil {
+			h.add(*w.DroppedAt, withReason("dropped", w.DropReason))
+		}
+	} else {
+		t := findTask(pf, id)
+		if t == nil {
+			return nil, &NotFoundError{Kind: "task", ItemID: id}
+		}
+		h = &ItemHistory{ID: t.ID, Title: t.Title}
+		h.add(t.Created, "created")
+		h.addBlockers(pf, t.BlockedBy, t.Created)
+		if t.DoneAt != nil {
+			h.add(*t.DoneAt, "done")
+		}
+		if t.DroppedAt != nil {
+			h.add(*t.DroppedAt, withReason("dropped", t.DropReason))
+		}
+		// Completing or dropping also sets Updated, so only a later or
+		// separate edit is worth listing
+		if t.Updated.After(t.Created) && !sameTime(t.Updated, t.DoneAt) && !sameTime(t.Updated, t.DroppedAt) {
+			h.add(t.Updated, "last updated")
+		}
+	}
+
+	sort.SliceStable(h.Events, func(i, j int) bool {
+		return h.Events[i].Time.Before(h.Events[j].Time)
+	})
+	return h, nil
+}
+
+func (h *ItemHistory) add(at time.Time, description string) {
+	h.Events = append(h.Events, HistoryEvent{Time: at, Description: description})
+}
+
+// addBlockers records when each of the item's current blockers was
+// completed, resolved, or dropped. Blockers closed before the item existed
+// never held it up and are skipped.
+func (h *ItemHistory) addBlockers(pf *model.ProjectFile, blockedBy []string, created time.Time) {
+	for _, blockerID := range blockedBy {
+		var doneAt, droppedAt *time.Time
+		verb := "done"
+		if w := findWait(pf, blockerID); w != nil {
+			doneAt, droppedAt, verb = w.DoneAt, w.DroppedAt, "resolved"
+		} else if t := findTask(pf, blockerID); t != nil {
+			doneAt, droppedAt = t.DoneAt, t.DroppedAt
+		}
+		if doneAt != nil && doneAt.After(created) {
+			h.add(*doneAt, fmt.Sprintf("blocker %s %s", blockerID, verb))
+		}
+		if droppedAt != nil && droppedAt.After(created) {
+			h.add(*droppedAt, fmt.Sprintf("blocker %s dropped", blockerID))
+		}
+	}
+}
+
+// withReason appends a drop reason to an event description, if there is one.
+func withReason(description, reason string) string {
+	if reason == "" {
+		return description
+	}
+	return fmt.Sprintf("%s (%s)", description, reason)
+}
+
+// sameTime reports whether at is set and equal to t.
+func sameTime(t time.Time, at *time.Time) bool {
+	return at != nil && t.Equal(*at)
+}
@@ -0,0 +1,92 @@
+package ops
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/jacksmith/tk/internal/model"
+)
+
+// HookEvent identifies the kind of operation that triggers a hook.
+type HookEvent string
+
+const (
+	HookEventTaskAdd     HookEvent = "task_add"
+	HookEventTaskDone    HookEvent = "task_done"
+	HookEventWaitResolve HookEvent = "wait_resolve"
+)
+
+// runHooks runs every configured hook for the given event.
+// Hooks receive the item ID as their last argument and the environment
+// variables TK_EVENT, TK_ITEM_ID, and TK_PROJECT, plus any extra env given.
+// Hook failures never fail the operation that triggered them; they are
+// reported as warnings on stderr.
+func runHooks(s Store, event HookEvent, itemID string, extraEnv ...string) {
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return
+	}
+
+	env := append([]string{
+		"TK_EVENT=" + string(event),
+		"TK_ITEM_ID=" + itemID,
+		"TK_PROJECT=" + model.ExtractPrefix(itemID),
+	}, extraEnv...)
+
+	for _, h := range cfg.Hooks {
+		if HookEvent(h.Event) != event {
+			continue
+		}
+		if err := runHookCommand(h.Command, []string{itemID}, env); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %s hook failed for %s: %v\n", event, itemID, err)
+		}
+	}
+}
+
+// runResolveHooks runs the hooks for a resolved wait, including the
+// on_resolve_hook shorthand, which also receives the resolution as an argument.
+func runResolveHooks(s Store, waitID, resolution string) {
+	runHooks(s, HookEventWaitResolve, waitID, "TK_RESOLUTION="+resolution)
+
+	cfg, err := s.LoadConfig()
+	if err != nil || cfg.OnResolveHook == "" {
+		return
+	}
+
+	if err := runHookCommand(cfg.OnResolveHook, []string{waitID, resolution}, []string{
+		"TK_EVENT=" + string(HookEventWaitResolve),
+		"TK_ITEM_ID=" + waitID,
+		"TK_PROJECT=" + model.ExtractPrefix(waitID),
+		"TK_WAIT_ID=" + waitID,
+		"TK_RESOLUTION=" + resolution,
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: on_resolve_hook failed for %s: %v\n", waitID, err)
+	}
+}
+
+// runHookCommand executes a hook command with extra arguments and environment.
+func runHookCommand(command string, args []string, env []string) error {
+	// Split command into program and args (e.g., "notify-send 'tk wait'")
+	parts, err := model.SplitCommand(command)
+	if err != nil {
+		return err
+	}
+	if len(parts) == 0 {
+		return fmt.Errorf("empty hook command")
+	}
+
+	cmd := exec.Command(parts[0], append(parts[1:], args...)...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return fmt.Errorf("hook exited with status %d", exitErr.ExitCode())
+		}
+		return fmt.Errorf("failed to run hook: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package ops
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// StaleTaskDays is how long an open task can go unchanged before tk lint
+// --stale reports it.
+const StaleTaskDays = 90
+
+// DuplicateGroup is a set of open tasks in one project whose titles are
+// similar enough that they are probably the same piece of work.
+type DuplicateGroup struct {
+	Project string
+	Tasks   []TaskResult
+}
+
+// FindDuplicateTasks scans open tasks for likely duplicates. Titles are
+// normalized (case, punctuation, whitespace) and compared by edit distance;
+// tasks whose titles are within the similarity threshold are grouped
+// together. Only tasks in the same project are compared. An empty
+// projectRef scans all active projects.
+func FindDuplicateTasks(s Store, projectRef string) ([]DuplicateGroup, error) {
+	results, err := ListTasks(s, TaskFilter{Project: projectRef})
+	if err != nil {
+		return nil, err
+	}
+
+	// Bucket tasks by project, preserving list order
+	var prefixes []string
+	byProject := make(map[string][]TaskResult)
+	for _, r := range results {
+		if _, ok := byProject[r.Project]; !ok {
+			prefixes = append(prefixes, r.Project)
+		}
+		byProject[r.Project] = append(byProject[r.Project], r)
+	}
+
+	var groups []DuplicateGroup
+	for _, prefix := range prefixes {
+		tasks := byProject[prefix]
+		titles := make([]string, len(tasks))
+		for i, t := range tasks {
+			titles[i] = normalizeTitle(t.Task.Title)
+		}
+
+		// Union-find over similar pairs so chains of near-matches group together
+		parent := make([]int, len(tasks))
+		for i := range parent {
+			parent[i] = i
+		}
+		var find func(int) int
+		find = func(i int) int {
+			if parent[i] != i {
+				parent[i] = find(parent[i])
+			}
+			return parent[i]
+		}
+		for i := range tasks {
+			for j := i + 1; j < len(tasks); j++ {
+				if titlesSimilar(titles[i], titles[j]) {
+					parent[find(j)] = find(i)
+				}
+			}
+		}
+
+		members := make(map[int][]TaskResult)
+		var roots []int
+		for i, t := range tasks {
+			root := find(i)
+			if _, ok := members[root]; !ok {
+				roots = append(roots, root)
+			}
+			members[root] = append(members[root], t)
+		}
+		for _, root := range roots {
+			if len(members[root]) > 1 {
+				groups = append(groups, DuplicateGroup{Project: prefix, Tasks: members[root]})
+			}
+		}
+	}
+
+	return groups, nil
+}
+
+// FindStaleTasks returns open tasks that haven't been updated in
+// StaleTaskDays days, least recently updated first. Snoozed tasks are left
+// out, since they were pushed back on purpose. An empty projectRef scans all
+// active projects.
+func FindStaleTasks(s Store, projectRef string, now time.Time) ([]TaskResult, error) {
+	results, err := ListTasks(s, TaskFilter{Project: projectRef, HideSnoozed: true})
+	if err != nil {
+		return nil, err
+	}
+
+	staleBefore := now.AddDate(0, 0, -StaleTaskDays)
+	var stale []TaskResult
+	for _, r := range results {
+		if r.Task.Updated.Before(staleBefore) {
+			stale = append(stale, r)
+		}
+	}
+	sort.SliceStable(stale, func(i, j int) bool {
+		return stale[i].Task.Updated.Before(stale[j].Task.Updated)
+	})
+	return stale, nil
+}
+
+// normalizeTitle lowercases a title and collapses punctuation and runs of
+// whitespace into single spaces.
+func normalizeTitle(title string) string {
+	mapped := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, title)
+	return strings.Join(strings.Fields(mapped), " ")
+}
+
+// titlesSimilar reports whether two normalized titles differ by at most one
+// edit per five characters of the longer title.
+func titlesSimilar(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	return editDistance(ra, rb)*5 <= longest
+}
+
+// editDistance returns the Levenshtein distance between two rune slices.
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -2,6 +2,7 @@ package ops
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -34,6 +35,210 @@ func setupTestStorage(t *testing.T) (*storage.Storage, func()) {
 	return s, cleanup
 }
 
+// TestLookupProjectFuzzy tests partial project references.
+func TestLookupProjectFuzzy(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	CreateProject(s, "backyard", "BY", "Backyard Garden", "")
+	CreateProject(s, "backend", "BE", "Server Work", "")
+	CreateProject(s, "house", "HM", "Home Maintenance", "")
+
+	tests := []struct {
+		ref    string
+		want   string
+		errMsg string
+	}{
+		{ref: "backy", want: "BY"},
+		{ref: "BACKYARD", want: "BY"},
+		{ref: "hou", want: "HM"},
+		{ref: "maint", want: "HM"},
+		{ref: "garden", want: "BY"},
+		{ref: "back", errMsg: "ambiguous"},
+		{ref: "zzz", errMsg: "not found"},
+	}
+	for _, tt := range tests {
+		pf, err := LookupProject(s, tt.ref)
+		if tt.errMsg != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("LookupProject(%q): expected error containing %q, got %v", tt.ref, tt.errMsg, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("LookupProject(%q) failed: %v", tt.ref, err)
+			continue
+		}
+		if pf.Prefix != tt.want {
+			t.Errorf("LookupProject(%q) = %s, want %s", tt.ref, pf.Prefix, tt.want)
+		}
+	}
+
+	// Ambiguous errors list the candidates
+	_, err := LookupProject(s, "back")
+	if err == nil || !strings.Contains(err.Error(), "backyard (BY)") || !strings.Contains(err.Error(), "backend (BE)") {
+		t.Errorf("expected candidates in error, got %v", err)
+	}
+
+	// ResolveProject, used by everything that writes, stays exact
+	if _, err := ResolveProject(s, "backy"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("ResolveProject(%q): expected not found, got %v", "backy", err)
+	}
+	if pf, err := ResolveProject(s, "backyard"); err != nil || pf.Prefix != "BY" {
+		t.Errorf("ResolveProject(%q) = %v, %v; want BY", "backyard", pf, err)
+	}
+}
+
+// TestSimilarPrefixes tests finding prefixes one edit away from a new one.
+func TestSimilarPrefixes(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	CreateProject(s, "backyard", "BY", "Backyard", "")
+	CreateProject(s, "bay", "BAY", "Bay House", "")
+	CreateProject(s, "house", "HM", "House", "")
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"bv", []string{"BY"}},        // substitution
+		{"BYX", []string{"BY"}},       // insertion
+		{"BA", []string{"BAY", "BY"}}, // deletion from BAY, substitution in BY
+		{"QQ", nil},
+		{"HM", nil}, // exact matches are left to CreateProject
+	}
+	for _, tt := range tests {
+		got, err := SimilarPrefixes(s, tt.prefix)
+		if err != nil {
+			t.Fatalf("SimilarPrefixes(%q) failed: %v", tt.prefix, err)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("SimilarPrefixes(%q) = %v, want %v", tt.prefix, got, tt.want)
+		}
+	}
+}
+
+// TestIgnoredProjects tests that ignored_projects hides a project from
+// aggregate queries but not from an explicit project filter.
+func TestIgnoredProjects(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	CreateProject(s, "archive", "AR", "Old Stuff", "")
+	AddTask(s, "TS", "Visible task", TaskOptions{})
+	AddTask(s, "AR", "Hidden task", TaskOptions{})
+
+	if err := os.WriteFile(s.ConfigPath(), []byte("ignored_projects: [ar]\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	projects, err := LoadActiveProjects(s, true)
+	if err != nil {
+		t.Fatalf("LoadActiveProjects failed: %v", err)
+	}
+	for _, pf := range projects {
+		if pf.Prefix == "AR" {
+			t.Error("ignored project should not be loaded")
+		}
+	}
+
+	results, err := ListTasks(s, TaskFilter{})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Task.ID != "TS-01" {
+		t.Errorf("expected only TS-01, got %v", results)
+	}
+
+	results, err = ListTasks(s, TaskFilter{Project: "AR"})
+	if err != nil {
+		t.Fatalf("ListTasks with project failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Task.ID != "AR-01" {
+		t.Errorf("expected AR-01 with explicit project, got %v", results)
+	}
+
+	// Fuzzy references still reach ignored projects
+	pf, err := LookupProject(s, "arch")
+	if err != nil {
+		t.Fatalf("LookupProject failed: %v", err)
+	}
+	if pf.Prefix != "AR" {
+		t.Errorf("expected AR, got %s", pf.Prefix)
+	}
+}
+
+// TestRenameProjectID tests changing a project's ID.
+func TestRenameProjectID(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	CreateProject(s, "p1", "BY", "Backyard", "")
+	CreateProject(s, "house", "HM", "House", "")
+	AddTask(s, "BY", "Plant tree", TaskOptions{})
+
+	updated, err := RenameProjectID(s, s, "BY", "Backyard")
+	if err != nil {
+		t.Fatalf("RenameProjectID failed: %v", err)
+	}
+	if updated {
+		t.Error("config should not be updated when default_project refers to another project")
+	}
+
+	pf, err := s.LoadProjectByID("backyard")
+	if err != nil {
+		t.Fatalf("expected project under new ID: %v", err)
+	}
+	if pf.Prefix != "BY" || len(pf.Tasks) != 1 || pf.Tasks[0].ID != "BY-01" {
+		t.Error("prefix and task IDs should be unchanged")
+	}
+	if _, err := s.LoadProjectByID("p1"); err == nil {
+		t.Error("old ID should no longer resolve")
+	}
+
+	// The archive carries the new ID too
+	CompleteTask(s, "BY-01", CompleteOptions{})
+	if _, err := ArchiveProject(s, "BY"); err != nil {
+		t.Fatalf("ArchiveProject failed: %v", err)
+	}
+	if _, err := RenameProjectID(s, s, "BY", "garden"); err != nil {
+		t.Fatalf("RenameProjectID failed: %v", err)
+	}
+	archive, err := GetArchive(s, "garden")
+	if err != nil {
+		t.Fatalf("GetArchive failed: %v", err)
+	}
+	if archive.ID != "garden" || len(archive.Tasks) != 1 {
+		t.Errorf("expected archive with ID garden and 1 task, got %q with %d", archive.ID, len(archive.Tasks))
+	}
+
+	if _, err := RenameProjectID(s, s, "BY", "house"); err == nil {
+		t.Error("expected error for ID collision")
+	}
+	if _, err := RenameProjectID(s, s, "BY", " "); err == nil {
+		t.Error("expected error for empty ID")
+	}
+
+	// Renaming the default project updates default_project in config
+	def, _ := s.LoadConfig()
+	tsPf, _ := s.LoadProject("TS")
+	if def.DefaultProject != tsPf.ID {
+		t.Fatalf("expected TS to be the default project, got %q", def.DefaultProject)
+	}
+	updated, err = RenameProjectID(s, s, "TS", "main")
+	if err != nil {
+		t.Fatalf("RenameProjectID failed: %v", err)
+	}
+	if !updated {
+		t.Error("expected config to be updated")
+	}
+	cfg, _ := s.LoadConfig()
+	if cfg.DefaultProject != "main" {
+		t.Errorf("expected default_project 'main', got %q", cfg.DefaultProject)
+	}
+}
+
 // TestCreateProject tests project creation.
 func TestCreateProject(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -115,6 +320,31 @@ func TestEditProject(t *testing.T) {
 	}
 }
 
+// TestEditProjectNotes tests setting and clearing multi-line project notes.
+func TestEditProjectNotes(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	notes := "2026-10: waiting on permits\n2026-09: design approved"
+	if err := EditProject(s, "TS", ProjectChanges{Notes: &notes}); err != nil {
+		t.Fatalf("EditProject failed: %v", err)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	if pf.Notes != notes {
+		t.Errorf("expected notes %q, got %q", notes, pf.Notes)
+	}
+
+	empty := ""
+	if err := EditProject(s, "TS", ProjectChanges{Notes: &empty}); err != nil {
+		t.Fatalf("EditProject failed: %v", err)
+	}
+	pf, _ = s.LoadProject("TS")
+	if pf.Notes != "" {
+		t.Errorf("expected notes to be cleared, got %q", pf.Notes)
+	}
+}
+
 // TestDeleteProject tests project deletion.
 func TestDeleteProject(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -144,6 +374,139 @@ func TestDeleteProject(t *testing.T) {
 	}
 }
 
+// TestCompleteProject tests marking a project done.
+func TestCompleteProject(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Task 1", TaskOptions{})
+	AddTask(s, "TS", "Task 2", TaskOptions{})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Inspected?"})
+	CompleteTask(s, "TS-01", CompleteOptions{})
+
+	// Should fail, listing the open items
+	_, err := CompleteProject(s, "TS", false)
+	if err == nil {
+		t.Fatal("expected error when completing project with open items")
+	}
+	if !strings.Contains(err.Error(), "TS-02, TS-03W") {
+		t.Errorf("expected open items listed, got %v", err)
+	}
+	pf, _ := s.LoadProject("TS")
+	if pf.Status != model.ProjectStatusActive {
+		t.Errorf("expected project still active, got %s", pf.Status)
+	}
+
+	// A partial reference is refused even with force
+	if _, err := CompleteProject(s, "def", true); err == nil {
+		t.Error("expected error for a partial project reference")
+	}
+
+	// Should drop the open items with force
+	dropped, err := CompleteProject(s, "TS", true)
+	if err != nil {
+		t.Fatalf("CompleteProject with force failed: %v", err)
+	}
+	if got := strings.Join(dropped, ","); got != "TS-02,TS-03W" {
+		t.Errorf("expected TS-02,TS-03W dropped, got %s", got)
+	}
+
+	pf, _ = s.LoadProject("TS")
+	if pf.Status != model.ProjectStatusDone {
+		t.Errorf("expected project done, got %s", pf.Status)
+	}
+	if task := findTask(pf, "TS-01"); task.Status != model.TaskStatusDone {
+		t.Errorf("expected TS-01 to stay done, got %s", task.Status)
+	}
+	if task := findTask(pf, "TS-02"); task.Status != model.TaskStatusDropped {
+		t.Errorf("expected TS-02 dropped, got %s", task.Status)
+	}
+	if wait := findWait(pf, "TS-03W"); wait.Status != model.WaitStatusDropped {
+		t.Errorf("expected TS-03W dropped, got %s", wait.Status)
+	}
+
+	if _, err := CompleteProject(s, "TS", false); err == nil {
+		t.Error("expected error completing a project that is already done")
+	}
+}
+
+// TestArchiveProject tests moving closed items into the archive file.
+func TestArchiveProject(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Old", TaskOptions{})
+	AddTask(s, "TS", "Survey", TaskOptions{})
+	AddTask(s, "TS", "Dig", TaskOptions{BlockedBy: []string{"TS-02"}})
+	AddTask(s, "TS", "Plant", TaskOptions{BlockedBy: []string{"TS-03"}})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Inspected?"})
+	CompleteTask(s, "TS-01", CompleteOptions{})
+	CompleteTask(s, "TS-02", CompleteOptions{})
+	CompleteTask(s, "TS-03", CompleteOptions{})
+	ResolveWait(s, "TS-05W", "yes")
+
+	// Closed blockers of the open task, direct or not, stay behind
+	result, err := ArchiveProject(s, "TS")
+	if err != nil {
+		t.Fatalf("ArchiveProject failed: %v", err)
+	}
+	if got := strings.Join(result.Tasks, ","); got != "TS-01" {
+		t.Errorf("expected TS-01 archived, got %s", got)
+	}
+	if got := strings.Join(result.Waits, ","); got != "TS-05W" {
+		t.Errorf("expected TS-05W archived, got %s", got)
+	}
+	if got := strings.Join(result.Kept, ","); got != "TS-02,TS-03" {
+		t.Errorf("expected TS-02,TS-03 kept, got %s", got)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	if len(pf.Tasks) != 3 || len(pf.Waits) != 0 {
+		t.Errorf("expected 3 tasks and no waits left, got %d and %d", len(pf.Tasks), len(pf.Waits))
+	}
+	if pf.NextID != 6 {
+		t.Errorf("expected next_id to stay 6, got %d", pf.NextID)
+	}
+
+	// A second run merges into the existing archive
+	CompleteTask(s, "TS-04", CompleteOptions{})
+	result, err = ArchiveProject(s, "TS")
+	if err != nil {
+		t.Fatalf("second ArchiveProject failed: %v", err)
+	}
+	if got := strings.Join(result.Tasks, ","); got != "TS-02,TS-03,TS-04" {
+		t.Errorf("expected TS-02,TS-03,TS-04 archived, got %s", got)
+	}
+	archive, err := s.LoadArchive("TS")
+	if err != nil {
+		t.Fatalf("LoadArchive failed: %v", err)
+	}
+	if len(archive.Tasks) != 4 || len(archive.Waits) != 1 {
+		t.Errorf("expected 4 tasks and 1 wait archived, got %d and %d", len(archive.Tasks), len(archive.Waits))
+	}
+
+	// Nothing left to archive
+	result, err = ArchiveProject(s, "TS")
+	if err != nil {
+		t.Fatalf("third ArchiveProject failed: %v", err)
+	}
+	if len(result.Tasks) != 0 || len(result.Waits) != 0 {
+		t.Errorf("expected nothing archived, got %v and %v", result.Tasks, result.Waits)
+	}
+
+	// The archive follows a prefix change
+	if err := ChangeProjectPrefix(s, "TS", "TT"); err != nil {
+		t.Fatalf("ChangeProjectPrefix failed: %v", err)
+	}
+	archive, _ = s.LoadArchive("TT")
+	if archive == nil || archive.Tasks[2].ID != "TT-03" || archive.Tasks[2].BlockedBy[0] != "TT-02" {
+		t.Errorf("expected archive renumbered to TT, got %+v", archive)
+	}
+	if old, _ := s.LoadArchive("TS"); old != nil {
+		t.Error("expected old archive removed")
+	}
+}
+
 // TestChangeProjectPrefix tests prefix changes.
 func TestChangeProjectPrefix(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -181,6 +544,108 @@ func TestChangeProjectPrefix(t *testing.T) {
 	}
 }
 
+// failingSaveStore is a Store whose SaveProject fails for any project file
+// that holds tasks.
+type failingSaveStore struct {
+	Store
+}
+
+func (f failingSaveStore) SaveProject(p *model.ProjectFile) error {
+	if len(p.Tasks) > 0 {
+		return errors.New("disk full")
+	}
+	return f.Store.SaveProject(p)
+}
+
+// TestCopyProjectSaveFails tests that a failed copy doesn't leave an empty
+// project behind.
+func TestCopyProjectSaveFails(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Dig", TaskOptions{})
+
+	if _, err := CopyProject(failingSaveStore{s}, "TS", CopyOptions{Prefix: "TV"}); err == nil {
+		t.Fatal("expected error when the copy can't be saved")
+	}
+	if s.ProjectExists("TV") {
+		t.Error("expected the new project removed after the failed copy")
+	}
+}
+
+// TestCopyProject tests duplicating a project under a new prefix.
+func TestCopyProject(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	CreateProject(s, "house", "HM", "House", "")
+	AddTask(s, "HM", "Get keys", TaskOptions{})
+	AddTask(s, "TS", "Dig", TaskOptions{Tags: []string{"outside"}})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Permit approved?"})
+	if _, err := AddTask(s, "TS", "Plant", TaskOptions{BlockedBy: []string{"TS-01", "TS-02W"}}); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if _, err := AddProjectBlocker(s, "TS-03", "HM", ""); err != nil {
+		t.Fatalf("AddProjectBlocker failed: %v", err)
+	}
+	if _, err := CompleteTask(s, "TS-01", CompleteOptions{}); err != nil {
+		t.Fatalf("CompleteTask failed: %v", err)
+	}
+
+	pf, err := CopyProject(s, "TS", CopyOptions{ID: "test-2", Prefix: "tt"})
+	if err != nil {
+		t.Fatalf("CopyProject failed: %v", err)
+	}
+	if pf.ID != "test-2" || pf.Prefix != "TT" {
+		t.Errorf("expected test-2/TT, got %s/%s", pf.ID, pf.Prefix)
+	}
+	if pf.NextID != 4 {
+		t.Errorf("expected next ID 4, got %d", pf.NextID)
+	}
+
+	copied, err := s.LoadProject("TT")
+	if err != nil {
+		t.Fatalf("failed to load copy: %v", err)
+	}
+	if len(copied.Tasks) != 2 || len(copied.Waits) != 1 {
+		t.Fatalf("expected 2 tasks and 1 wait, got %d and %d", len(copied.Tasks), len(copied.Waits))
+	}
+	plant := findTask(copied, "TT-03")
+	if plant == nil {
+		t.Fatal("TT-03 not found in copy")
+	}
+	if got := strings.Join(plant.BlockedBy, ","); got != "TT-01,TT-02W,@HM" {
+		t.Errorf("expected blockers TT-01,TT-02W,@HM, got %s", got)
+	}
+	if dig := findTask(copied, "TT-01"); dig.Status != model.TaskStatusDone {
+		t.Errorf("expected TT-01 to stay done without --reset-status, got %s", dig.Status)
+	}
+
+	// The source is untouched
+	src, _ := s.LoadProject("TS")
+	if src.Tasks[1].BlockedBy[0] != "TS-01" {
+		t.Errorf("source blockers changed: %v", src.Tasks[1].BlockedBy)
+	}
+
+	// Reset status reopens everything
+	if _, err := CopyProject(s, "TS", CopyOptions{Prefix: "TU", ResetStatus: true}); err != nil {
+		t.Fatalf("CopyProject with reset failed: %v", err)
+	}
+	reset, _ := s.LoadProject("TU")
+	dig := findTask(reset, "TU-01")
+	if dig.Status != model.TaskStatusOpen || dig.DoneAt != nil {
+		t.Errorf("expected TU-01 reopened, got %s (done at %v)", dig.Status, dig.DoneAt)
+	}
+	if reset.ID != "tu" {
+		t.Errorf("expected ID derived from prefix, got %q", reset.ID)
+	}
+
+	// The new prefix must be free
+	if _, err := CopyProject(s, "TS", CopyOptions{Prefix: "HM"}); err == nil {
+		t.Error("expected error copying onto an existing prefix")
+	}
+}
+
 // TestAddTask tests task creation.
 func TestAddTask(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -225,6 +690,74 @@ func TestAddTask(t *testing.T) {
 	}
 }
 
+// TestAddTaskDefaultAssignee tests that new tasks inherit the project's default assignee.
+func TestAddTaskDefaultAssignee(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	assignee := "alice"
+	if err := EditProject(s, "TS", ProjectChanges{DefaultAssignee: &assignee}); err != nil {
+		t.Fatalf("EditProject failed: %v", err)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	if pf.DefaultAssignee != "alice" {
+		t.Fatalf("expected default assignee 'alice', got %q", pf.DefaultAssignee)
+	}
+
+	task, err := AddTask(s, "TS", "Defaulted", TaskOptions{})
+	if err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if task.Assignee != "alice" {
+		t.Errorf("expected assignee 'alice', got %q", task.Assignee)
+	}
+
+	// An explicit assignee overrides the project default
+	task, err = AddTask(s, "TS", "Overridden", TaskOptions{Assignee: "bob"})
+	if err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if task.Assignee != "bob" {
+		t.Errorf("expected assignee 'bob', got %q", task.Assignee)
+	}
+}
+
+// TestAddTaskTagAssignee tests that tag_assignees rules route new tasks.
+func TestAddTaskTagAssignee(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	config := "tag_assignees:\n  billing: alice\n  infra: bob\n"
+	if err := os.WriteFile(s.ConfigPath(), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	assignee := "carol"
+	if err := EditProject(s, "TS", ProjectChanges{DefaultAssignee: &assignee}); err != nil {
+		t.Fatalf("EditProject failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		opts     TaskOptions
+		expected string
+	}{
+		{"mapped tag", TaskOptions{Tags: []string{"Billing"}}, "alice"},
+		{"first matching tag wins", TaskOptions{Tags: []string{"urgent", "infra", "billing"}}, "bob"},
+		{"explicit assignee", TaskOptions{Tags: []string{"billing"}, Assignee: "dave"}, "dave"},
+		{"no matching tag", TaskOptions{Tags: []string{"urgent"}}, "carol"},
+	}
+	for _, tt := range tests {
+		task, err := AddTask(s, "TS", tt.name, tt.opts)
+		if err != nil {
+			t.Fatalf("%s: AddTask failed: %v", tt.name, err)
+		}
+		if task.Assignee != tt.expected {
+			t.Errorf("%s: expected assignee %q, got %q", tt.name, tt.expected, task.Assignee)
+		}
+	}
+}
+
 // TestEditTask tests task editing.
 func TestEditTask(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -254,6 +787,40 @@ func TestEditTask(t *testing.T) {
 	}
 }
 
+// TestEditTasks tests that a batch of edits is saved only if every edit is
+// valid, with each edit checked against the earlier ones.
+func TestEditTasks(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "First", TaskOptions{})
+	AddTask(s, "TS", "Second", TaskOptions{})
+
+	// Each edit is valid alone, but together they form a cycle
+	title := "Renamed"
+	first := []string{"TS-02"}
+	second := []string{"TS-01"}
+	err := EditTasks(s, []TaskEdit{
+		{TaskID: "TS-01", Changes: TaskChanges{Title: &title, BlockedBy: &first}},
+		{TaskID: "TS-02", Changes: TaskChanges{BlockedBy: &second}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "TS-02: adding blocker would create cycle") {
+		t.Fatalf("expected cycle error for TS-02, got %v", err)
+	}
+	pf, _ := s.LoadProject("TS")
+	if pf.Tasks[0].Title != "First" || len(pf.Tasks[0].BlockedBy) != 0 {
+		t.Errorf("expected TS-01 unchanged, got %q blocked by %v", pf.Tasks[0].Title, pf.Tasks[0].BlockedBy)
+	}
+
+	if err := EditTasks(s, []TaskEdit{{TaskID: "TS-01", Changes: TaskChanges{Title: &title, BlockedBy: &first}}}); err != nil {
+		t.Fatalf("EditTasks failed: %v", err)
+	}
+	pf, _ = s.LoadProject("TS")
+	if pf.Tasks[0].Title != title || strings.Join(pf.Tasks[0].BlockedBy, ",") != "TS-02" {
+		t.Errorf("expected TS-01 edited, got %q blocked by %v", pf.Tasks[0].Title, pf.Tasks[0].BlockedBy)
+	}
+}
+
 // TestCompleteTask tests task completion.
 func TestCompleteTask(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -264,13 +831,13 @@ func TestCompleteTask(t *testing.T) {
 	AddTask(s, "TS", "Dependent task", TaskOptions{BlockedBy: []string{"TS-01"}})
 
 	// Try to complete dependent task (should fail)
-	_, err := CompleteTask(s, "TS-02", false)
+	_, err := CompleteTask(s, "TS-02", CompleteOptions{})
 	if err == nil {
 		t.Error("expected error when completing task with incomplete blockers")
 	}
 
 	// Complete blocker task
-	result, err := CompleteTask(s, "TS-01", false)
+	result, err := CompleteTask(s, "TS-01", CompleteOptions{})
 	if err != nil {
 		t.Fatalf("CompleteTask failed: %v", err)
 	}
@@ -294,7 +861,7 @@ func TestCompleteTaskWithForce(t *testing.T) {
 	AddTask(s, "TS", "Dependent", TaskOptions{BlockedBy: []string{"TS-01"}})
 
 	// Force complete dependent task
-	_, err := CompleteTask(s, "TS-02", true)
+	_, err := CompleteTask(s, "TS-02", CompleteOptions{Force: true})
 	if err != nil {
 		t.Fatalf("CompleteTask with force failed: %v", err)
 	}
@@ -309,18 +876,85 @@ func TestCompleteTaskWithForce(t *testing.T) {
 	}
 }
 
-// TestAutoComplete tests cascading auto-completion.
-func TestAutoComplete(t *testing.T) {
+// TestCompleteTaskKeepBlockers tests forced completion that keeps the
+// incomplete blockers as a record.
+func TestCompleteTaskKeepBlockers(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
 	defer cleanup()
 
-	// Create chain: TS-01 -> TS-02 (auto) -> TS-03 (auto)
+	AddTask(s, "TS", "Blocker", TaskOptions{})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Parts arrived?"})
+	AddTask(s, "TS", "Dependent", TaskOptions{BlockedBy: []string{"TS-01", "TS-02W"}})
+
+	if _, err := CompleteTask(s, "TS-03", CompleteOptions{Force: true, KeepBlockers: true}); err != nil {
+		t.Fatalf("CompleteTask failed: %v", err)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	task := findTask(pf, "TS-03")
+	if task.Status != model.TaskStatusDone {
+		t.Error("task should be done")
+	}
+	if got := strings.Join(task.BlockedBy, ","); got != "TS-01,TS-02W" {
+		t.Errorf("expected blockers TS-01,TS-02W kept, got %q", got)
+	}
+
+	// Without Force, KeepBlockers doesn't bypass the blocker check
+	AddTask(s, "TS", "Another", TaskOptions{BlockedBy: []string{"TS-01"}})
+	if _, err := CompleteTask(s, "TS-04", CompleteOptions{KeepBlockers: true}); err == nil {
+		t.Error("expected incomplete blockers error without Force")
+	}
+}
+
+// TestPreviewCompleteTask tests that previewing a completion saves nothing.
+func TestPreviewCompleteTask(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Root", TaskOptions{})
+	AddTask(s, "TS", "Auto", TaskOptions{BlockedBy: []string{"TS-01"}, AutoComplete: true})
+	AddTask(s, "TS", "Next", TaskOptions{BlockedBy: []string{"TS-01"}})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Arrived?", BlockedBy: []string{"TS-01"}})
+
+	result, err := PreviewCompleteTask(s, "TS-01", CompleteOptions{})
+	if err != nil {
+		t.Fatalf("PreviewCompleteTask failed: %v", err)
+	}
+	if strings.Join(result.AutoCompleted, ",") != "TS-02" {
+		t.Errorf("expected TS-02 auto-completed, got %v", result.AutoCompleted)
+	}
+	if strings.Join(result.Activated, ",") != "TS-04W" {
+		t.Errorf("expected TS-04W activated, got %v", result.Activated)
+	}
+	if strings.Join(result.Unblocked, ",") != "TS-02,TS-03,TS-04W" {
+		t.Errorf("expected TS-02,TS-03,TS-04W unblocked, got %v", result.Unblocked)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	for _, id := range []string{"TS-01", "TS-02"} {
+		if findTask(pf, id).Status != model.TaskStatusOpen {
+			t.Errorf("%s should still be open after preview", id)
+		}
+	}
+
+	// Previews report the same blocker errors as completion
+	if _, err := PreviewCompleteTask(s, "TS-03", CompleteOptions{}); err == nil {
+		t.Error("expected error previewing a blocked task")
+	}
+}
+
+// TestAutoComplete tests cascading auto-completion.
+func TestAutoComplete(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	// Create chain: TS-01 -> TS-02 (auto) -> TS-03 (auto)
 	AddTask(s, "TS", "Blocker", TaskOptions{})
 	AddTask(s, "TS", "Auto 1", TaskOptions{BlockedBy: []string{"TS-01"}, AutoComplete: true})
 	AddTask(s, "TS", "Auto 2", TaskOptions{BlockedBy: []string{"TS-02"}, AutoComplete: true})
 
 	// Complete blocker
-	result, err := CompleteTask(s, "TS-01", false)
+	result, err := CompleteTask(s, "TS-01", CompleteOptions{})
 	if err != nil {
 		t.Fatalf("CompleteTask failed: %v", err)
 	}
@@ -338,6 +972,47 @@ func TestAutoComplete(t *testing.T) {
 	}
 }
 
+// TestAutoCompleteCascadeLimit tests that oversized cascades require ForceCascade.
+func TestAutoCompleteCascadeLimit(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	if err := os.WriteFile(s.ConfigPath(), []byte("max_auto_cascade: 1\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	AddTask(s, "TS", "Blocker", TaskOptions{})
+	AddTask(s, "TS", "Auto 1", TaskOptions{BlockedBy: []string{"TS-01"}, AutoComplete: true})
+	AddTask(s, "TS", "Auto 2", TaskOptions{BlockedBy: []string{"TS-02"}, AutoComplete: true})
+
+	result, err := CompleteTask(s, "TS-01", CompleteOptions{})
+	var cascadeErr *CascadeLimitError
+	if !errors.As(err, &cascadeErr) {
+		t.Fatalf("expected CascadeLimitError, got %v", err)
+	}
+	if len(cascadeErr.AutoCompleted) != 2 || len(result.AutoCompleted) != 2 {
+		t.Errorf("expected 2 tasks in cascade preview, got %v", cascadeErr.AutoCompleted)
+	}
+
+	// Nothing should have been saved
+	pf, _ := s.LoadProject("TS")
+	for _, task := range pf.Tasks {
+		if task.Status != model.TaskStatusOpen {
+			t.Errorf("task %s should still be open", task.ID)
+		}
+	}
+
+	if _, err := CompleteTask(s, "TS-01", CompleteOptions{ForceCascade: true}); err != nil {
+		t.Fatalf("CompleteTask with ForceCascade failed: %v", err)
+	}
+	pf, _ = s.LoadProject("TS")
+	for _, task := range pf.Tasks {
+		if task.Status != model.TaskStatusDone {
+			t.Errorf("task %s should be done", task.ID)
+		}
+	}
+}
+
 // TestDropTask tests task dropping.
 func TestDropTask(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -347,16 +1022,19 @@ func TestDropTask(t *testing.T) {
 	AddTask(s, "TS", "Dependent", TaskOptions{BlockedBy: []string{"TS-01"}})
 
 	// Should fail without flags
-	err := DropTask(s, "TS-01", "not needed", false, false)
+	_, err := DropTask(s, "TS-01", "not needed", false, false)
 	if err == nil {
 		t.Error("expected error when dropping task with dependents")
 	}
 
 	// Drop with remove-deps
-	err = DropTask(s, "TS-01", "not needed", false, true)
+	result, err := DropTask(s, "TS-01", "not needed", false, true)
 	if err != nil {
 		t.Fatalf("DropTask with removeDeps failed: %v", err)
 	}
+	if len(result.Unlinked) != 1 || len(result.Unblocked) != 1 || result.Unblocked[0] != "TS-02" {
+		t.Errorf("expected TS-02 unlinked and unblocked, got %+v", result)
+	}
 
 	pf, _ := s.LoadProject("TS")
 	if pf.Tasks[0].Status != model.TaskStatusDropped {
@@ -367,6 +1045,48 @@ func TestDropTask(t *testing.T) {
 	}
 }
 
+// TestDroppedUnblocks tests both meanings of a dropped blocker.
+func TestDroppedUnblocks(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Prerequisite", TaskOptions{})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Approved?"})
+	AddTask(s, "TS", "Dependent", TaskOptions{BlockedBy: []string{"TS-01", "TS-02W"}})
+
+	// Drop both blockers while leaving the dependent linked to them
+	pf, _ := s.LoadProject("TS")
+	now := time.Now()
+	findTask(pf, "TS-01").Status = model.TaskStatusDropped
+	findTask(pf, "TS-01").DroppedAt = &now
+	findWait(pf, "TS-02W").Status = model.WaitStatusDropped
+	findWait(pf, "TS-02W").DroppedAt = &now
+	s.SaveProject(pf)
+
+	state := func() model.TaskState {
+		pf, _ := s.LoadProject("TS")
+		return model.ComputeTaskState(findTask(pf, "TS-03"), ComputeBlockerStates(pf, true))
+	}
+
+	if got := state(); got != model.TaskStateReady {
+		t.Errorf("expected ready by default, got %s", got)
+	}
+
+	pf, _ = s.LoadProject("TS")
+	if got := model.ComputeTaskState(findTask(pf, "TS-03"), ComputeBlockerStates(pf, false)); got != model.TaskStateBlocked {
+		t.Errorf("expected blocked with dropped_unblocks off, got %s", got)
+	}
+	if summary := SummarizeBlockers(pf, findTask(pf, "TS-03").BlockedBy, false); summary.Resolved != 0 {
+		t.Errorf("expected no resolved blockers, got %d", summary.Resolved)
+	}
+	if err := os.WriteFile(s.ConfigPath(), []byte("dropped_unblocks: false\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CompleteTask(s, "TS-03", CompleteOptions{}); err == nil {
+		t.Error("expected completion to be refused while dropped blockers remain")
+	}
+}
+
 // TestDropTaskWithDropDeps tests cascading drop.
 func TestDropTaskWithDropDeps(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -376,10 +1096,13 @@ func TestDropTaskWithDropDeps(t *testing.T) {
 	AddTask(s, "TS", "Child", TaskOptions{BlockedBy: []string{"TS-01"}})
 	AddTask(s, "TS", "Grandchild", TaskOptions{BlockedBy: []string{"TS-02"}})
 
-	err := DropTask(s, "TS-01", "cancelled", true, false)
+	result, err := DropTask(s, "TS-01", "cancelled", true, false)
 	if err != nil {
 		t.Fatalf("DropTask with dropDeps failed: %v", err)
 	}
+	if got := strings.Join(result.Dropped, ","); got != "TS-02,TS-03" {
+		t.Errorf("expected TS-02,TS-03 also dropped, got %s", got)
+	}
 
 	pf, _ := s.LoadProject("TS")
 	for _, task := range pf.Tasks {
@@ -389,18 +1112,54 @@ func TestDropTaskWithDropDeps(t *testing.T) {
 	}
 }
 
+// TestPreviewDropTask tests that previewing a drop reports its impact on
+// dependents without changing anything.
+func TestPreviewDropTask(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Task to drop", TaskOptions{})
+	AddTask(s, "TS", "Other blocker", TaskOptions{})
+	AddTask(s, "TS", "Only blocked by TS-01", TaskOptions{BlockedBy: []string{"TS-01"}})
+	AddTask(s, "TS", "Blocked by both", TaskOptions{BlockedBy: []string{"TS-01", "TS-02"}})
+
+	result, err := PreviewDropTask(s, "TS-01", false, false)
+	if err != nil {
+		t.Fatalf("PreviewDropTask without flags failed: %v", err)
+	}
+	if got := strings.Join(result.Dependents, ","); got != "TS-03,TS-04" {
+		t.Errorf("expected dependents TS-03,TS-04, got %s", got)
+	}
+
+	result, err = PreviewDropTask(s, "TS-01", false, true)
+	if err != nil {
+		t.Fatalf("PreviewDropTask failed: %v", err)
+	}
+	if got := strings.Join(result.Unblocked, ","); got != "TS-03" {
+		t.Errorf("expected only TS-03 unblocked, got %s", got)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	if findTask(pf, "TS-01").Status != model.TaskStatusOpen || len(findTask(pf, "TS-03").BlockedBy) != 1 {
+		t.Error("preview should not change anything")
+	}
+}
+
 // TestReopenTask tests reopening tasks.
 func TestReopenTask(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
 	defer cleanup()
 
 	AddTask(s, "TS", "Task", TaskOptions{})
-	CompleteTask(s, "TS-01", false)
+	CompleteTask(s, "TS-01", CompleteOptions{})
 
-	err := ReopenTask(s, "TS-01")
+	result, err := ReopenTask(s, "TS-01")
 	if err != nil {
 		t.Fatalf("ReopenTask failed: %v", err)
 	}
+	if len(result.InconsistentDependents) != 0 {
+		t.Errorf("expected no inconsistent dependents, got %v", result.InconsistentDependents)
+	}
 
 	pf, _ := s.LoadProject("TS")
 	if pf.Tasks[0].Status != model.TaskStatusOpen {
@@ -411,6 +1170,26 @@ func TestReopenTask(t *testing.T) {
 	}
 }
 
+// TestReopenTaskInconsistentDependents tests that reopening reports done dependents.
+func TestReopenTaskInconsistentDependents(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Blocker", TaskOptions{})
+	AddTask(s, "TS", "Done dependent", TaskOptions{BlockedBy: []string{"TS-01"}})
+	AddTask(s, "TS", "Open dependent", TaskOptions{BlockedBy: []string{"TS-01"}})
+	CompleteTask(s, "TS-01", CompleteOptions{})
+	CompleteTask(s, "TS-02", CompleteOptions{})
+
+	result, err := ReopenTask(s, "TS-01")
+	if err != nil {
+		t.Fatalf("ReopenTask failed: %v", err)
+	}
+	if len(result.InconsistentDependents) != 1 || result.InconsistentDependents[0] != "TS-02" {
+		t.Errorf("expected [TS-02] inconsistent, got %v", result.InconsistentDependents)
+	}
+}
+
 // TestDeferTask tests task deferral.
 func TestDeferTask(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -443,7 +1222,7 @@ func TestAddBlocker(t *testing.T) {
 	AddTask(s, "TS", "Blocker", TaskOptions{})
 	AddTask(s, "TS", "Task", TaskOptions{})
 
-	err := AddBlocker(s, "TS-02", "TS-01")
+	err := AddBlocker(s, "TS-02", "TS-01", "")
 	if err != nil {
 		t.Fatalf("AddBlocker failed: %v", err)
 	}
@@ -455,6 +1234,158 @@ func TestAddBlocker(t *testing.T) {
 	}
 }
 
+// TestAddBlockerReason tests that a blocker reason is stored with the
+// relationship and removed along with it.
+func TestAddBlockerReason(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Blocker", TaskOptions{})
+	AddTask(s, "TS", "Task", TaskOptions{})
+
+	if err := AddBlocker(s, "TS-02", "TS-01", "  needs their output "); err != nil {
+		t.Fatalf("AddBlocker failed: %v", err)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	task := findTask(pf, "TS-02")
+	if got := task.BlockReasons["TS-01"]; got != "needs their output" {
+		t.Errorf("expected trimmed reason keyed by blocker ID, got %v", task.BlockReasons)
+	}
+
+	if err := RemoveBlocker(s, "TS-02", "TS-01"); err != nil {
+		t.Fatalf("RemoveBlocker failed: %v", err)
+	}
+
+	pf, _ = s.LoadProject("TS")
+	task = findTask(pf, "TS-02")
+	if len(task.BlockReasons) != 0 {
+		t.Errorf("reason should be removed with the blocker, got %v", task.BlockReasons)
+	}
+}
+
+// TestAddProjectBlocker tests blocking a task on a whole project finishing.
+func TestAddProjectBlocker(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	CreateProject(s, "house", "HM", "House", "")
+	AddTask(s, "TS", "Move in", TaskOptions{})
+	AddTask(s, "HM", "Paint", TaskOptions{})
+	AddWait(s, "HM", WaitOptions{Type: model.ResolutionTypeManual, Question: "Inspected?"})
+
+	if _, err := AddProjectBlocker(s, "TS-01", "hou", ""); err == nil {
+		t.Error("expected a partial project reference to be refused")
+	}
+	prefix, err := AddProjectBlocker(s, "ts-01", "house", "needs the house done")
+	if err != nil {
+		t.Fatalf("AddProjectBlocker failed: %v", err)
+	}
+	if prefix != "HM" {
+		t.Errorf("expected resolved prefix HM, got %s", prefix)
+	}
+	if _, err := AddProjectBlocker(s, "TS-01", "HM", ""); err == nil {
+		t.Error("expected error adding the same project blocker twice")
+	}
+	if _, err := AddProjectBlocker(s, "TS-01", "TS", ""); err == nil {
+		t.Error("expected error blocking a task on its own project")
+	}
+
+	state := func() model.TaskState {
+		result, _, err := ShowTask(s, "TS-01")
+		if err != nil {
+			t.Fatalf("ShowTask failed: %v", err)
+		}
+		return result.State
+	}
+
+	pf, _ := s.LoadProject("TS")
+	task := findTask(pf, "TS-01")
+	if len(task.BlockedBy) != 1 || task.BlockedBy[0] != "@HM" {
+		t.Fatalf("expected blocker @HM, got %v", task.BlockedBy)
+	}
+	if task.BlockReasons["@HM"] != "needs the house done" {
+		t.Errorf("expected reason to be stored, got %v", task.BlockReasons)
+	}
+	if got := state(); got != model.TaskStateBlocked {
+		t.Errorf("expected blocked while HM has open items, got %s", got)
+	}
+
+	// HM can't in turn wait on TS, directly or through another project
+	if _, err := AddProjectBlocker(s, "HM-01", "TS", ""); err == nil {
+		t.Error("expected error for a project-level cycle")
+	}
+	CreateProject(s, "other", "OT", "Other", "")
+	AddTask(s, "OT", "Plan", TaskOptions{})
+	if _, err := AddProjectBlocker(s, "OT-01", "TS", ""); err != nil {
+		t.Fatalf("AddProjectBlocker failed: %v", err)
+	}
+	_, err = AddProjectBlocker(s, "HM-01", "OT", "")
+	if err == nil || !strings.Contains(err.Error(), "@OT -> @TS -> @HM -> @OT") {
+		t.Errorf("expected a transitive project cycle error, got %v", err)
+	}
+
+	if _, err := CompleteTask(s, "HM-01", CompleteOptions{}); err != nil {
+		t.Fatalf("CompleteTask failed: %v", err)
+	}
+	if got := state(); got != model.TaskStateBlocked {
+		t.Errorf("expected blocked while the HM wait is open, got %s", got)
+	}
+	if err := ResolveWait(s, "HM-02W", "yes"); err != nil {
+		t.Fatalf("ResolveWait failed: %v", err)
+	}
+	if got := state(); got != model.TaskStateReady {
+		t.Errorf("expected ready once HM is finished, got %s", got)
+	}
+
+	errs, err := ValidateProject(s, "TS")
+	if err != nil {
+		t.Fatalf("ValidateProject failed: %v", err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("expected project reference to validate, got %v", errs)
+	}
+
+	// A reference to a deleted project is an orphan
+	if err := DeleteProject(s, "HM", true); err != nil {
+		t.Fatalf("DeleteProject failed: %v", err)
+	}
+	errs, _ = ValidateProject(s, "TS")
+	if len(errs) != 1 || errs[0].Type != ValidationErrorOrphanBlocker {
+		t.Errorf("expected an orphan blocker error, got %v", errs)
+	}
+	if got := state(); got != model.TaskStateBlocked {
+		t.Errorf("expected a missing project to keep blocking, got %s", got)
+	}
+}
+
+// TestChangeProjectPrefixUpdatesProjectRefs tests that whole-project
+// blocker references follow a prefix change.
+func TestChangeProjectPrefixUpdatesProjectRefs(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	CreateProject(s, "house", "HM", "House", "")
+	AddTask(s, "TS", "Move in", TaskOptions{})
+	AddTask(s, "HM", "Paint", TaskOptions{})
+	if _, err := AddProjectBlocker(s, "TS-01", "HM", "keys"); err != nil {
+		t.Fatalf("AddProjectBlocker failed: %v", err)
+	}
+
+	if err := ChangeProjectPrefix(s, "HM", "HO"); err != nil {
+		t.Fatalf("ChangeProjectPrefix failed: %v", err)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	task := findTask(pf, "TS-01")
+	if len(task.BlockedBy) != 1 || task.BlockedBy[0] != "@HO" {
+		t.Errorf("expected blocker @HO, got %v", task.BlockedBy)
+	}
+	if task.BlockReasons["@HO"] != "keys" {
+		t.Errorf("expected reason to move with the blocker, got %v", task.BlockReasons)
+	}
+}
+
 // TestAddBlockerCycleDetection tests cycle detection.
 func TestAddBlockerCycleDetection(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -465,7 +1396,7 @@ func TestAddBlockerCycleDetection(t *testing.T) {
 	AddTask(s, "TS", "C", TaskOptions{BlockedBy: []string{"TS-02"}})
 
 	// Try to create cycle: TS-01 -> TS-03 (would create TS-01 -> TS-02 -> TS-03 -> TS-01)
-	err := AddBlocker(s, "TS-01", "TS-03")
+	err := AddBlocker(s, "TS-01", "TS-03", "")
 	if err == nil {
 		t.Error("expected error for cycle")
 	}
@@ -503,6 +1434,50 @@ func TestAddWait(t *testing.T) {
 	}
 }
 
+// TestWaitTrackingAndLink tests storing and editing wait tracking metadata.
+func TestWaitTrackingAndLink(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	wait, err := AddWait(s, "TS", WaitOptions{
+		Type:     model.ResolutionTypeManual,
+		Question: "Did the package arrive?",
+		Tracking: "123456",
+		Link:     "https://example.com/track/123456",
+	})
+	if err != nil {
+		t.Fatalf("AddWait failed: %v", err)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	if pf.Waits[0].Tracking != "123456" || pf.Waits[0].Link != "https://example.com/track/123456" {
+		t.Errorf("tracking/link not persisted: %q %q", pf.Waits[0].Tracking, pf.Waits[0].Link)
+	}
+
+	// Clearing a field removes it
+	empty := ""
+	if err := EditWait(s, wait.ID, WaitChanges{Tracking: &empty}); err != nil {
+		t.Fatalf("EditWait failed: %v", err)
+	}
+	pf, _ = s.LoadProject("TS")
+	if pf.Waits[0].Tracking != "" {
+		t.Errorf("expected tracking cleared, got %q", pf.Waits[0].Tracking)
+	}
+
+	// Links must be absolute URLs
+	bad := "not a url"
+	if err := EditWait(s, wait.ID, WaitChanges{Link: &bad}); err == nil {
+		t.Error("expected error for invalid link")
+	}
+	if _, err := AddWait(s, "TS", WaitOptions{
+		Type:     model.ResolutionTypeManual,
+		Question: "Arrived?",
+		Link:     "example.com/track",
+	}); err == nil {
+		t.Error("expected error for link without scheme")
+	}
+}
+
 // TestResolveWait tests wait resolution.
 func TestResolveWait(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -528,57 +1503,183 @@ func TestResolveWait(t *testing.T) {
 	}
 }
 
-// TestResolveWaitDormant tests that dormant waits can't be resolved.
-func TestResolveWaitDormant(t *testing.T) {
+// TestResolveWaitHook tests that on_resolve_hook runs on resolution and never fails it.
+func TestResolveWaitHook(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
 	defer cleanup()
 
-	AddTask(s, "TS", "Blocker", TaskOptions{})
-	AddWait(s, "TS", WaitOptions{
-		Type:      model.ResolutionTypeManual,
-		Question:  "Ready?",
-		BlockedBy: []string{"TS-01"},
-	})
-
-	err := ResolveWait(s, "TS-02W", "Yes")
-	if err == nil {
-		t.Error("expected error when resolving dormant wait")
+	// The script lives in a directory with a space, quoted in the config
+	outPath := filepath.Join(s.Root(), "hook.out")
+	scriptDir := filepath.Join(s.Root(), "my hooks")
+	if err := os.Mkdir(scriptDir, 0755); err != nil {
+		t.Fatalf("failed to create hook dir: %v", err)
+	}
+	scriptPath := filepath.Join(scriptDir, "hook.sh")
+	script := "#!/bin/sh\necho \"$1|$2|$TK_WAIT_ID\" >> " + outPath + "\n"
+	if err := os.WriteFile(scriptPath, []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write hook: %v", err)
+	}
+	if err := os.WriteFile(s.ConfigPath(), []byte("on_resolve_hook: \"'"+scriptPath+"'\"\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
 	}
-}
 
-// TestDropWait tests wait dropping.
-func TestDropWait(t *testing.T) {
-	s, cleanup := setupTestStorage(t)
-	defer cleanup()
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Ready?"})
+	past := time.Now().Add(-time.Hour)
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeTime, After: &past})
 
-	AddWait(s, "TS", WaitOptions{
-		Type:     model.ResolutionTypeManual,
-		Question: "Ready?",
-	})
+	if err := ResolveWait(s, "TS-01W", "yes"); err != nil {
+		t.Fatalf("ResolveWait failed: %v", err)
+	}
+	if _, err := RunCheck(s); err != nil {
+		t.Fatalf("RunCheck failed: %v", err)
+	}
 
-	err := DropWait(s, "TS-01W", "No longer needed", false, false)
+	data, err := os.ReadFile(outPath)
 	if err != nil {
-		t.Fatalf("DropWait failed: %v", err)
+		t.Fatalf("hook did not run: %v", err)
+	}
+	expected := "TS-01W|yes|TS-01W\nTS-02W||TS-02W\n"
+	if string(data) != expected {
+		t.Errorf("expected hook output %q, got %q", expected, string(data))
 	}
 
-	pf, _ := s.LoadProject("TS")
-	wait := findWait(pf, "TS-01W")
-	if wait.Status != model.WaitStatusDropped {
-		t.Error("wait should be dropped")
+	// A failing hook must not fail the resolution
+	if err := os.WriteFile(s.ConfigPath(), []byte("on_resolve_hook: /nonexistent/hook\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Again?"})
+	if err := ResolveWait(s, "TS-03W", ""); err != nil {
+		t.Errorf("ResolveWait should succeed despite hook failure: %v", err)
 	}
 }
 
-// TestDeferWait tests wait deferral.
-func TestDeferWait(t *testing.T) {
+// TestHooks tests that event hooks run with structured environment variables.
+func TestHooks(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
 	defer cleanup()
 
-	after := time.Now()
-	AddWait(s, "TS", WaitOptions{
-		Type:  model.ResolutionTypeTime,
-		After: &after,
-	})
-
+	outPath := filepath.Join(s.Root(), "hooks.out")
+	scriptPath := filepath.Join(s.Root(), "hook.sh")
+	script := "#!/bin/sh\necho \"$TK_EVENT $TK_ITEM_ID $TK_PROJECT $1\" >> " + outPath + "\n"
+	if err := os.WriteFile(scriptPath, []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write hook: %v", err)
+	}
+	config := "hooks:\n" +
+		"  - event: task_add\n    command: " + scriptPath + "\n" +
+		"  - event: task_done\n    command: " + scriptPath + "\n" +
+		"  - event: wait_resolve\n    command: " + scriptPath + "\n"
+	if err := os.WriteFile(s.ConfigPath(), []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	AddTask(s, "TS", "Blocker", TaskOptions{})
+	AddTask(s, "TS", "Auto", TaskOptions{BlockedBy: []string{"TS-01"}, AutoComplete: true})
+	if _, err := CompleteTask(s, "TS-01", CompleteOptions{}); err != nil {
+		t.Fatalf("CompleteTask failed: %v", err)
+	}
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Ready?"})
+	if err := ResolveWait(s, "TS-03W", "yes"); err != nil {
+		t.Fatalf("ResolveWait failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("hooks did not run: %v", err)
+	}
+	expected := "task_add TS-01 TS TS-01\n" +
+		"task_add TS-02 TS TS-02\n" +
+		"task_done TS-01 TS TS-01\n" +
+		"task_done TS-02 TS TS-02\n" +
+		"wait_resolve TS-03W TS TS-03W\n"
+	if string(data) != expected {
+		t.Errorf("expected hook output %q, got %q", expected, string(data))
+	}
+}
+
+// TestHookQuotedArguments tests that hook commands keep quoted arguments
+// together, with the item ID appended after them.
+func TestHookQuotedArguments(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	outPath := filepath.Join(s.Root(), "hooks.out")
+	scriptPath := filepath.Join(s.Root(), "hook.sh")
+	script := "#!/bin/sh\necho \"$#|$1|$2\" >> " + outPath + "\n"
+	if err := os.WriteFile(scriptPath, []byte(script), 0755); err != nil {
+		t.Fatalf("failed to write hook: %v", err)
+	}
+	config := "hooks:\n  - event: task_add\n    command: " + scriptPath + " \"new task\"\n" +
+		"  - event: task_done\n    command: \"'unterminated\"\n"
+	if err := os.WriteFile(s.ConfigPath(), []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	AddTask(s, "TS", "First", TaskOptions{})
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	if expected := "2|new task|TS-01\n"; string(data) != expected {
+		t.Errorf("expected hook output %q, got %q", expected, string(data))
+	}
+
+	// A command that can't be split is a warning, not a failure
+	if _, err := CompleteTask(s, "TS-01", CompleteOptions{}); err != nil {
+		t.Errorf("CompleteTask should succeed despite a malformed hook: %v", err)
+	}
+}
+
+// TestResolveWaitDormant tests that dormant waits can't be resolved.
+func TestResolveWaitDormant(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Blocker", TaskOptions{})
+	AddWait(s, "TS", WaitOptions{
+		Type:      model.ResolutionTypeManual,
+		Question:  "Ready?",
+		BlockedBy: []string{"TS-01"},
+	})
+
+	err := ResolveWait(s, "TS-02W", "Yes")
+	if err == nil {
+		t.Error("expected error when resolving dormant wait")
+	}
+}
+
+// TestDropWait tests wait dropping.
+func TestDropWait(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddWait(s, "TS", WaitOptions{
+		Type:     model.ResolutionTypeManual,
+		Question: "Ready?",
+	})
+
+	err := DropWait(s, "TS-01W", "No longer needed", false, false)
+	if err != nil {
+		t.Fatalf("DropWait failed: %v", err)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	wait := findWait(pf, "TS-01W")
+	if wait.Status != model.WaitStatusDropped {
+		t.Error("wait should be dropped")
+	}
+}
+
+// TestDeferWait tests wait deferral.
+func TestDeferWait(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	after := time.Now()
+	AddWait(s, "TS", WaitOptions{
+		Type:  model.ResolutionTypeTime,
+		After: &after,
+	})
+
 	newTime := time.Now().Add(48 * time.Hour)
 	err := DeferWait(s, "TS-01W", newTime)
 	if err != nil {
@@ -592,6 +1693,206 @@ func TestDeferWait(t *testing.T) {
 	}
 }
 
+// TestConvertWaitType tests converting waits between manual and time.
+func TestConvertWaitType(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	checkAfter := time.Now().Add(24 * time.Hour)
+	after := time.Now().Add(72 * time.Hour)
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Delivery date set?", CheckAfter: &checkAfter})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeTime, After: &after})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeTime, After: &after, Title: "Cure concrete"})
+
+	t.Run("manual to time", func(t *testing.T) {
+		if err := ConvertWaitType(s, "TS-01W", ConvertOptions{Type: model.ResolutionTypeManual}); err == nil {
+			t.Error("expected error converting to the same type")
+		}
+		if err := ConvertWaitType(s, "TS-01W", ConvertOptions{Type: model.ResolutionTypeTime}); err == nil {
+			t.Error("expected error without an after date")
+		}
+		if err := ConvertWaitType(s, "TS-01W", ConvertOptions{Type: model.ResolutionTypeTime, After: &after}); err != nil {
+			t.Fatalf("ConvertWaitType failed: %v", err)
+		}
+
+		pf, _ := s.LoadProject("TS")
+		rc := findWait(pf, "TS-01W").ResolutionCriteria
+		if rc.Type != model.ResolutionTypeTime || rc.After == nil || !rc.After.Equal(after.Truncate(time.Second)) {
+			t.Errorf("expected a time wait until %v, got %+v", after, rc)
+		}
+		if rc.Question != "" || rc.CheckAfter != nil {
+			t.Errorf("expected manual fields cleared, got %+v", rc)
+		}
+	})
+
+	t.Run("time to manual", func(t *testing.T) {
+		// An untitled wait needs a question to display
+		if err := ConvertWaitType(s, "TS-02W", ConvertOptions{Type: model.ResolutionTypeManual}); err == nil {
+			t.Error("expected error converting an untitled wait without a question")
+		}
+		if err := ConvertWaitType(s, "TS-02W", ConvertOptions{Type: model.ResolutionTypeManual, Question: "Arrived?", After: &after}); err == nil {
+			t.Error("expected error passing an after date to a manual wait")
+		}
+		if err := ConvertWaitType(s, "TS-02W", ConvertOptions{Type: model.ResolutionTypeManual, Question: "Arrived?", CheckAfter: &checkAfter}); err != nil {
+			t.Fatalf("ConvertWaitType failed: %v", err)
+		}
+		// A titled wait doesn't need a question
+		if err := ConvertWaitType(s, "TS-03W", ConvertOptions{Type: model.ResolutionTypeManual}); err != nil {
+			t.Fatalf("ConvertWaitType failed: %v", err)
+		}
+
+		pf, _ := s.LoadProject("TS")
+		rc := findWait(pf, "TS-02W").ResolutionCriteria
+		if rc.Type != model.ResolutionTypeManual || rc.Question != "Arrived?" || rc.CheckAfter == nil {
+			t.Errorf("expected a manual wait with question and check-after, got %+v", rc)
+		}
+		if rc.After != nil {
+			t.Errorf("expected after date cleared, got %v", rc.After)
+		}
+		if w := findWait(pf, "TS-03W"); w.ResolutionCriteria.Type != model.ResolutionTypeManual || w.DisplayText() != "Cure concrete" {
+			t.Errorf("expected TS-03W manual and titled, got %+v", w.ResolutionCriteria)
+		}
+	})
+
+	t.Run("closed waits are rejected", func(t *testing.T) {
+		if err := ResolveWait(s, "TS-01W", ""); err != nil {
+			t.Fatalf("ResolveWait failed: %v", err)
+		}
+		if err := ConvertWaitType(s, "TS-01W", ConvertOptions{Type: model.ResolutionTypeManual, Question: "Done?"}); err == nil {
+			t.Error("expected error converting a resolved wait")
+		}
+	})
+}
+
+// TestResolveWaitChain tests resolving a wait with its upstream waits.
+func TestResolveWaitChain(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	future := time.Now().Add(48 * time.Hour)
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Permit filed?"})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeTime, After: &future, Title: "Review period", BlockedBy: []string{"TS-01W"}})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Permit issued?", BlockedBy: []string{"TS-02W", "TS-01W"}})
+
+	resolved, err := ResolveWaitChain(s, "TS-03W", "approved")
+	if err != nil {
+		t.Fatalf("ResolveWaitChain failed: %v", err)
+	}
+	if got := strings.Join(resolved, ","); got != "TS-01W,TS-02W,TS-03W" {
+		t.Errorf("expected TS-01W,TS-02W,TS-03W leaves first, got %s", got)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	for _, id := range resolved {
+		w := findWait(pf, id)
+		if w.Status != model.WaitStatusDone || w.Resolution != "approved" {
+			t.Errorf("expected %s resolved as approved, got %s/%q", id, w.Status, w.Resolution)
+		}
+	}
+	if findWait(pf, "TS-02W").ResolutionCriteria.After.After(time.Now()) {
+		t.Error("expected the time wait's date moved up to now")
+	}
+}
+
+// TestResolveWaitChainTaskBlocker tests that a chain with an open task in it
+// is left untouched.
+func TestResolveWaitChainTaskBlocker(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Submit forms", TaskOptions{})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Filed?", BlockedBy: []string{"TS-01"}})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Issued?", BlockedBy: []string{"TS-02W"}})
+
+	_, err := ResolveWaitChain(s, "TS-03W", "")
+	if err == nil || !strings.Contains(err.Error(), "TS-01") {
+		t.Fatalf("expected error naming TS-01, got %v", err)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	for _, w := range pf.Waits {
+		if w.Status != model.WaitStatusOpen {
+			t.Errorf("expected %s left open, got %s", w.ID, w.Status)
+		}
+	}
+}
+
+// TestReopenWait tests reopening resolved and dropped waits.
+func TestReopenWait(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Arrived?"})
+	AddTask(s, "TS", "Unpack", TaskOptions{BlockedBy: []string{"TS-01W"}})
+	if err := ResolveWait(s, "TS-01W", "yes"); err != nil {
+		t.Fatalf("ResolveWait failed: %v", err)
+	}
+	CompleteTask(s, "TS-02", CompleteOptions{})
+
+	result, err := ReopenWait(s, "TS-01W")
+	if err != nil {
+		t.Fatalf("ReopenWait failed: %v", err)
+	}
+	if strings.Join(result.InconsistentDependents, ",") != "TS-02" {
+		t.Errorf("expected TS-02 reported as inconsistent, got %v", result.InconsistentDependents)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	wait := findWait(pf, "TS-01W")
+	if wait.Status != model.WaitStatusOpen || wait.DoneAt != nil || wait.Resolution != "" {
+		t.Errorf("expected open wait with no resolution, got %s/%v/%q", wait.Status, wait.DoneAt, wait.Resolution)
+	}
+
+	if _, err := ReopenWait(s, "TS-01W"); err == nil {
+		t.Error("expected error reopening an open wait")
+	}
+}
+
+// TestUncheckWait tests reverting a time wait resolved by tk check.
+func TestUncheckWait(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	past := time.Now().Add(-time.Hour)
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeTime, After: &past, Title: "Delivery"})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Arrived?"})
+
+	until := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+	if _, err := UncheckWait(s, "TS-01W", until); err == nil {
+		t.Error("expected error unchecking an open wait")
+	}
+
+	if _, err := RunCheck(s); err != nil {
+		t.Fatalf("RunCheck failed: %v", err)
+	}
+	if _, err := UncheckWait(s, "TS-01W", past); err == nil {
+		t.Error("expected error for a date that has passed")
+	}
+	if _, err := UncheckWait(s, "TS-01W", until); err != nil {
+		t.Fatalf("UncheckWait failed: %v", err)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	wait := findWait(pf, "TS-01W")
+	if wait.Status != model.WaitStatusOpen || wait.DoneAt != nil {
+		t.Errorf("expected wait reopened, got %s", wait.Status)
+	}
+	if !wait.ResolutionCriteria.After.Equal(until) {
+		t.Errorf("expected after %v, got %v", until, wait.ResolutionCriteria.After)
+	}
+
+	// A later check leaves it alone until the new date
+	result, _ := RunCheck(s)
+	if len(result.ResolvedWaits) != 0 {
+		t.Errorf("expected nothing resolved, got %v", result.ResolvedWaits)
+	}
+
+	ResolveWait(s, "TS-02W", "yes")
+	if _, err := UncheckWait(s, "TS-02W", until); err == nil {
+		t.Error("expected error unchecking a manual wait")
+	}
+}
+
 // TestRunCheck tests auto-resolution of time waits.
 func TestRunCheck(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -633,6 +1934,72 @@ func TestRunCheck(t *testing.T) {
 	}
 }
 
+// TestRunCheckIgnoredProject tests that check skips ignored projects unless
+// the project is checked explicitly.
+func TestRunCheckIgnoredProject(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	past := time.Now().Add(-1 * time.Hour)
+	AddWait(s, "TS", WaitOptions{
+		Type:  model.ResolutionTypeTime,
+		After: &past,
+		Title: "Past wait",
+	})
+
+	if err := os.WriteFile(s.ConfigPath(), []byte("ignored_projects: [TS]\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	result, err := RunCheck(s)
+	if err != nil {
+		t.Fatalf("RunCheck failed: %v", err)
+	}
+	if len(result.ResolvedWaits) != 0 {
+		t.Errorf("expected ignored project to be skipped, got %v", result.ResolvedWaits)
+	}
+
+	result, err = RunCheckProject(s, "TS")
+	if err != nil {
+		t.Fatalf("RunCheckProject failed: %v", err)
+	}
+	if len(result.ResolvedWaits) != 1 {
+		t.Errorf("expected 1 resolved wait, got %v", result.ResolvedWaits)
+	}
+}
+
+// TestRunCheckNowReady tests that check separates newly ready tasks from
+// merely unblocked ones.
+func TestRunCheckNowReady(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	past := time.Now().Add(-1 * time.Hour)
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeTime, After: &past, Title: "Past wait"})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Still open?"})
+	AddTask(s, "TS", "Open blocker", TaskOptions{})
+	// Only blocked by the past wait: becomes ready
+	AddTask(s, "TS", "Now ready", TaskOptions{BlockedBy: []string{"TS-01W"}})
+	// Also blocked by an open manual wait: still waiting
+	AddTask(s, "TS", "Still waiting", TaskOptions{BlockedBy: []string{"TS-01W", "TS-02W"}})
+	// Also blocked by an open task: still blocked
+	AddTask(s, "TS", "Still blocked", TaskOptions{BlockedBy: []string{"TS-01W", "TS-03"}})
+
+	result, err := RunCheck(s)
+	if err != nil {
+		t.Fatalf("RunCheck failed: %v", err)
+	}
+
+	if len(result.NowReady) != 1 || result.NowReady[0] != "TS-04" {
+		t.Errorf("expected NowReady [TS-04], got %v", result.NowReady)
+	}
+	for _, id := range result.NowReady {
+		if id == "TS-03" {
+			t.Error("already-ready task should not be reported as now ready")
+		}
+	}
+}
+
 // TestRunCheckCascade tests cascading effects of check.
 func TestRunCheckCascade(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -649,19 +2016,695 @@ func TestRunCheckCascade(t *testing.T) {
 		AutoComplete: true,
 	})
 
-	result, err := RunCheck(s)
-	if err != nil {
-		t.Fatalf("RunCheck failed: %v", err)
+	result, err := RunCheck(s)
+	if err != nil {
+		t.Fatalf("RunCheck failed: %v", err)
+	}
+
+	if len(result.AutoCompleted) != 1 {
+		t.Errorf("expected 1 auto-completed task, got %d", len(result.AutoCompleted))
+	}
+
+	pf, _ := s.LoadProject("TS")
+	task := findTask(pf, "TS-02")
+	if task.Status != model.TaskStatusDone {
+		t.Error("task should be auto-completed")
+	}
+}
+
+// TestListTasksAgenda tests the agenda filter and RemindBefore lead time.
+func TestListTasksAgenda(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	day := func(offset int) *time.Time {
+		d, _ := time.Parse("2006-01-02", time.Now().AddDate(0, 0, offset).Format("2006-01-02"))
+		return &d
+	}
+	AddTask(s, "TS", "Overdue", TaskOptions{DueDate: day(-1)})
+	AddTask(s, "TS", "Due today", TaskOptions{DueDate: day(0)})
+	AddTask(s, "TS", "In window", TaskOptions{DueDate: day(2), RemindBefore: 3})
+	AddTask(s, "TS", "Not yet", TaskOptions{DueDate: day(10), RemindBefore: 3})
+	AddTask(s, "TS", "No lead time", TaskOptions{DueDate: day(1)})
+	AddTask(s, "TS", "No due date", TaskOptions{RemindBefore: 3})
+
+	results, err := ListTasks(s, TaskFilter{Agenda: true})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.Task.ID)
+	}
+	if strings.Join(ids, ",") != "TS-01,TS-02,TS-03" {
+		t.Errorf("expected TS-01,TS-02,TS-03 on agenda, got %v", ids)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	if findTask(pf, "TS-03").RemindBefore != 3 {
+		t.Errorf("expected remind_before 3 after reload, got %d", findTask(pf, "TS-03").RemindBefore)
+	}
+
+	if _, err := AddTask(s, "TS", "Negative", TaskOptions{RemindBefore: -1}); err == nil {
+		t.Error("expected error for negative remind_before")
+	}
+}
+
+// TestListTasksAssignee tests filtering by assignee and for unassigned tasks.
+func TestListTasksAssignee(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Mow lawn", TaskOptions{Assignee: "Alice"})
+	AddTask(s, "TS", "Rake leaves", TaskOptions{Assignee: "bob"})
+	AddTask(s, "TS", "Weed beds", TaskOptions{})
+	AddTask(s, "TS", "Edge lawn", TaskOptions{Assignee: "alice"})
+
+	ids := func(f TaskFilter) string {
+		results, err := ListTasks(s, f)
+		if err != nil {
+			t.Fatalf("ListTasks failed: %v", err)
+		}
+		var got []string
+		for _, r := range results {
+			got = append(got, r.Task.ID)
+		}
+		return strings.Join(got, ",")
+	}
+
+	if got := ids(TaskFilter{Assignee: "ALICE"}); got != "TS-01,TS-04" {
+		t.Errorf("expected TS-01,TS-04 for alice, got %s", got)
+	}
+	if got := ids(TaskFilter{Unassigned: true}); got != "TS-03" {
+		t.Errorf("expected TS-03 unassigned, got %s", got)
+	}
+	if got := ids(TaskFilter{}); got != "TS-01,TS-02,TS-03,TS-04" {
+		t.Errorf("expected all tasks with no assignee filter, got %s", got)
+	}
+}
+
+// TestListTasksDueWithin tests the due-soon window, which includes overdue
+// tasks and the whole day the window ends on.
+func TestListTasksDueWithin(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	due := func(days int) *time.Time {
+		d := time.Now().AddDate(0, 0, days)
+		return &d
+	}
+	AddTask(s, "TS", "Overdue", TaskOptions{DueDate: due(-3)})
+	AddTask(s, "TS", "Due today", TaskOptions{DueDate: due(0)})
+	AddTask(s, "TS", "Due in a week", TaskOptions{DueDate: due(7)})
+	AddTask(s, "TS", "Due in a month", TaskOptions{DueDate: due(30)})
+	AddTask(s, "TS", "No due date", TaskOptions{})
+
+	window := 7 * 24 * time.Hour
+	results, err := ListTasks(s, TaskFilter{DueWithin: &window})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.Task.ID)
+	}
+	if got := strings.Join(ids, ","); got != "TS-01,TS-02,TS-03" {
+		t.Errorf("expected TS-01,TS-02,TS-03 due within a week, got %s", got)
+	}
+}
+
+// TestListTasksOverdueTimezone tests that a due date becomes overdue the day
+// after it, in the configured timezone, whatever the machine's zone.
+func TestListTasksOverdueTimezone(t *testing.T) {
+	defer model.SetTimezone("")
+
+	for _, zone := range []string{"Pacific/Kiritimati", "Pacific/Pago_Pago"} {
+		t.Run(zone, func(t *testing.T) {
+			s, cleanup := setupTestStorage(t)
+			defer cleanup()
+			if err := model.SetTimezone(zone); err != nil {
+				t.Fatal(err)
+			}
+
+			// Due dates as they load from a project file: midnight UTC
+			today, _ := time.Parse("2006-01-02", model.Day(time.Now()))
+			yesterday := today.AddDate(0, 0, -1)
+			AddTask(s, "TS", "Due today", TaskOptions{DueDate: &today})
+			AddTask(s, "TS", "Due yesterday", TaskOptions{DueDate: &yesterday})
+
+			results, err := ListTasks(s, TaskFilter{Overdue: true})
+			if err != nil {
+				t.Fatalf("ListTasks failed: %v", err)
+			}
+			if len(results) != 1 || results[0].Task.ID != "TS-02" {
+				t.Errorf("expected only TS-02 overdue in %s, got %v", zone, results)
+			}
+		})
+	}
+}
+
+// TestListTasksReadySoon tests widening the ready filter to tasks whose only
+// open blockers are waits due within the lookahead window.
+func TestListTasksReadySoon(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	soon := time.Now().Add(24 * time.Hour)
+	later := time.Now().Add(30 * 24 * time.Hour)
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeTime, After: &soon})                            // TS-01W
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeTime, After: &later})                           // TS-02W
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Reply?", CheckAfter: &soon}) // TS-03W
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Arrived?"})                  // TS-04W
+	AddTask(s, "TS", "Soon", TaskOptions{BlockedBy: []string{"TS-01W"}})                                   // TS-05
+	AddTask(s, "TS", "Later", TaskOptions{BlockedBy: []string{"TS-02W"}})                                  // TS-06
+	AddTask(s, "TS", "Check soon", TaskOptions{BlockedBy: []string{"TS-03W"}})                             // TS-07
+	AddTask(s, "TS", "No date", TaskOptions{BlockedBy: []string{"TS-04W"}})                                // TS-08
+	AddTask(s, "TS", "Mixed", TaskOptions{BlockedBy: []string{"TS-01W", "TS-02W"}})                        // TS-09
+	AddTask(s, "TS", "Ready", TaskOptions{})                                                               // TS-10
+
+	ready := model.TaskStateReady
+	results, err := ListTasks(s, TaskFilter{State: &ready, ReadySoon: 3 * 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.Task.ID)
+		if r.Task.ID == "TS-05" && r.State != model.TaskStateWaiting {
+			t.Errorf("expected TS-05 to keep waiting state, got %s", r.State)
+		}
+	}
+	if got := strings.Join(ids, ","); got != "TS-05,TS-07,TS-10" {
+		t.Errorf("expected TS-05,TS-07,TS-10, got %s", got)
+	}
+
+	results, _ = ListTasks(s, TaskFilter{State: &ready})
+	if len(results) != 1 {
+		t.Errorf("expected only TS-10 without a window, got %d tasks", len(results))
+	}
+}
+
+// TestListTasksExclude tests that excluded states combine with each other.
+func TestListTasksExclude(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Arrived?"}) // TS-01W
+	AddTask(s, "TS", "Ready", TaskOptions{})                                              // TS-02
+	AddTask(s, "TS", "Blocked", TaskOptions{BlockedBy: []string{"TS-02"}})                // TS-03
+	AddTask(s, "TS", "Waiting", TaskOptions{BlockedBy: []string{"TS-01W"}})               // TS-04
+
+	ids := func(f TaskFilter) string {
+		results, err := ListTasks(s, f)
+		if err != nil {
+			t.Fatalf("ListTasks failed: %v", err)
+		}
+		var ids []string
+		for _, r := range results {
+			ids = append(ids, r.Task.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	if got := ids(TaskFilter{Exclude: []model.TaskState{model.TaskStateWaiting}}); got != "TS-02,TS-03" {
+		t.Errorf("expected TS-02,TS-03, got %s", got)
+	}
+	both := []model.TaskState{model.TaskStateWaiting, model.TaskStateBlocked}
+	if got := ids(TaskFilter{Exclude: both}); got != "TS-02" {
+		t.Errorf("expected TS-02, got %s", got)
+	}
+}
+
+// TestListTasksCreatedRange tests filtering tasks by creation time.
+func TestListTasksCreatedRange(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Old", TaskOptions{})
+	AddTask(s, "TS", "Boundary", TaskOptions{})
+	AddTask(s, "TS", "Recent", TaskOptions{})
+
+	pf, _ := s.LoadProject("TS")
+	findTask(pf, "TS-01").Created = time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	findTask(pf, "TS-02").Created = time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	findTask(pf, "TS-03").Created = time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	s.SaveProject(pf)
+
+	ids := func(filter TaskFilter) string {
+		results, err := ListTasks(s, filter)
+		if err != nil {
+			t.Fatalf("ListTasks failed: %v", err)
+		}
+		var out []string
+		for _, r := range results {
+			out = append(out, r.Task.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	after := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+
+	if got := ids(TaskFilter{CreatedAfter: &after}); got != "TS-02,TS-03" {
+		t.Errorf("created after: expected TS-02,TS-03, got %s", got)
+	}
+	if got := ids(TaskFilter{CreatedBefore: &before}); got != "TS-01,TS-02" {
+		t.Errorf("created before: expected TS-01,TS-02, got %s", got)
+	}
+	if got := ids(TaskFilter{CreatedAfter: &after, CreatedBefore: &before}); got != "TS-02" {
+		t.Errorf("created range: expected TS-02, got %s", got)
+	}
+}
+
+// TestDependencyProgress tests progress over a transitive blocker tree.
+func TestDependencyProgress(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Foundation", TaskOptions{})
+	AddTask(s, "TS", "Framing", TaskOptions{BlockedBy: []string{"TS-01"}})
+	AddTask(s, "TS", "Permit", TaskOptions{})
+	AddTask(s, "TS", "Milestone", TaskOptions{BlockedBy: []string{"TS-02", "TS-03"}})
+	if _, err := CompleteTask(s, "TS-01", CompleteOptions{}); err != nil {
+		t.Fatalf("CompleteTask failed: %v", err)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	summary := DependencyProgress(pf, "TS-04", true)
+	if summary.Total != 3 || summary.Resolved != 1 {
+		t.Errorf("expected 1/3 resolved, got %d/%d", summary.Resolved, summary.Total)
+	}
+	if strings.Join(summary.Unresolved, ",") != "TS-02,TS-03" {
+		t.Errorf("expected TS-02,TS-03 unresolved, got %v", summary.Unresolved)
+	}
+
+	if summary := DependencyProgress(pf, "TS-03", true); summary.Total != 0 {
+		t.Errorf("expected no dependencies for TS-03, got %d", summary.Total)
+	}
+}
+
+// TestFindDuplicateTasks tests grouping of near-identical task titles.
+func TestFindDuplicateTasks(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	CreateProject(s, "other", "OT", "Other", "")
+	AddTask(s, "TS", "Renew passport", TaskOptions{})
+	AddTask(s, "TS", "Call the plumber", TaskOptions{})
+	AddTask(s, "TS", "renew  passport!", TaskOptions{})
+	AddTask(s, "TS", "Renew pasport", TaskOptions{})
+	AddTask(s, "TS", "Call the dentist", TaskOptions{})
+	AddTask(s, "OT", "Renew passport", TaskOptions{})
+
+	groups, err := FindDuplicateTasks(s, "")
+	if err != nil {
+		t.Fatalf("FindDuplicateTasks failed: %v", err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 duplicate group, got %d", len(groups))
+	}
+	g := groups[0]
+	if g.Project != "TS" {
+		t.Errorf("expected group in TS, got %s", g.Project)
+	}
+	var ids []string
+	for _, r := range g.Tasks {
+		ids = append(ids, r.Task.ID)
+	}
+	if strings.Join(ids, ",") != "TS-01,TS-03,TS-04" {
+		t.Errorf("expected TS-01,TS-03,TS-04, got %v", ids)
+	}
+}
+
+// TestFindStaleTasks tests finding open tasks that haven't changed in a long time.
+func TestFindStaleTasks(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Old", TaskOptions{})
+	AddTask(s, "TS", "Older", TaskOptions{})
+	AddTask(s, "TS", "Fresh", TaskOptions{})
+	AddTask(s, "TS", "Old but done", TaskOptions{})
+	AddTask(s, "TS", "Old but snoozed", TaskOptions{})
+	CompleteTask(s, "TS-04", CompleteOptions{})
+	SnoozeTask(s, "TS-05", time.Now().AddDate(0, 1, 0))
+
+	now := time.Now()
+	pf, _ := s.LoadProject("TS")
+	for id, days := range map[string]int{"TS-01": 100, "TS-02": 200, "TS-04": 300, "TS-05": 300} {
+		findTask(pf, id).Updated = now.AddDate(0, 0, -days)
+	}
+	s.SaveProject(pf)
+
+	stale, err := FindStaleTasks(s, "TS", now)
+	if err != nil {
+		t.Fatalf("FindStaleTasks failed: %v", err)
+	}
+	var ids []string
+	for _, r := range stale {
+		ids = append(ids, r.Task.ID)
+	}
+	if got := strings.Join(ids, ","); got != "TS-02,TS-01" {
+		t.Errorf("expected TS-02,TS-01, got %s", got)
+	}
+}
+
+// TestTagsStoredLowercase tests that every tag-writing path stores the same
+// lowercase form.
+func TestTagsStoredLowercase(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Via add", TaskOptions{Tags: []string{"Urgent", "urgent"}})
+	AddTask(s, "TS", "Via tag", TaskOptions{})
+	AddTask(s, "TS", "Via edit", TaskOptions{})
+
+	if added, err := AddTag(s, "TS-02", "URGENT"); err != nil || !added {
+		t.Fatalf("AddTag failed: added=%v err=%v", added, err)
+	}
+	if added, _ := AddTag(s, "TS-02", "Urgent"); added {
+		t.Error("expected AddTag to treat differently-cased tag as already present")
+	}
+	tags := []string{"UrGent", "urgent"}
+	if err := EditTask(s, "TS-03", TaskChanges{Tags: &tags}); err != nil {
+		t.Fatalf("EditTask failed: %v", err)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	for _, id := range []string{"TS-01", "TS-02", "TS-03"} {
+		got := findTask(pf, id).Tags
+		if len(got) != 1 || got[0] != "urgent" {
+			t.Errorf("%s: expected tags [urgent], got %v", id, got)
+		}
+	}
+}
+
+// TestRemoveTagsWithPrefix tests removing all tags sharing a prefix.
+func TestRemoveTagsWithPrefix(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Sprinting", TaskOptions{Tags: []string{"sprint-01", "home", "sprint-02"}})
+
+	removed, err := RemoveTagsWithPrefix(s, "TS-01", "Sprint-")
+	if err != nil {
+		t.Fatalf("RemoveTagsWithPrefix failed: %v", err)
+	}
+	if len(removed) != 2 {
+		t.Errorf("expected 2 removed tags, got %v", removed)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	if got := findTask(pf, "TS-01").Tags; len(got) != 1 || got[0] != "home" {
+		t.Errorf("expected tags [home], got %v", got)
+	}
+
+	removed, err = RemoveTagsWithPrefix(s, "TS-01", "sprint-")
+	if err != nil || removed != nil {
+		t.Errorf("expected nothing removed, got %v, %v", removed, err)
+	}
+}
+
+// TestValidateTagCase tests detection and repair of non-lowercase tags
+// written before tags were normalized.
+func TestValidateTagCase(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Legacy", TaskOptions{})
+	pf, _ := s.LoadProject("TS")
+	pf.Tasks[0].Tags = []string{"Home", "home", "Errand"}
+	s.SaveProject(pf)
+
+	errs, err := Validate(s)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	count := 0
+	for _, e := range errs {
+		if e.Type == ValidationErrorTagCase {
+			count++
+		}
+	}
+	if count != 2 {
+		t.Errorf("expected 2 tag_case errors, got %d", count)
+	}
+
+	if _, err := ValidateAndFix(s); err != nil {
+		t.Fatalf("ValidateAndFix failed: %v", err)
+	}
+	pf, _ = s.LoadProject("TS")
+	if got := strings.Join(pf.Tasks[0].Tags, ","); got != "home,errand" {
+		t.Errorf("expected tags home,errand after fix, got %s", got)
+	}
+}
+
+// TestValidateNextID tests detection and repair of a next_id that a
+// hand-edit left at or below an existing item number.
+func TestValidateNextID(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "First", TaskOptions{})
+	AddTask(s, "TS", "Second", TaskOptions{})
+	pf, _ := s.LoadProject("TS")
+	pf.NextID = 2
+	s.SaveProject(pf)
+
+	errs, err := Validate(s)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	found := false
+	for _, e := range errs {
+		if e.Type == ValidationErrorNextID {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected next_id error, got %v", errs)
+	}
+
+	fixes, err := ValidateAndFix(s)
+	if err != nil {
+		t.Fatalf("ValidateAndFix failed: %v", err)
+	}
+	if len(fixes) != 1 || fixes[0].Type != ValidationErrorNextID {
+		t.Errorf("expected one next_id fix, got %v", fixes)
+	}
+	pf, _ = s.LoadProject("TS")
+	if pf.NextID != 3 {
+		t.Errorf("expected next_id 3 after fix, got %d", pf.NextID)
+	}
+}
+
+// TestAddTaskAfter tests blocking a new task on the last-created task.
+func TestAddTaskAfter(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	if _, err := AddTask(s, "TS", "Step 1", TaskOptions{After: true}); err == nil {
+		t.Error("expected error adding after in an empty project")
+	}
+
+	AddTask(s, "TS", "Step 1", TaskOptions{})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Approved?"})
+
+	step2, err := AddTask(s, "TS", "Step 2", TaskOptions{After: true})
+	if err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if len(step2.BlockedBy) != 1 || step2.BlockedBy[0] != "TS-01" {
+		t.Errorf("expected step 2 blocked by TS-01, got %v", step2.BlockedBy)
+	}
+
+	// Combines with explicit blockers without duplicating
+	step3, err := AddTask(s, "TS", "Step 3", TaskOptions{After: true, BlockedBy: []string{"TS-02W", "ts-03"}})
+	if err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if len(step3.BlockedBy) != 2 {
+		t.Errorf("expected 2 blockers, got %v", step3.BlockedBy)
+	}
+
+	// Completed tasks still count as the last-created task
+	if _, err := CompleteTask(s, "TS-04", CompleteOptions{Force: true}); err != nil {
+		t.Fatalf("CompleteTask failed: %v", err)
+	}
+	step4, err := AddTask(s, "TS", "Step 4", TaskOptions{After: true})
+	if err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if len(step4.BlockedBy) != 1 || step4.BlockedBy[0] != "TS-04" {
+		t.Errorf("expected step 4 blocked by TS-04, got %v", step4.BlockedBy)
+	}
+}
+
+// TestAutoCompleteManualWaits tests finding open manual waits that hold up
+// an auto-complete task.
+func TestAutoCompleteManualWaits(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	later := time.Now().Add(time.Hour)
+	AddTask(s, "TS", "Step", TaskOptions{})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Approved?"})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeTime, After: &later})
+	blockers := []string{"TS-01", "TS-02W", "TS-03W"}
+	AddTask(s, "TS", "Milestone", TaskOptions{AutoComplete: true, BlockedBy: blockers})
+	AddTask(s, "TS", "Plain", TaskOptions{BlockedBy: blockers})
+
+	waits, err := AutoCompleteManualWaits(s, "ts-04")
+	if err != nil {
+		t.Fatalf("AutoCompleteManualWaits failed: %v", err)
+	}
+	if len(waits) != 1 || waits[0] != "TS-02W" {
+		t.Errorf("expected [TS-02W], got %v", waits)
+	}
+
+	waits, _ = AutoCompleteManualWaits(s, "TS-05")
+	if len(waits) != 0 {
+		t.Errorf("expected no waits for a task without auto_complete, got %v", waits)
+	}
+
+	if err := ResolveWait(s, "TS-02W", "yes"); err != nil {
+		t.Fatalf("ResolveWait failed: %v", err)
+	}
+	waits, _ = AutoCompleteManualWaits(s, "TS-04")
+	if len(waits) != 0 {
+		t.Errorf("expected resolved waits to be ignored, got %v", waits)
+	}
+}
+
+// TestAddTaskNeverReusesID tests that adding items skips past numbers
+// already in use even when next_id is too low.
+func TestAddTaskNeverReusesID(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "First", TaskOptions{})
+	AddTask(s, "TS", "Second", TaskOptions{})
+	pf, _ := s.LoadProject("TS")
+	pf.NextID = 1
+	s.SaveProject(pf)
+
+	task, err := AddTask(s, "TS", "Third", TaskOptions{})
+	if err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if task.ID != "TS-03" {
+		t.Errorf("expected TS-03, got %s", task.ID)
+	}
+	wait, err := AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Done?"})
+	if err != nil {
+		t.Fatalf("AddWait failed: %v", err)
+	}
+	if wait.ID != "TS-04W" {
+		t.Errorf("expected TS-04W, got %s", wait.ID)
+	}
+}
+
+// TestValidateStaleTimeWait tests reporting of long-past time waits.
+func TestValidateStaleTimeWait(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	longAgo := time.Now().AddDate(0, 0, -30)
+	recent := time.Now().AddDate(0, 0, -2)
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeTime, After: &longAgo})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeTime, After: &recent})
+	AddTask(s, "TS", "Open blocker", TaskOptions{})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeTime, After: &longAgo, BlockedBy: []string{"TS-03"}})
+
+	errs, err := Validate(s)
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	var stale []string
+	for _, e := range errs {
+		if e.Type == ValidationErrorStaleWait {
+			stale = append(stale, e.ItemID)
+			if !strings.Contains(e.Message, "tk check") {
+				t.Errorf("expected message to suggest tk check, got %q", e.Message)
+			}
+		}
+	}
+	if strings.Join(stale, ",") != "TS-01W" {
+		t.Errorf("expected only TS-01W to be stale, got %v", stale)
+	}
+
+	// Running check resolves it
+	if _, err := RunCheck(s); err != nil {
+		t.Fatalf("RunCheck failed: %v", err)
+	}
+	errs, _ = Validate(s)
+	for _, e := range errs {
+		if e.Type == ValidationErrorStaleWait {
+			t.Errorf("unexpected stale wait after check: %s", e.ItemID)
+		}
+	}
+}
+
+// TestDoctor tests the store-wide health check and its repairs.
+func TestDoctor(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	pf, _ := s.LoadProject("TS")
+	pf.Tasks = append(pf.Tasks, model.Task{
+		ID:        "TS-01",
+		Title:     "Task with orphan",
+		Status:    model.TaskStatusOpen,
+		Priority:  3,
+		BlockedBy: []string{"TS-99"},
+		Created:   time.Now(),
+		Updated:   time.Now(),
+	})
+	pf.NextID = 2
+	s.SaveProject(pf)
+
+	longAgo := time.Now().AddDate(0, 0, -30)
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Called back?", CheckAfter: &longAgo})
+
+	config := "default_project: nowhere\nignored_projects: [ZZ, ts]\nhooks:\n  - event: task_drop\n    command: echo\n"
+	if err := os.WriteFile(s.ConfigPath(), []byte(config), 0644); err != nil {
+		t.Fatal(err)
 	}
 
-	if len(result.AutoCompleted) != 1 {
-		t.Errorf("expected 1 auto-completed task, got %d", len(result.AutoCompleted))
+	issueIDs := func(r *DoctorReport) string {
+		var ids []string
+		for _, e := range r.Issues {
+			ids = append(ids, fmt.Sprintf("%s/%s", e.Type, e.ItemID))
+		}
+		return strings.Join(ids, ",")
 	}
 
-	pf, _ := s.LoadProject("TS")
-	task := findTask(pf, "TS-02")
-	if task.Status != model.TaskStatusDone {
-		t.Error("task should be auto-completed")
+	report, err := Doctor(s, s, false)
+	if err != nil {
+		t.Fatalf("Doctor failed: %v", err)
+	}
+	if report.Projects != 1 || len(report.Fixes) != 0 {
+		t.Errorf("expected 1 project checked and no fixes, got %d and %v", report.Projects, report.Fixes)
+	}
+	want := "orphan_blocker/TS-01,config/default_project,config/ignored_projects,config/hooks,stale_wait/TS-02W"
+	if got := issueIDs(report); got != want {
+		t.Errorf("expected issues %s, got %s", want, got)
+	}
+
+	report, err = Doctor(s, s, true)
+	if err != nil {
+		t.Fatalf("Doctor with fix failed: %v", err)
+	}
+	if len(report.Fixes) != 2 {
+		t.Errorf("expected orphan and default_project fixes, got %v", report.Fixes)
+	}
+	want = "config/ignored_projects,config/hooks,stale_wait/TS-02W"
+	if got := issueIDs(report); got != want {
+		t.Errorf("expected remaining issues %s, got %s", want, got)
+	}
+	cfg, _ := s.LoadConfig()
+	if cfg.DefaultProject != "" {
+		t.Errorf("expected default_project cleared, got %q", cfg.DefaultProject)
 	}
 }
 
@@ -766,7 +2809,7 @@ func TestMoveTask(t *testing.T) {
 	AddTask(s, "TS", "Task to move", TaskOptions{Priority: 1})
 
 	// Move task
-	err := MoveTask(s, "TS-01", "OT")
+	err := MoveTask(s, "TS-01", "OT", false)
 	if err != nil {
 		t.Fatalf("MoveTask failed: %v", err)
 	}
@@ -789,6 +2832,72 @@ func TestMoveTask(t *testing.T) {
 	}
 }
 
+// TestMoveTaskKeepID tests moving a task while preserving its numeric ID.
+func TestMoveTaskKeepID(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	CreateProject(s, "other", "OT", "Other Project", "")
+	AddTask(s, "OT", "Existing", TaskOptions{})
+	AddTask(s, "TS", "First", TaskOptions{})
+	AddTask(s, "TS", "Second", TaskOptions{})
+	AddTask(s, "TS", "Third", TaskOptions{})
+
+	if err := MoveTask(s, "TS-03", "OT", true); err != nil {
+		t.Fatalf("MoveTask failed: %v", err)
+	}
+
+	otPf, _ := s.LoadProject("OT")
+	moved := findTask(otPf, "OT-03")
+	if moved == nil {
+		t.Fatal("expected task OT-03 in destination project")
+	}
+	if moved.Title != "Third" {
+		t.Errorf("expected title 'Third', got %q", moved.Title)
+	}
+	if otPf.NextID != 4 {
+		t.Errorf("expected next_id 4, got %d", otPf.NextID)
+	}
+
+	// Number 1 is already used in the destination
+	if err := MoveTask(s, "TS-01", "OT", true); err == nil {
+		t.Error("expected error when ID number is already in use")
+	}
+	tsPf, _ := s.LoadProject("TS")
+	if findTask(tsPf, "TS-01") == nil {
+		t.Error("task should remain in source project after failed move")
+	}
+}
+
+// TestMoveTaskBlockReasons tests that a moved task keeps the reasons for the
+// blockers it keeps.
+func TestMoveTaskBlockReasons(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	CreateProject(s, "other", "OT", "Other", "")
+	CreateProject(s, "home", "HM", "Home", "")
+	AddTask(s, "OT", "Existing", TaskOptions{})
+	AddTask(s, "TS", "Blocked", TaskOptions{})
+	if _, err := AddProjectBlocker(s, "TS-01", "HM", "after the move home"); err != nil {
+		t.Fatalf("AddProjectBlocker failed: %v", err)
+	}
+
+	if err := MoveTask(s, "TS-01", "OT", false); err != nil {
+		t.Fatalf("MoveTask failed: %v", err)
+	}
+
+	otPf, _ := s.LoadProject("OT")
+	moved := findTask(otPf, "OT-02")
+	if moved == nil {
+		t.Fatal("expected task OT-02 in destination project")
+	}
+	want := map[string]string{"@HM": "after the move home"}
+	if fmt.Sprint(moved.BlockReasons) != fmt.Sprint(want) {
+		t.Errorf("expected reasons %v, got %v", want, moved.BlockReasons)
+	}
+}
+
 // TestMoveTaskWithBlockers tests that tasks with internal blockers can't be moved.
 func TestMoveTaskWithBlockers(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -798,12 +2907,120 @@ func TestMoveTaskWithBlockers(t *testing.T) {
 	AddTask(s, "TS", "Blocker", TaskOptions{})
 	AddTask(s, "TS", "Blocked", TaskOptions{BlockedBy: []string{"TS-01"}})
 
-	err := MoveTask(s, "TS-02", "OT")
+	err := MoveTask(s, "TS-02", "OT", false)
 	if err == nil {
 		t.Error("expected error when moving task with blockers in source project")
 	}
 }
 
+// TestMergeTasks tests merging one task into another.
+func TestMergeTasks(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Blocker A", TaskOptions{})
+	AddTask(s, "TS", "Blocker B", TaskOptions{})
+	AddTask(s, "TS", "Keep", TaskOptions{Tags: []string{"home"}, Notes: "first", BlockedBy: []string{"TS-01"}})
+	AddTask(s, "TS", "Duplicate", TaskOptions{Tags: []string{"Home", "errand"}, Notes: "second", BlockedBy: []string{"TS-02"}})
+	AddTask(s, "TS", "Dependent", TaskOptions{BlockedBy: []string{"TS-04"}})
+
+	if err := MergeTasks(s, "TS-03", "TS-04"); err != nil {
+		t.Fatalf("MergeTasks failed: %v", err)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	target := findTask(pf, "TS-03")
+	if len(target.Tags) != 2 || target.Tags[0] != "home" || target.Tags[1] != "errand" {
+		t.Errorf("expected tags [home errand], got %v", target.Tags)
+	}
+	if target.Notes != "first\n\nsecond" {
+		t.Errorf("expected appended notes, got %q", target.Notes)
+	}
+	if len(target.BlockedBy) != 2 || target.BlockedBy[0] != "TS-01" || target.BlockedBy[1] != "TS-02" {
+		t.Errorf("expected blockers [TS-01 TS-02], got %v", target.BlockedBy)
+	}
+
+	dependent := findTask(pf, "TS-05")
+	if len(dependent.BlockedBy) != 1 || dependent.BlockedBy[0] != "TS-03" {
+		t.Errorf("expected dependent blocked by TS-03, got %v", dependent.BlockedBy)
+	}
+
+	source := findTask(pf, "TS-04")
+	if source.Status != model.TaskStatusDropped {
+		t.Errorf("expected source dropped, got %s", source.Status)
+	}
+	if source.DropReason != "merged into TS-03" {
+		t.Errorf("expected drop reason 'merged into TS-03', got %q", source.DropReason)
+	}
+}
+
+// TestMergeTasksBlockReasons tests that blocker reasons follow the blockers
+// they describe when tasks are merged.
+func TestMergeTasksBlockReasons(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Blocker A", TaskOptions{})
+	AddTask(s, "TS", "Blocker B", TaskOptions{})
+	AddTask(s, "TS", "Keep", TaskOptions{})
+	AddTask(s, "TS", "Duplicate", TaskOptions{})
+	AddTask(s, "TS", "Dependent", TaskOptions{})
+	AddTask(s, "TS", "Blocked by both", TaskOptions{})
+	AddBlocker(s, "TS-03", "TS-01", "needs A")
+	AddBlocker(s, "TS-04", "TS-01", "also needs A")
+	AddBlocker(s, "TS-04", "TS-02", "needs B")
+	AddBlocker(s, "TS-04", "TS-03", "after keep")
+	AddBlocker(s, "TS-05", "TS-04", "needs the duplicate")
+	AddBlocker(s, "TS-06", "TS-03", "needs keep")
+	AddBlocker(s, "TS-06", "TS-04", "needs the duplicate")
+
+	if err := MergeTasks(s, "TS-03", "TS-04"); err != nil {
+		t.Fatalf("MergeTasks failed: %v", err)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	target := findTask(pf, "TS-03")
+	want := map[string]string{"TS-01": "needs A", "TS-02": "needs B"}
+	if fmt.Sprint(target.BlockReasons) != fmt.Sprint(want) {
+		t.Errorf("expected target reasons %v, got %v", want, target.BlockReasons)
+	}
+
+	dependent := findTask(pf, "TS-05")
+	want = map[string]string{"TS-03": "needs the duplicate"}
+	if fmt.Sprint(dependent.BlockReasons) != fmt.Sprint(want) {
+		t.Errorf("expected dependent reasons %v, got %v", want, dependent.BlockReasons)
+	}
+
+	// The reason already given for the target wins
+	both := findTask(pf, "TS-06")
+	want = map[string]string{"TS-03": "needs keep"}
+	if fmt.Sprint(both.BlockReasons) != fmt.Sprint(want) {
+		t.Errorf("expected reasons %v, got %v", want, both.BlockReasons)
+	}
+}
+
+// TestMergeTasksCycle tests that a merge creating a cycle is rejected.
+func TestMergeTasksCycle(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Target", TaskOptions{})
+	AddTask(s, "TS", "Middle", TaskOptions{BlockedBy: []string{"TS-01"}})
+	AddTask(s, "TS", "Source", TaskOptions{BlockedBy: []string{"TS-02"}})
+
+	if err := MergeTasks(s, "TS-01", "TS-03"); err == nil {
+		t.Fatal("expected error for merge that creates a cycle")
+	}
+
+	pf, _ := s.LoadProject("TS")
+	if findTask(pf, "TS-03").Status != model.TaskStatusOpen {
+		t.Error("source task should remain open after failed merge")
+	}
+	if err := MergeTasks(s, "TS-01", "TS-01"); err == nil {
+		t.Error("expected error when merging a task into itself")
+	}
+}
+
 // TestRemoveBlocker tests removing blockers.
 func TestRemoveBlocker(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -843,7 +3060,7 @@ func TestIntegration(t *testing.T) {
 		Type:     model.ResolutionTypeManual,
 		Question: "Has code review been approved?",
 	})
-	AddBlocker(s, "WK-04", "WK-05W")
+	AddBlocker(s, "WK-04", "WK-05W", "")
 
 	// Validate
 	errors, _ := Validate(s)
@@ -852,16 +3069,16 @@ func TestIntegration(t *testing.T) {
 	}
 
 	// Complete first task
-	result, _ := CompleteTask(s, "WK-01", false)
+	result, _ := CompleteTask(s, "WK-01", CompleteOptions{})
 	if len(result.Unblocked) != 1 || result.Unblocked[0] != "WK-02" {
 		t.Errorf("expected WK-02 unblocked, got %v", result.Unblocked)
 	}
 
 	// Complete second task
-	CompleteTask(s, "WK-02", false)
+	CompleteTask(s, "WK-02", CompleteOptions{})
 
 	// Complete third task
-	CompleteTask(s, "WK-03", false)
+	CompleteTask(s, "WK-03", CompleteOptions{})
 
 	// WK-04 still blocked by wait
 	pf, _ := s.LoadProject("WK")
@@ -874,7 +3091,7 @@ func TestIntegration(t *testing.T) {
 	ResolveWait(s, "WK-05W", "Approved!")
 
 	// Complete WK-04 (should trigger auto-complete)
-	result, _ = CompleteTask(s, "WK-04", false)
+	result, _ = CompleteTask(s, "WK-04", CompleteOptions{})
 	// Note: WK-04 already completed above, so this is just for demonstration
 	// In a real scenario, the auto-complete would have been triggered
 
@@ -1009,6 +3226,38 @@ func TestEditWaitBlockedBy(t *testing.T) {
 	}
 }
 
+// TestEditBlockersSorted tests that edited blocker lists are stored in ID order.
+func TestEditBlockersSorted(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	for i := 0; i < 10; i++ {
+		AddTask(s, "TS", fmt.Sprintf("Task %d", i+1), TaskOptions{})
+	}
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Ready?"})
+
+	blockers := []string{"TS-09", "TS-02", "TS-11W", "TS-05"}
+	if err := EditTask(s, "TS-10", TaskChanges{BlockedBy: &blockers}); err != nil {
+		t.Fatalf("EditTask failed: %v", err)
+	}
+	waitBlockers := []string{"TS-10", "TS-03"}
+	if err := EditWait(s, "TS-11W", WaitChanges{BlockedBy: &waitBlockers}); err == nil {
+		t.Fatal("expected cycle error for TS-11W blocked by TS-10")
+	}
+	waitBlockers = []string{"TS-08", "TS-03"}
+	if err := EditWait(s, "TS-11W", WaitChanges{BlockedBy: &waitBlockers}); err != nil {
+		t.Fatalf("EditWait failed: %v", err)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	if got := strings.Join(findTask(pf, "TS-10").BlockedBy, ","); got != "TS-02,TS-05,TS-09,TS-11W" {
+		t.Errorf("expected task blockers TS-02,TS-05,TS-09,TS-11W, got %s", got)
+	}
+	if got := strings.Join(findWait(pf, "TS-11W").BlockedBy, ","); got != "TS-03,TS-08" {
+		t.Errorf("expected wait blockers TS-03,TS-08, got %s", got)
+	}
+}
+
 // TestEditWaitCycleDetection tests that editing wait blockers detects cycles.
 func TestEditWaitCycleDetection(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -1021,7 +3270,7 @@ func TestEditWaitCycleDetection(t *testing.T) {
 		Question: "Test?",
 	})
 	// Make task blocked by wait
-	AddBlocker(s, "TS-01", "TS-02W")
+	AddBlocker(s, "TS-01", "TS-02W", "")
 
 	// Try to make wait blocked by task (would create cycle)
 	blockers := []string{"TS-01"}
@@ -1108,7 +3357,7 @@ func TestAddWaitBlockerCycle(t *testing.T) {
 		Type:     model.ResolutionTypeManual,
 		Question: "Test?",
 	})
-	AddBlocker(s, "TS-01", "TS-02W")
+	AddBlocker(s, "TS-01", "TS-02W", "")
 
 	// Try to create cycle
 	err := AddWaitBlocker(s, "TS-02W", "TS-01")
@@ -1180,6 +3429,205 @@ func TestRemoveWaitBlocker(t *testing.T) {
 	}
 }
 
+// TestGetBlockersAndBlockingWaits tests both directions of the blocker
+// relationship for wait IDs as well as task IDs.
+func TestGetBlockersAndBlockingWaits(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Blocker task", TaskOptions{})
+	AddWait(s, "TS", WaitOptions{
+		Type:      model.ResolutionTypeManual,
+		Question:  "Approved?",
+		BlockedBy: []string{"TS-01"},
+	})
+	AddTask(s, "TS", "Second", TaskOptions{BlockedBy: []string{"TS-02W"}})
+	AddTask(s, "TS", "Third", TaskOptions{BlockedBy: []string{"TS-02W", "TS-01"}})
+
+	blockers, err := GetBlockers(s, "TS-02W")
+	if err != nil {
+		t.Fatalf("GetBlockers failed: %v", err)
+	}
+	if len(blockers) != 1 || blockers[0] != "TS-01" {
+		t.Errorf("expected wait blockers [TS-01], got %v", blockers)
+	}
+
+	// IDs are matched case-insensitively
+	blocking, err := GetBlocking(s, "ts-02w")
+	if err != nil {
+		t.Fatalf("GetBlocking failed: %v", err)
+	}
+	if strings.Join(blocking, ",") != "TS-03,TS-04" {
+		t.Errorf("expected wait to block [TS-03 TS-04], got %v", blocking)
+	}
+
+	blocking, err = GetBlocking(s, "TS-01")
+	if err != nil {
+		t.Fatalf("GetBlocking failed: %v", err)
+	}
+	if strings.Join(blocking, ",") != "TS-02W,TS-04" {
+		t.Errorf("expected task to block [TS-02W TS-04], got %v", blocking)
+	}
+
+	var notFound *NotFoundError
+	for _, id := range []string{"TS-99W", "TS-99"} {
+		if _, err := GetBlockers(s, id); !errors.As(err, &notFound) {
+			t.Errorf("GetBlockers(%s): expected NotFoundError, got %v", id, err)
+		}
+		if _, err := GetBlocking(s, id); !errors.As(err, &notFound) {
+			t.Errorf("GetBlocking(%s): expected NotFoundError, got %v", id, err)
+		}
+	}
+}
+
+// TestBlockerTree tests the full blocker tree, including a diamond.
+func TestBlockerTree(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Base", TaskOptions{})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Approved?"})
+	AddTask(s, "TS", "Left", TaskOptions{BlockedBy: []string{"TS-01", "TS-02W"}})
+	AddTask(s, "TS", "Right", TaskOptions{BlockedBy: []string{"TS-01"}})
+	AddTask(s, "TS", "Top", TaskOptions{BlockedBy: []string{"TS-03", "TS-04"}})
+	CompleteTask(s, "TS-01", CompleteOptions{})
+
+	root, err := BlockerTree(s, "ts-05")
+	if err != nil {
+		t.Fatalf("BlockerTree failed: %v", err)
+	}
+
+	var lines []string
+	var walk func(node *BlockerNode, depth int)
+	walk = func(node *BlockerNode, depth int) {
+		line := fmt.Sprintf("%d %s %s", depth, node.ID, node.State)
+		if node.Seen {
+			line += " seen"
+		}
+		lines = append(lines, line)
+		for _, b := range node.Blockers {
+			walk(b, depth+1)
+		}
+	}
+	walk(root, 0)
+
+	want := "0 TS-05 blocked|1 TS-03 waiting|2 TS-01 done|2 TS-02W actionable|1 TS-04 ready|2 TS-01 done seen"
+	if got := strings.Join(lines, "|"); got != want {
+		t.Errorf("unexpected tree:\n got %s\nwant %s", got, want)
+	}
+
+	var notFound *NotFoundError
+	if _, err := BlockerTree(s, "TS-99"); !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
+// TestCriticalPath tests that the longest open chain skips closed items.
+func TestCriticalPath(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Survey", TaskOptions{})
+	AddTask(s, "TS", "Dig", TaskOptions{BlockedBy: []string{"TS-01"}})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Inspected?", BlockedBy: []string{"TS-02"}})
+	AddTask(s, "TS", "Plant", TaskOptions{BlockedBy: []string{"TS-03W"}})
+	AddTask(s, "TS", "Water", TaskOptions{BlockedBy: []string{"TS-01"}})
+
+	pf, _ := s.LoadProject("TS")
+	var ids []string
+	for _, node := range CriticalPath(pf, true) {
+		ids = append(ids, node.ID+" "+node.State)
+	}
+	if got := strings.Join(ids, ","); got != "TS-01 ready,TS-02 blocked,TS-03W dormant,TS-04 waiting" {
+		t.Errorf("unexpected critical path: %s", got)
+	}
+
+	CompleteTask(s, "TS-01", CompleteOptions{})
+	pf, _ = s.LoadProject("TS")
+	ids = nil
+	for _, node := range CriticalPath(pf, true) {
+		ids = append(ids, node.ID)
+	}
+	if got := strings.Join(ids, ","); got != "TS-02,TS-03W,TS-04" {
+		t.Errorf("expected done TS-01 left out, got %s", got)
+	}
+}
+
+// TestPlanTasks tests ordering open tasks after their blockers.
+func TestPlanTasks(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Plant", TaskOptions{Priority: 1})
+	AddTask(s, "TS", "Dig", TaskOptions{Priority: 3})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Inspected?", BlockedBy: []string{"TS-02"}})
+	AddTask(s, "TS", "Water", TaskOptions{Priority: 1, BlockedBy: []string{"TS-02"}})
+	AddTask(s, "TS", "Mulch", TaskOptions{Priority: 2, BlockedBy: []string{"TS-03W"}})
+	AddTask(s, "TS", "Done already", TaskOptions{})
+	CompleteTask(s, "TS-06", CompleteOptions{})
+
+	results, err := PlanTasks(s, "TS")
+	if err != nil {
+		t.Fatalf("PlanTasks failed: %v", err)
+	}
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.Task.ID)
+	}
+	// TS-04 waits for TS-02 despite its priority, and so does TS-05 through
+	// the wait between them; the wait itself is left out
+	if got := strings.Join(ids, ","); got != "TS-01,TS-02,TS-04,TS-05" {
+		t.Errorf("unexpected plan order: %s", got)
+	}
+	if results[3].State != model.TaskStateWaiting {
+		t.Errorf("expected TS-05 waiting, got %s", results[1].State)
+	}
+
+	// A cycle from a hand-edit is reported, not looped on
+	pf, _ := s.LoadProject("TS")
+	findTask(pf, "TS-02").BlockedBy = []string{"TS-04"}
+	s.SaveProject(pf)
+	if _, err := PlanTasks(s, "TS"); err == nil || !strings.Contains(err.Error(), "dependency cycle") {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+}
+
+// TestDependencyPath tests finding the blocker chain between two items.
+func TestDependencyPath(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Base", TaskOptions{})
+	AddTask(s, "TS", "Middle", TaskOptions{BlockedBy: []string{"TS-01"}})
+	AddTask(s, "TS", "Top", TaskOptions{BlockedBy: []string{"TS-02"}})
+	AddTask(s, "TS", "Unrelated", TaskOptions{})
+
+	path, err := DependencyPath(s, "ts-03", "TS-01")
+	if err != nil {
+		t.Fatalf("DependencyPath failed: %v", err)
+	}
+	if got := strings.Join(path, ","); got != "TS-03,TS-02,TS-01" {
+		t.Errorf("expected TS-03,TS-02,TS-01, got %s", got)
+	}
+
+	for _, pair := range [][2]string{{"TS-01", "TS-03"}, {"TS-04", "TS-01"}} {
+		if path, err := DependencyPath(s, pair[0], pair[1]); err != nil || path != nil {
+			t.Errorf("DependencyPath(%s, %s): expected no path, got %v, %v", pair[0], pair[1], path, err)
+		}
+	}
+
+	if _, err := DependencyPath(s, "TS-01", "TS-01"); err == nil {
+		t.Error("expected error for the same item")
+	}
+	if _, err := DependencyPath(s, "TS-01", "XX-01"); err == nil {
+		t.Error("expected error for items in different projects")
+	}
+	var notFound *NotFoundError
+	if _, err := DependencyPath(s, "TS-01", "TS-99"); !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
 // TestRemoveWaitBlockerNotFound tests removing non-existent blocker.
 func TestRemoveWaitBlockerNotFound(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -1326,6 +3774,65 @@ func TestDetectCyclesInWaits(t *testing.T) {
 	}
 }
 
+// TestSuggestCycleBreaks tests that a cycle break proposes the edge on the
+// most recently updated item and that applying it clears the cycle.
+func TestSuggestCycleBreaks(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	// TS-01 -> TS-02 -> TS-03 -> TS-01, with TS-02 edited last
+	base := time.Now().Add(-time.Hour)
+	pf, _ := s.LoadProject("TS")
+	for i, blocker := range []string{"TS-02", "TS-03", "TS-01"} {
+		updated := base
+		if i == 1 {
+			updated = base.Add(time.Minute)
+		}
+		pf.Tasks = append(pf.Tasks, model.Task{
+			ID:        fmt.Sprintf("TS-%02d", i+1),
+			Title:     fmt.Sprintf("Task %d", i+1),
+			Status:    model.TaskStatusOpen,
+			Priority:  3,
+			BlockedBy: []string{blocker},
+			Created:   base,
+			Updated:   updated,
+		})
+	}
+	pf.NextID = 4
+	s.SaveProject(pf)
+
+	breaks, err := SuggestCycleBreaks(s)
+	if err != nil {
+		t.Fatalf("SuggestCycleBreaks failed: %v", err)
+	}
+	if len(breaks) != 1 {
+		t.Fatalf("expected 1 suggestion, got %d: %+v", len(breaks), breaks)
+	}
+	b := breaks[0]
+	if b.Item != "TS-02" || b.Blocker != "TS-03" {
+		t.Errorf("expected to remove TS-02 blocked by TS-03, got %s blocked by %s", b.Item, b.Blocker)
+	}
+	if len(b.Cycle) != 4 || b.Cycle[0] != b.Cycle[3] {
+		t.Errorf("expected closed cycle path, got %v", b.Cycle)
+	}
+
+	if err := BreakCycle(s, b); err != nil {
+		t.Fatalf("BreakCycle failed: %v", err)
+	}
+	pf, _ = s.LoadProject("TS")
+	if blockers := findTask(pf, "TS-02").BlockedBy; len(blockers) != 0 {
+		t.Errorf("expected TS-02 to have no blockers, got %v", blockers)
+	}
+	breaks, _ = SuggestCycleBreaks(s)
+	if len(breaks) != 0 {
+		t.Errorf("expected no cycles after break, got %+v", breaks)
+	}
+
+	if err := BreakCycle(s, b); err == nil {
+		t.Error("expected error breaking an edge that no longer exists")
+	}
+}
+
 // TestDetectDuplicateIDs tests detection of duplicate IDs.
 func TestDetectDuplicateIDs(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -1555,10 +4062,10 @@ func TestCompleteAlreadyDone(t *testing.T) {
 	defer cleanup()
 
 	AddTask(s, "TS", "Task", TaskOptions{})
-	CompleteTask(s, "TS-01", false)
+	CompleteTask(s, "TS-01", CompleteOptions{})
 
 	// Try to complete again
-	_, err := CompleteTask(s, "TS-01", false)
+	_, err := CompleteTask(s, "TS-01", CompleteOptions{})
 	if err == nil {
 		t.Error("expected error when completing already done task")
 	}
@@ -1571,9 +4078,9 @@ func TestCompleteAlreadyDoneErrorType(t *testing.T) {
 	defer cleanup()
 
 	AddTask(s, "TS", "Task", TaskOptions{})
-	CompleteTask(s, "TS-01", false)
+	CompleteTask(s, "TS-01", CompleteOptions{})
 
-	_, err := CompleteTask(s, "TS-01", false)
+	_, err := CompleteTask(s, "TS-01", CompleteOptions{})
 	if err == nil {
 		t.Fatal("expected error when completing already done task")
 	}
@@ -1597,7 +4104,7 @@ func TestCompleteDroppedTaskErrorType(t *testing.T) {
 	AddTask(s, "TS", "Task", TaskOptions{})
 	DropTask(s, "TS-01", "not needed", false, false)
 
-	_, err := CompleteTask(s, "TS-01", false)
+	_, err := CompleteTask(s, "TS-01", CompleteOptions{})
 	if err == nil {
 		t.Fatal("expected error when completing dropped task")
 	}
@@ -1621,7 +4128,7 @@ func TestCompleteBlockedTaskReturnsBlockerError(t *testing.T) {
 	AddTask(s, "TS", "Blocker", TaskOptions{})
 	AddTask(s, "TS", "Blocked", TaskOptions{BlockedBy: []string{"TS-01"}})
 
-	_, err := CompleteTask(s, "TS-02", false)
+	_, err := CompleteTask(s, "TS-02", CompleteOptions{})
 	if err == nil {
 		t.Fatal("expected error when completing blocked task")
 	}
@@ -1645,7 +4152,7 @@ func TestCompleteNotFoundErrorType(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
 	defer cleanup()
 
-	_, err := CompleteTask(s, "TS-99", false)
+	_, err := CompleteTask(s, "TS-99", CompleteOptions{})
 	if err == nil {
 		t.Fatal("expected error when completing non-existent task")
 	}
@@ -1655,8 +4162,12 @@ func TestCompleteNotFoundErrorType(t *testing.T) {
 		t.Error("completing a non-existent task should NOT return IncompleteBlockersError")
 	}
 
-	if !strings.Contains(err.Error(), "not found") {
-		t.Errorf("expected error to mention 'not found', got: %v", err)
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected NotFoundError, got: %v", err)
+	}
+	if notFound.Kind != "task" || notFound.ItemID != "TS-99" {
+		t.Errorf("expected task TS-99, got %s %s", notFound.Kind, notFound.ItemID)
 	}
 }
 
@@ -1667,7 +4178,7 @@ func TestReopenAlreadyOpen(t *testing.T) {
 
 	AddTask(s, "TS", "Task", TaskOptions{})
 
-	err := ReopenTask(s, "TS-01")
+	_, err := ReopenTask(s, "TS-01")
 	if err == nil {
 		t.Error("expected error when reopening already open task")
 	}
@@ -1683,7 +4194,7 @@ func TestDeferTaskWithExistingWait(t *testing.T) {
 		Type:     model.ResolutionTypeManual,
 		Question: "Test?",
 	})
-	AddBlocker(s, "TS-01", "TS-02W")
+	AddBlocker(s, "TS-01", "TS-02W", "")
 
 	// Try to defer (should fail because task already has open wait)
 	until := time.Now().Add(24 * time.Hour)
@@ -1866,6 +4377,61 @@ func TestAddWaitToPausedProject(t *testing.T) {
 	}
 }
 
+// TestInactiveProjectReadOnly tests that items in paused and done projects
+// can only be changed through a store with AllowInactive set.
+func TestInactiveProjectReadOnly(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "First", TaskOptions{})
+	AddTask(s, "TS", "Second", TaskOptions{})
+	AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Arrived?"})
+
+	doneStatus := model.ProjectStatusDone
+	if err := EditProject(s, "TS", ProjectChanges{Status: &doneStatus}); err != nil {
+		t.Fatalf("EditProject failed: %v", err)
+	}
+
+	title := "Renamed"
+	attempts := map[string]error{
+		"EditTask":     EditTask(s, "TS-01", TaskChanges{Title: &title}),
+		"AddBlocker":   AddBlocker(s, "TS-02", "TS-01", ""),
+		"ResolveWait":  ResolveWait(s, "TS-03W", "yes"),
+		"AppendNote":   AppendNote(s, "TS-01", "note"),
+		"DropTask":     func() error { _, err := DropTask(s, "TS-02", "", false, false); return err }(),
+		"CompleteTask": func() error { _, err := CompleteTask(s, "TS-01", CompleteOptions{}); return err }(),
+	}
+	for name, err := range attempts {
+		var statusErr *ProjectStatusError
+		if !errors.As(err, &statusErr) {
+			t.Errorf("%s: expected ProjectStatusError, got %v", name, err)
+		}
+	}
+
+	pf, _ := s.LoadProject("TS")
+	if findTask(pf, "TS-01").Title != "First" || findTask(pf, "TS-01").Status != model.TaskStatusOpen {
+		t.Error("task in done project should be unchanged")
+	}
+
+	// The override allows changes
+	forced := WithOptions(s, StoreOptions{AllowInactive: true})
+	if err := EditTask(forced, "TS-01", TaskChanges{Title: &title}); err != nil {
+		t.Fatalf("EditTask with AllowInactive failed: %v", err)
+	}
+	if _, err := CompleteTask(forced, "TS-01", CompleteOptions{}); err != nil {
+		t.Fatalf("CompleteTask with AllowInactive failed: %v", err)
+	}
+
+	// Reactivating the project lifts the restriction
+	activeStatus := model.ProjectStatusActive
+	if err := EditProject(s, "TS", ProjectChanges{Status: &activeStatus}); err != nil {
+		t.Fatalf("EditProject failed: %v", err)
+	}
+	if err := EditTask(s, "TS-02", TaskChanges{Title: &title}); err != nil {
+		t.Errorf("EditTask in active project failed: %v", err)
+	}
+}
+
 // TestAddWaitToDoneProject tests adding wait to done project fails.
 func TestAddWaitToDoneProject(t *testing.T) {
 	s, cleanup := setupTestStorage(t)
@@ -1926,7 +4492,67 @@ func BenchmarkAddTask(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		AddTask(s, "BN", "Benchmark task", TaskOptions{})
+		AddTask(s, "BN", "Benchmark task", TaskOptions{})
+	}
+}
+
+// BenchmarkListTasksSeeded benchmarks listing a seeded project.
+func BenchmarkListTasksSeeded(b *testing.B) {
+	dir, _ := os.MkdirTemp("", "tk-bench")
+	defer os.RemoveAll(dir)
+
+	s, _ := storage.Init(dir, "Bench", "BN")
+	if _, err := Seed(s, "BN", SeedOptions{Tasks: 1000, Seed: 1}); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ListTasks(s, TaskFilter{Project: "BN"})
+	}
+}
+
+// TestSeed tests that seeding is reproducible and produces valid data.
+func TestSeed(t *testing.T) {
+	seedProject := func(seed int64) *model.ProjectFile {
+		s, cleanup := setupTestStorage(t)
+		defer cleanup()
+
+		result, err := Seed(s, "TS", SeedOptions{Tasks: 50, Seed: seed})
+		if err != nil {
+			t.Fatalf("Seed failed: %v", err)
+		}
+		if result.Tasks != 50 || result.Waits != 9 {
+			t.Errorf("expected 50 tasks and 9 waits, got %+v", result)
+		}
+
+		errs, err := ValidateProject(s, "TS")
+		if err != nil {
+			t.Fatalf("ValidateProject failed: %v", err)
+		}
+		if len(errs) > 0 {
+			t.Errorf("seeded project has validation errors: %v", errs)
+		}
+
+		pf, _ := s.LoadProject("TS")
+		return pf
+	}
+
+	a, b := seedProject(1), seedProject(1)
+	for i := range a.Tasks {
+		if a.Tasks[i].ID != b.Tasks[i].ID || a.Tasks[i].Title != b.Tasks[i].Title ||
+			strings.Join(a.Tasks[i].BlockedBy, ",") != strings.Join(b.Tasks[i].BlockedBy, ",") {
+			t.Fatalf("same seed produced different task %d: %+v vs %+v", i, a.Tasks[i], b.Tasks[i])
+		}
+	}
+
+	c := seedProject(2)
+	same := true
+	for i := range a.Tasks {
+		same = same && a.Tasks[i].Title == c.Tasks[i].Title
+	}
+	if same {
+		t.Error("different seeds produced identical titles")
 	}
 }
 
@@ -2388,3 +5014,580 @@ func TestValidateTagErrorMessages(t *testing.T) {
 		t.Errorf("expected 'must not contain whitespace' message, got: %v", err)
 	}
 }
+
+// TestExportImportProject tests a portable export round-tripping through
+// ImportProject into another tk directory.
+func TestExportImportProject(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "First", TaskOptions{})
+	AddTask(s, "TS", "Second", TaskOptions{BlockedBy: []string{"TS-01"}})
+	if err := SnoozeTask(s, "TS-02", time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("SnoozeTask failed: %v", err)
+	}
+
+	plain, err := ExportProject(s, "TS", false)
+	if err != nil {
+		t.Fatalf("ExportProject failed: %v", err)
+	}
+	if findTask(plain, "TS-02").SnoozedUntil == nil {
+		t.Error("non-portable export should keep snooze state")
+	}
+
+	pf, err := ExportProject(s, "TS", true)
+	if err != nil {
+		t.Fatalf("portable ExportProject failed: %v", err)
+	}
+	if findTask(pf, "TS-02").SnoozedUntil != nil {
+		t.Error("portable export should strip snooze state")
+	}
+
+	data, err := model.MarshalProject(pf)
+	if err != nil {
+		t.Fatalf("MarshalProject failed: %v", err)
+	}
+	bundle, err := model.ParseProject(data)
+	if err != nil {
+		t.Fatalf("ParseProject failed: %v", err)
+	}
+
+	other, cleanupOther := setupTestStorage(t)
+	defer cleanupOther()
+	if err := ImportProject(other, bundle); err == nil {
+		t.Error("expected error importing over an existing prefix")
+	}
+	DeleteProject(other, "TS", true)
+	if err := ImportProject(other, bundle); err != nil {
+		t.Fatalf("ImportProject failed: %v", err)
+	}
+
+	imported, err := other.LoadProject("TS")
+	if err != nil {
+		t.Fatalf("LoadProject failed: %v", err)
+	}
+	if len(imported.Tasks) != 2 || imported.NextID != 3 {
+		t.Errorf("expected 2 tasks and next_id 3, got %d tasks and next_id %d", len(imported.Tasks), imported.NextID)
+	}
+	if got := findTask(imported, "TS-02").BlockedBy; len(got) != 1 || got[0] != "TS-01" {
+		t.Errorf("expected TS-02 blocked by TS-01, got %v", got)
+	}
+	if got := findTask(imported, "TS-01").Source; got != model.TaskSourceImport {
+		t.Errorf("expected source %q, got %q", model.TaskSourceImport, got)
+	}
+}
+
+// TestExportPortableExternalReference tests that a portable export refuses a
+// project whose blocker references escape it.
+func TestExportPortableExternalReference(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "First", TaskOptions{})
+	pf, _ := s.LoadProject("TS")
+	pf.Tasks[0].BlockedBy = []string{"EL-01"}
+	s.SaveProject(pf)
+
+	if _, err := ExportProject(s, "TS", false); err != nil {
+		t.Errorf("non-portable export should not check references: %v", err)
+	}
+	_, err := ExportProject(s, "TS", true)
+	if err == nil || !strings.Contains(err.Error(), "TS-01 -> EL-01") {
+		t.Errorf("expected external reference error, got %v", err)
+	}
+
+	pf.Prefix = "OT"
+	pf.ID = "other"
+	pf.Tasks[0].ID = "OT-01"
+	if err := ImportProject(s, pf); err == nil || !strings.Contains(err.Error(), "not self-contained") {
+		t.Errorf("expected import to reject external reference, got %v", err)
+	}
+}
+
+// TestImportProjectValidates tests that a bundle failing validation is
+// refused without creating the project.
+func TestImportProjectValidates(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	bundle := func() *model.ProjectFile {
+		return &model.ProjectFile{
+			Project: model.Project{ID: "other", Prefix: "OT", Name: "Other", NextID: 3},
+			Tasks: []model.Task{
+				{ID: "OT-01", Title: "First", Status: model.TaskStatusOpen, Priority: 3},
+				{ID: "OT-02", Title: "Second", Status: model.TaskStatusOpen, Priority: 3, BlockedBy: []string{"OT-01"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		edit   func(pf *model.ProjectFile)
+		errMsg string
+	}{
+		{"duplicate ID", func(pf *model.ProjectFile) { pf.Tasks[1].ID = "OT-01" }, "duplicate task ID"},
+		{"malformed ID", func(pf *model.ProjectFile) { pf.Tasks[1].ID = "OT-2b" }, "invalid task ID format"},
+		{"foreign prefix", func(pf *model.ProjectFile) { pf.Tasks[1].ID = "XX-02" }, "does not belong"},
+		{"cycle", func(pf *model.ProjectFile) { pf.Tasks[0].BlockedBy = []string{"OT-02"} }, "cycle"},
+		{"missing title", func(pf *model.ProjectFile) { pf.Tasks[0].Title = "" }, "missing required field: title"},
+		{"bad priority", func(pf *model.ProjectFile) { pf.Tasks[0].Priority = 9 }, "invalid priority"},
+	}
+	for _, tt := range tests {
+		pf := bundle()
+		tt.edit(pf)
+		err := ImportProject(s, pf)
+		if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.errMsg, err)
+		}
+		if s.ProjectExists("OT") {
+			t.Fatalf("%s: invalid bundle should not be saved", tt.name)
+		}
+	}
+
+	if err := ImportProject(s, bundle()); err != nil {
+		t.Fatalf("ImportProject of a valid bundle failed: %v", err)
+	}
+}
+
+// TestDependentsReflectBlockerChanges tests that dependents are derived from
+// the current blocked_by lists rather than stored on the blocker.
+func TestDependentsReflectBlockerChanges(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Blocker", TaskOptions{})
+	AddTask(s, "TS", "Second", TaskOptions{})
+	AddTask(s, "TS", "Third", TaskOptions{BlockedBy: []string{"TS-01"}})
+
+	if err := AddBlocker(s, "TS-02", "TS-01", ""); err != nil {
+		t.Fatalf("AddBlocker failed: %v", err)
+	}
+	pf, _ := s.LoadProject("TS")
+	if got := strings.Join(Dependents(pf, "TS-01"), ","); got != "TS-02,TS-03" {
+		t.Errorf("expected dependents TS-02,TS-03, got %s", got)
+	}
+
+	if err := RemoveBlocker(s, "TS-03", "TS-01"); err != nil {
+		t.Fatalf("RemoveBlocker failed: %v", err)
+	}
+	pf, _ = s.LoadProject("TS")
+	if got := strings.Join(Dependents(pf, "TS-01"), ","); got != "TS-02" {
+		t.Errorf("expected dependents TS-02, got %s", got)
+	}
+}
+
+// TestScoreTask tests the weighted combination of priority, due-date
+// urgency, and downstream impact.
+func TestScoreTask(t *testing.T) {
+	now := time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+	weights := storage.ScoreWeights{Priority: 1, Due: 1, Impact: 1}
+	dueIn := func(days int) *time.Time {
+		d := now.AddDate(0, 0, days)
+		return &d
+	}
+
+	tests := []struct {
+		name       string
+		task       model.Task
+		dependents int
+		want       float64
+	}{
+		{"p1 alone", model.Task{Priority: 1}, 0, 1},
+		{"p4 alone", model.Task{Priority: 4}, 0, 0.25},
+		{"due far off", model.Task{Priority: 4, DueDate: dueIn(30)}, 0, 0.25},
+		{"due in a week", model.Task{Priority: 4, DueDate: dueIn(7)}, 0, 0.75},
+		{"overdue", model.Task{Priority: 4, DueDate: dueIn(-3)}, 0, 1.25},
+		{"blocks one", model.Task{Priority: 4}, 1, 0.75},
+		{"blocks three", model.Task{Priority: 4}, 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScoreTask(&tt.task, tt.dependents, weights, now)
+			if diff := got - tt.want; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("expected score %.3f, got %.3f", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestSortByScore tests that a low-priority task blocking a lot of open work
+// can outrank a higher-priority leaf task.
+func TestSortByScore(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Leaf", TaskOptions{Priority: 3})
+	AddTask(s, "TS", "Foundation", TaskOptions{Priority: 4})
+	AddTask(s, "TS", "Needs foundation", TaskOptions{BlockedBy: []string{"TS-02"}})
+	AddTask(s, "TS", "Needs that too", TaskOptions{BlockedBy: []string{"TS-03"}})
+
+	ready := model.TaskStateReady
+	results, err := ListTasks(s, TaskFilter{State: &ready})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+
+	weights := storage.ScoreWeights{Priority: 1, Impact: 1}
+	if err := SortByScore(s, results, weights, time.Now()); err != nil {
+		t.Fatalf("SortByScore failed: %v", err)
+	}
+	if len(results) != 2 || results[0].Task.ID != "TS-02" {
+		t.Errorf("expected TS-02 first, got %v", results)
+	}
+
+	weights.Impact = 0
+	SortByScore(s, results, weights, time.Now())
+	if results[0].Task.ID != "TS-01" {
+		t.Errorf("expected TS-01 first without impact weight, got %s", results[0].Task.ID)
+	}
+}
+
+// TestProjectDependencies tests aggregating cross-project blockers into
+// project-level edges.
+func TestProjectDependencies(t *testing.T) {
+	projects := []*model.ProjectFile{
+		{
+			Project: model.Project{Prefix: "BY"},
+			Tasks: []model.Task{
+				{ID: "BY-01", BlockedBy: []string{"HM-01", "BY-02"}},
+				{ID: "BY-02", BlockedBy: []string{"HM-02W", "TS-01"}},
+			},
+			Waits: []model.Wait{
+				{ID: "BY-03W", BlockedBy: []string{"hm-3"}},
+			},
+		},
+		{
+			Project: model.Project{Prefix: "HM"},
+			Tasks: []model.Task{
+				{ID: "HM-01", BlockedBy: []string{"TS-04"}},
+			},
+		},
+	}
+
+	deps := ProjectDependencies(projects)
+	expected := []ProjectDependency{
+		{Project: "BY", DependsOn: "HM", Count: 3},
+		{Project: "BY", DependsOn: "TS", Count: 1},
+		{Project: "HM", DependsOn: "TS", Count: 1},
+	}
+	if len(deps) != len(expected) {
+		t.Fatalf("expected %d edges, got %v", len(expected), deps)
+	}
+	for i := range expected {
+		if deps[i] != expected[i] {
+			t.Errorf("edge %d: expected %+v, got %+v", i, expected[i], deps[i])
+		}
+	}
+}
+
+// TestGetItemHistory tests that a task's timeline is rebuilt in order from
+// its own and its blockers' timestamps.
+func TestGetItemHistory(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Order lumber", TaskOptions{})
+	AddTask(s, "TS", "Build fence", TaskOptions{BlockedBy: []string{"TS-01"}})
+
+	day := func(d int) *time.Time {
+		at := time.Date(2026, 3, d, 9, 0, 0, 0, time.UTC)
+		return &at
+	}
+	pf, _ := s.LoadProject("TS")
+	pf.Tasks[0].Created, pf.Tasks[0].Updated = *day(1), *day(3)
+	pf.Tasks[0].Status, pf.Tasks[0].DoneAt = model.TaskStatusDone, day(3)
+	pf.Tasks[1].Created, pf.Tasks[1].Updated = *day(2), *day(4)
+	pf.Tasks[1].Status, pf.Tasks[1].DroppedAt = model.TaskStatusDropped, day(5)
+	pf.Tasks[1].DropReason = "hired out"
+	s.SaveProject(pf)
+
+	h, err := GetItemHistory(s, "ts-2")
+	if err != nil {
+		t.Fatalf("GetItemHistory failed: %v", err)
+	}
+	if h.ID != "TS-02" || h.Title != "Build fence" {
+		t.Errorf("expected TS-02: Build fence, got %s: %s", h.ID, h.Title)
+	}
+
+	var got []string
+	for _, e := range h.Events {
+		got = append(got, e.Description)
+	}
+	want := "created,blocker TS-01 done,last updated,dropped (hired out)"
+	if strings.Join(got, ",") != want {
+		t.Errorf("events = %v, want %s", got, want)
+	}
+
+	if _, err := GetItemHistory(s, "TS-99"); err == nil {
+		t.Error("expected error for missing task")
+	}
+}
+
+// TestStrictLoad tests that a strict store refuses projects with duplicate
+// IDs but still loads ones with only non-structural issues.
+func TestStrictLoad(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "First", TaskOptions{})
+	AddTask(s, "TS", "Second", TaskOptions{BlockedBy: []string{"TS-01"}})
+	strict := WithOptions(s, StoreOptions{StrictLoad: true})
+
+	// An orphan blocker is not structural
+	pf, _ := s.LoadProject("TS")
+	pf.Tasks[1].BlockedBy = []string{"TS-09"}
+	s.SaveProject(pf)
+	if _, err := strict.LoadProject("TS"); err != nil {
+		t.Fatalf("expected orphan blocker to be allowed, got %v", err)
+	}
+
+	pf.Tasks[1].ID = "TS-01"
+	s.SaveProject(pf)
+
+	_, err := strict.LoadProject("TS")
+	var corruptErr *CorruptProjectError
+	if !errors.As(err, &corruptErr) {
+		t.Fatalf("expected CorruptProjectError, got %v", err)
+	}
+	if corruptErr.Problems[0].Type != ValidationErrorDuplicateID {
+		t.Errorf("expected duplicate_id, got %s", corruptErr.Problems[0].Type)
+	}
+	if !strings.Contains(err.Error(), "tk validate --fix") {
+		t.Errorf("expected a pointer to tk validate --fix, got %q", err.Error())
+	}
+
+	// Mutations through the strict store are refused too
+	if _, err := AddTask(strict, "TS", "Third", TaskOptions{}); !errors.As(err, &corruptErr) {
+		t.Errorf("expected AddTask to be refused, got %v", err)
+	}
+
+	// The plain store still loads it, so validate can report and repair it
+	if _, err := s.LoadProject("TS"); err != nil {
+		t.Errorf("plain load failed: %v", err)
+	}
+}
+
+// TestDormantUntil tests that only a wait's open blockers are reported.
+func TestDormantUntil(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	first, _ := AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Delivered?"})
+	second, _ := AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeManual, Question: "Inspected?"})
+	chained, err := AddWait(s, "TS", WaitOptions{
+		Type:      model.ResolutionTypeManual,
+		Question:  "Installed?",
+		BlockedBy: []string{first.ID, second.ID},
+	})
+	if err != nil {
+		t.Fatalf("AddWait failed: %v", err)
+	}
+	ResolveWait(s, first.ID, "arrived")
+
+	result, pf, _ := ShowWait(s, chained.ID)
+	if result.State != model.WaitStateDormant {
+		t.Fatalf("expected dormant, got %s", result.State)
+	}
+	got := DormantUntil(pf, &result.Wait, true)
+	if strings.Join(got, ",") != second.ID {
+		t.Errorf("DormantUntil = %v, want [%s]", got, second.ID)
+	}
+}
+
+// TestCompleteTaskResolveTimeWaits tests that a task's pending time waits
+// are resolved early only when they are its sole open blockers, and only
+// together with completing the task.
+func TestCompleteTaskResolveTimeWaits(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	after := time.Now().AddDate(0, 0, 7)
+	wait, _ := AddWait(s, "TS", WaitOptions{Type: model.ResolutionTypeTime, After: &after})
+	other, _ := AddTask(s, "TS", "Buy seed", TaskOptions{})
+	task, _ := AddTask(s, "TS", "Sow lawn", TaskOptions{BlockedBy: []string{wait.ID, other.ID}})
+
+	// An open task blocker remains, so nothing is resolved or completed
+	opts := CompleteOptions{ResolveTimeWaits: true}
+	if _, err := CompleteTask(s, task.ID, opts); err == nil {
+		t.Fatal("expected completion to be refused while a task blocker is open")
+	}
+	waitResult, _, _ := ShowWait(s, wait.ID)
+	if waitResult.Wait.Status != model.WaitStatusOpen {
+		t.Errorf("expected wait to stay open, got %s", waitResult.Wait.Status)
+	}
+
+	if _, err := CompleteTask(s, other.ID, CompleteOptions{}); err != nil {
+		t.Fatalf("CompleteTask failed: %v", err)
+	}
+	result, err := CompleteTask(s, task.ID, opts)
+	if err != nil {
+		t.Fatalf("CompleteTask failed: %v", err)
+	}
+	if strings.Join(result.ResolvedEarly, ",") != wait.ID {
+		t.Errorf("ResolvedEarly = %v, want [%s]", result.ResolvedEarly, wait.ID)
+	}
+
+	pf, _ := s.LoadProject("TS")
+	if w := findWait(pf, wait.ID); w.Status != model.WaitStatusDone || w.Resolution != "resolved early by completing "+task.ID {
+		t.Errorf("expected wait resolved early, got %s (%q)", w.Status, w.Resolution)
+	}
+	if tk := findTask(pf, task.ID); tk.Status != model.TaskStatusDone {
+		t.Errorf("expected task done, got %s", tk.Status)
+	}
+}
+
+// TestNotFoundErrorKinds tests that task, wait, and project lookups report
+// a NotFoundError naming what was looked up.
+func TestNotFoundErrorKinds(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	AddTask(s, "TS", "Task", TaskOptions{})
+
+	tests := []struct {
+		name   string
+		err    error
+		kind   string
+		itemID string
+	}{
+		{"task", func() error { _, _, err := ShowTask(s, "TS-42"); return err }(), "task", "TS-42"},
+		{"wait", ResolveWait(s, "TS-42W", ""), "wait", "TS-42W"},
+		{"project", func() error { _, err := ResolveProject(s, "nope"); return err }(), "project", "nope"},
+		{"blocker", func() error { _, err := AddTask(s, "TS", "New", TaskOptions{BlockedBy: []string{"TS-42"}}); return err }(), "blocker", "TS-42"},
+		{"project of a task", func() error { _, err := CompleteTask(s, "ZZ-01", CompleteOptions{}); return err }(), "project", "ZZ"},
+		{"project of a wait", ResolveWait(s, "ZZ-01W", ""), "project", "ZZ"},
+	}
+	for _, tt := range tests {
+		var notFound *NotFoundError
+		if !errors.As(tt.err, &notFound) {
+			t.Errorf("%s: expected NotFoundError, got %v", tt.name, tt.err)
+			continue
+		}
+		if notFound.Kind != tt.kind || notFound.ItemID != tt.itemID {
+			t.Errorf("%s: got %s %s, want %s %s", tt.name, notFound.Kind, notFound.ItemID, tt.kind, tt.itemID)
+		}
+	}
+}
+
+// TestNextTask tests picking the single most urgent ready task.
+func TestNextTask(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	next, err := NextTask(s, "")
+	if err != nil {
+		t.Fatalf("NextTask failed: %v", err)
+	}
+	if next != nil {
+		t.Fatalf("expected nothing ready, got %s", next.Task.ID)
+	}
+
+	soon := time.Now().AddDate(0, 0, 3)
+	later := time.Now().AddDate(0, 0, 10)
+	AddTask(s, "TS", "Low priority", TaskOptions{Priority: 4})
+	AddTask(s, "TS", "Due later", TaskOptions{Priority: 2, DueDate: &later})
+	AddTask(s, "TS", "Due soon", TaskOptions{Priority: 2, DueDate: &soon})
+	AddTask(s, "TS", "Blocked urgent", TaskOptions{Priority: 1, BlockedBy: []string{"TS-01"}})
+
+	next, err = NextTask(s, "")
+	if err != nil {
+		t.Fatalf("NextTask failed: %v", err)
+	}
+	if next == nil || next.Task.ID != "TS-03" {
+		t.Errorf("expected TS-03 (P2, due soonest), got %v", next)
+	}
+
+	// Without a project every active project is considered, even with a
+	// default_project set
+	CreateProject(s, "other", "OT", "Other", "")
+	AddTask(s, "OT", "Other work", TaskOptions{Priority: 1, DueDate: &soon})
+	if err := os.WriteFile(s.ConfigPath(), []byte("default_project: ts\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	next, _ = NextTask(s, "")
+	if next == nil || next.Task.ID != "OT-01" {
+		t.Errorf("expected OT-01 from the other project, got %v", next)
+	}
+	next, _ = NextTask(s, "TS")
+	if next == nil || next.Task.ID != "TS-03" {
+		t.Errorf("expected TS-03 with an explicit project, got %v", next)
+	}
+}
+
+// TestSortTasks tests ordering task results by each sort key.
+func TestSortTasks(t *testing.T) {
+	day := func(n int) *time.Time {
+		d := time.Date(2026, time.March, n, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	results := []TaskResult{
+		{Project: "TS", Task: model.Task{ID: "TS-01", Priority: 3, DueDate: day(10), Created: *day(1), Updated: *day(5)}},
+		{Project: "TS", Task: model.Task{ID: "TS-02", Priority: 1, Created: *day(2), Updated: *day(3)}},
+		{Project: "TS", Task: model.Task{ID: "TS-03", Priority: 3, DueDate: day(4), Created: *day(3), Updated: *day(4)}},
+		{Project: "AB", Task: model.Task{ID: "AB-01", Priority: 2, Created: *day(4), Updated: *day(1)}},
+	}
+
+	tests := []struct {
+		key     string
+		reverse bool
+		want    string
+	}{
+		{"id", false, "AB-01,TS-01,TS-02,TS-03"},
+		{"id", true, "TS-03,TS-02,TS-01,AB-01"},
+		{"priority", false, "TS-02,AB-01,TS-01,TS-03"},
+		{"priority", true, "TS-01,TS-03,AB-01,TS-02"},
+		{"due", false, "TS-03,TS-01,AB-01,TS-02"},
+		{"due", true, "TS-01,TS-03,AB-01,TS-02"},
+		{"created", false, "TS-01,TS-02,TS-03,AB-01"},
+		{"updated", true, "TS-01,TS-03,TS-02,AB-01"},
+	}
+	for _, tt := range tests {
+		if err := SortTasks(results, tt.key, tt.reverse); err != nil {
+			t.Fatalf("SortTasks(%s) failed: %v", tt.key, err)
+		}
+		var got []string
+		for _, r := range results {
+			got = append(got, r.Task.ID)
+		}
+		if strings.Join(got, ",") != tt.want {
+			t.Errorf("SortTasks(%s, reverse=%v) = %s, want %s", tt.key, tt.reverse, strings.Join(got, ","), tt.want)
+		}
+	}
+
+	if err := SortTasks(results, "title", false); err == nil {
+		t.Error("expected error for unknown sort key")
+	}
+}
+
+// TestListTasksPriorityRange tests filtering on a range of priorities.
+func TestListTasksPriorityRange(t *testing.T) {
+	s, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	for p := MinPriority; p <= MaxPriority; p++ {
+		AddTask(s, "TS", fmt.Sprintf("P%d task", p), TaskOptions{Priority: p})
+	}
+
+	tests := []struct {
+		min, max int
+		want     string
+	}{
+		{1, 2, "TS-01,TS-02"},
+		{3, 0, "TS-03,TS-04"},
+		{0, 1, "TS-01"},
+		{2, 2, "TS-02"},
+	}
+	for _, tt := range tests {
+		results, err := ListTasks(s, TaskFilter{Project: "TS", MinPriority: tt.min, MaxPriority: tt.max})
+		if err != nil {
+			t.Fatalf("ListTasks failed: %v", err)
+		}
+		var ids []string
+		for _, r := range results {
+			ids = append(ids, r.Task.ID)
+		}
+		if got := strings.Join(ids, ","); got != tt.want {
+			t.Errorf("priority %d..%d: got %s, want %s", tt.min, tt.max, got, tt.want)
+		}
+	}
+}
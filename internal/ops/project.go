@@ -3,6 +3,7 @@ package ops
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,9 +12,11 @@ import (
 
 // ProjectChanges represents fields that can be updated on a project.
 type ProjectChanges struct {
-	Name        *string
-	Description *string
-	Status      *model.ProjectStatus
+	Name            *string
+	Description     *string
+	Status          *model.ProjectStatus
+	DefaultAssignee *string
+	Notes           *string
 }
 
 // CreateProject creates a new project with the given parameters.
@@ -26,14 +29,8 @@ func CreateProject(s Store, id, prefix, name, description string) error {
 		return fmt.Errorf("project with prefix %q already exists", prefix)
 	}
 
-	// Validate prefix format (2-3 uppercase letters)
-	if len(prefix) < 2 || len(prefix) > 3 {
-		return fmt.Errorf("prefix must be 2-3 characters, got %q", prefix)
-	}
-	for _, c := range prefix {
-		if c < 'A' || c > 'Z' {
-			return fmt.Errorf("prefix must contain only letters, got %q", prefix)
-		}
+	if err := validatePrefix(prefix); err != nil {
+		return err
 	}
 
 	// Derive ID from prefix if not provided
@@ -64,6 +61,27 @@ func CreateProject(s Store, id, prefix, name, description string) error {
 	return s.SaveProject(project)
 }
 
+// SimilarPrefixes returns the existing project prefixes within one edit of
+// prefix (e.g. BY for BV), which invite mistyped IDs. An exact match is not
+// included; CreateProject already rejects it.
+func SimilarPrefixes(s Store, prefix string) ([]string, error) {
+	prefix = strings.ToUpper(prefix)
+	prefixes, err := s.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	var similar []string
+	for _, existing := range prefixes {
+		existing = strings.ToUpper(existing)
+		if existing != prefix && editDistance([]rune(existing), []rune(prefix)) <= 1 {
+			similar = append(similar, existing)
+		}
+	}
+	sort.Strings(similar)
+	return similar, nil
+}
+
 // EditProject updates project metadata.
 func EditProject(s Store, prefix string, changes ProjectChanges) error {
 	pf, err := s.LoadProject(prefix)
@@ -80,6 +98,12 @@ func EditProject(s Store, prefix string, changes ProjectChanges) error {
 	if changes.Status != nil {
 		pf.Status = *changes.Status
 	}
+	if changes.DefaultAssignee != nil {
+		pf.DefaultAssignee = *changes.DefaultAssignee
+	}
+	if changes.Notes != nil {
+		pf.Notes = *changes.Notes
+	}
 
 	return s.SaveProject(pf)
 }
@@ -110,6 +134,198 @@ func DeleteProject(s Store, prefix string, force bool) error {
 	return s.DeleteProject(prefix)
 }
 
+// CompleteProject marks a project done. projectRef must name the project
+// exactly (prefix or ID), since force drops its open items. It fails,
+// listing the open items, if any tasks or waits are still open, unless force
+// is set, in which case they are dropped first. It returns the IDs of the
+// dropped items.
+func CompleteProject(s Store, projectRef string, force bool) ([]string, error) {
+	pf, err := ResolveProject(s, projectRef)
+	if err != nil {
+		return nil, err
+	}
+	if pf.Status == model.ProjectStatusDone {
+		return nil, fmt.Errorf("project %s is already done", pf.ID)
+	}
+
+	var open []string
+	for _, t := range pf.Tasks {
+		if t.Status == model.TaskStatusOpen {
+			open = append(open, t.ID)
+		}
+	}
+	for _, w := range pf.Waits {
+		if w.Status == model.WaitStatusOpen {
+			open = append(open, w.ID)
+		}
+	}
+	if len(open) > 0 && !force {
+		return nil, fmt.Errorf("project %s still has open items: %s (use --force to drop them)", pf.ID, strings.Join(open, ", "))
+	}
+
+	now := time.Now()
+	for i := range pf.Tasks {
+		if t := &pf.Tasks[i]; t.Status == model.TaskStatusOpen {
+			t.Status = model.TaskStatusDropped
+			t.DroppedAt = &now
+			t.DropReason = "project completed"
+			t.Updated = now
+		}
+	}
+	for i := range pf.Waits {
+		if w := &pf.Waits[i]; w.Status == model.WaitStatusOpen {
+			w.Status = model.WaitStatusDropped
+			w.DroppedAt = &now
+			w.DropReason = "project completed"
+		}
+	}
+	pf.Status = model.ProjectStatusDone
+
+	if err := s.SaveProject(pf); err != nil {
+		return nil, err
+	}
+	return open, nil
+}
+
+// GetArchive returns the archive of closed items moved out of the project
+// identified by ref (ID or prefix). A project that has never been archived
+// has an empty archive.
+func GetArchive(s Store, ref string) (*model.ProjectFile, error) {
+	pf, err := LookupProject(s, ref)
+	if err != nil {
+		return nil, err
+	}
+	archive, err := s.LoadArchive(pf.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	if archive == nil {
+		archive = &model.ProjectFile{Project: pf.Project}
+	}
+	return archive, nil
+}
+
+// ArchiveResult reports what ArchiveProject moved.
+type ArchiveResult struct {
+	Prefix string   // the archived project's prefix
+	Tasks  []string // IDs of archived tasks
+	Waits  []string // IDs of archived waits
+	Kept   []string // closed items left in place because a remaining item is blocked by them
+}
+
+// ArchiveProject moves the done and dropped tasks and waits of a project (by
+// prefix or ID) into its archive file, merging with anything archived before,
+// and leaves open items in the project file. A closed item that a remaining
+// item still lists in blocked_by stays put, so the project file never refers
+// to items it no longer holds. next_id is left alone, so archived IDs are
+// never reused. Like the other project management operations, it works on
+// projects of any status.
+func ArchiveProject(s Store, projectRef string) (*ArchiveResult, error) {
+	pf, err := ResolveProject(s, projectRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start with the open items and add, until nothing changes, every item
+	// blocking one that stays.
+	key := func(id string) string { return strings.ToUpper(model.NormalizeID(id, 0)) }
+	stays := make(map[string]bool)
+	for _, t := range pf.Tasks {
+		if t.Status == model.TaskStatusOpen {
+			stays[key(t.ID)] = true
+		}
+	}
+	for _, w := range pf.Waits {
+		if w.Status == model.WaitStatusOpen {
+			stays[key(w.ID)] = true
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		keep := func(id string, blockedBy []string) {
+			if !stays[key(id)] {
+				return
+			}
+			for _, b := range blockedBy {
+				if !stays[key(b)] {
+					stays[key(b)] = true
+					changed = true
+				}
+			}
+		}
+		for _, t := range pf.Tasks {
+			keep(t.ID, t.BlockedBy)
+		}
+		for _, w := range pf.Waits {
+			keep(w.ID, w.BlockedBy)
+		}
+	}
+
+	archive, err := s.LoadArchive(pf.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	if archive == nil {
+		archive = &model.ProjectFile{}
+	}
+	tasks, waits := archive.Tasks, archive.Waits
+	archive.Project = pf.Project
+
+	result := &ArchiveResult{Prefix: pf.Prefix}
+	var openTasks []model.Task
+	for _, t := range pf.Tasks {
+		if !stays[key(t.ID)] {
+			result.Tasks = append(result.Tasks, t.ID)
+			tasks = append(tasks, t)
+			continue
+		}
+		if t.Status != model.TaskStatusOpen {
+			result.Kept = append(result.Kept, t.ID)
+		}
+		openTasks = append(openTasks, t)
+	}
+	var openWaits []model.Wait
+	for _, w := range pf.Waits {
+		if !stays[key(w.ID)] {
+			result.Waits = append(result.Waits, w.ID)
+			waits = append(waits, w)
+			continue
+		}
+		if w.Status != model.WaitStatusOpen {
+			result.Kept = append(result.Kept, w.ID)
+		}
+		openWaits = append(openWaits, w)
+	}
+	if len(result.Tasks) == 0 && len(result.Waits) == 0 {
+		return result, nil
+	}
+
+	// Write the archive first: if saving the project then fails, the items
+	// are in both files rather than in neither.
+	archive.Tasks, archive.Waits = tasks, waits
+	if err := s.SaveArchive(archive); err != nil {
+		return nil, err
+	}
+	pf.Tasks, pf.Waits = openTasks, openWaits
+	if err := s.SaveProject(pf); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// validatePrefix checks that an uppercased project prefix is 2-3 letters.
+func validatePrefix(prefix string) error {
+	if len(prefix) < 2 || len(prefix) > 3 {
+		return fmt.Errorf("prefix must be 2-3 characters, got %q", prefix)
+	}
+	for _, c := range prefix {
+		if c < 'A' || c > 'Z' {
+			return fmt.Errorf("prefix must contain only letters, got %q", prefix)
+		}
+	}
+	return nil
+}
+
 // ChangeProjectPrefix changes a project's prefix and updates all task/wait IDs.
 func ChangeProjectPrefix(s Store, oldPrefix, newPrefix string) error {
 	oldPrefix = strings.ToUpper(oldPrefix)
@@ -119,14 +335,8 @@ func ChangeProjectPrefix(s Store, oldPrefix, newPrefix string) error {
 		return fmt.Errorf("new prefix is the same as old prefix")
 	}
 
-	// Validate new prefix format
-	if len(newPrefix) < 2 || len(newPrefix) > 3 {
-		return fmt.Errorf("prefix must be 2-3 characters, got %q", newPrefix)
-	}
-	for _, c := range newPrefix {
-		if c < 'A' || c > 'Z' {
-			return fmt.Errorf("prefix must contain only letters, got %q", newPrefix)
-		}
+	if err := validatePrefix(newPrefix); err != nil {
+		return err
 	}
 
 	// Check if new prefix is in use
@@ -140,34 +350,50 @@ func ChangeProjectPrefix(s Store, oldPrefix, newPrefix string) error {
 		return err
 	}
 
+	// Archived items are renumbered along with the project's own
+	archive, err := s.LoadArchive(oldPrefix)
+	if err != nil {
+		return err
+	}
+	files := []*model.ProjectFile{pf}
+	if archive != nil {
+		files = append(files, archive)
+	}
+
 	// Build a mapping of old IDs to new IDs
 	idMap := make(map[string]string)
 
-	// Update task IDs
 	maxID := pf.NextID - 1
-	for i := range pf.Tasks {
-		oldID := pf.Tasks[i].ID
-		_, num, _, _ := model.ParseAnyID(oldID)
-		newID := model.FormatTaskID(newPrefix, num, maxID)
-		idMap[oldID] = newID
-		pf.Tasks[i].ID = newID
-	}
+	for _, f := range files {
+		// Update task IDs
+		for i := range f.Tasks {
+			oldID := f.Tasks[i].ID
+			_, num, _, _ := model.ParseAnyID(oldID)
+			newID := model.FormatTaskID(newPrefix, num, maxID)
+			idMap[oldID] = newID
+			f.Tasks[i].ID = newID
+		}
 
-	// Update wait IDs
-	for i := range pf.Waits {
-		oldID := pf.Waits[i].ID
-		_, num, _, _ := model.ParseAnyID(oldID)
-		newID := model.FormatWaitID(newPrefix, num, maxID)
-		idMap[oldID] = newID
-		pf.Waits[i].ID = newID
+		// Update wait IDs
+		for i := range f.Waits {
+			oldID := f.Waits[i].ID
+			_, num, _, _ := model.ParseAnyID(oldID)
+			newID := model.FormatWaitID(newPrefix, num, maxID)
+			idMap[oldID] = newID
+			f.Waits[i].ID = newID
+		}
 	}
 
 	// Update all blocked_by references
-	for i := range pf.Tasks {
-		pf.Tasks[i].BlockedBy = updateBlockerRefs(pf.Tasks[i].BlockedBy, idMap)
-	}
-	for i := range pf.Waits {
-		pf.Waits[i].BlockedBy = updateBlockerRefs(pf.Waits[i].BlockedBy, idMap)
+	for _, f := range files {
+		for i := range f.Tasks {
+			f.Tasks[i].BlockedBy = updateBlockerRefs(f.Tasks[i].BlockedBy, idMap)
+			f.Tasks[i].BlockReasons = updateBlockReasons(f.Tasks[i].BlockReasons, idMap)
+		}
+		for i := range f.Waits {
+			f.Waits[i].BlockedBy = updateBlockerRefs(f.Waits[i].BlockedBy, idMap)
+			f.Waits[i].BlockReasons = updateBlockReasons(f.Waits[i].BlockReasons, idMap)
+		}
 	}
 
 	// Update the project prefix
@@ -177,9 +403,189 @@ func ChangeProjectPrefix(s Store, oldPrefix, newPrefix string) error {
 	if err := s.SaveProject(pf); err != nil {
 		return err
 	}
+	if archive != nil {
+		archive.Project = pf.Project
+		if err := s.SaveArchive(archive); err != nil {
+			return err
+		}
+	}
+
+	// Delete old project file (and its archive)
+	if err := s.DeleteProject(oldPrefix); err != nil {
+		return err
+	}
 
-	// Delete old project file
-	return s.DeleteProject(oldPrefix)
+	return updateProjectRefs(s, oldPrefix, newPrefix)
+}
+
+// updateProjectRefs repoints whole-project blocker references (@OLD) in
+// every project to a renamed prefix.
+func updateProjectRefs(s Store, oldPrefix, newPrefix string) error {
+	refMap := map[string]string{model.FormatProjectRef(oldPrefix): model.FormatProjectRef(newPrefix)}
+
+	prefixes, err := s.ListProjects()
+	if err != nil {
+		return err
+	}
+	for _, prefix := range prefixes {
+		pf, err := s.LoadProject(prefix)
+		if err != nil {
+			return err
+		}
+		if !containsFold(pf.ProjectRefs(), model.FormatProjectRef(oldPrefix)) {
+			continue
+		}
+		for i := range pf.Tasks {
+			pf.Tasks[i].BlockedBy = updateBlockerRefs(pf.Tasks[i].BlockedBy, refMap)
+			pf.Tasks[i].BlockReasons = updateBlockReasons(pf.Tasks[i].BlockReasons, refMap)
+		}
+		for i := range pf.Waits {
+			pf.Waits[i].BlockedBy = updateBlockerRefs(pf.Waits[i].BlockedBy, refMap)
+			pf.Waits[i].BlockReasons = updateBlockReasons(pf.Waits[i].BlockReasons, refMap)
+		}
+		if err := s.SaveProject(pf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenameProjectID changes the ID of the project with the given prefix, and
+// of its archive if it has one. The prefix and all task and wait IDs are
+// unchanged. If default_project in the config refers to the old ID, it is
+// updated through cw; configUpdated reports whether that happened.
+func RenameProjectID(s Store, cw ConfigWriter, prefix, newID string) (configUpdated bool, err error) {
+	newID = strings.ToLower(strings.TrimSpace(newID))
+	if newID == "" {
+		return false, fmt.Errorf("project ID cannot be empty")
+	}
+	if strings.ContainsAny(newID, " \t/\\") {
+		return false, fmt.Errorf("project ID must not contain whitespace or slashes, got %q", newID)
+	}
+
+	pf, err := s.LoadProject(prefix)
+	if err != nil {
+		return false, err
+	}
+
+	oldID := pf.ID
+	if oldID == newID {
+		return false, fmt.Errorf("new ID is the same as old ID")
+	}
+	if _, err := s.LoadProjectByID(newID); err == nil {
+		return false, fmt.Errorf("project with ID %q already exists", newID)
+	}
+
+	archive, err := s.LoadArchive(pf.Prefix)
+	if err != nil {
+		return false, err
+	}
+
+	pf.ID = newID
+	if err := s.SaveProject(pf); err != nil {
+		return false, err
+	}
+	if archive != nil {
+		archive.ID = newID
+		if err := s.SaveArchive(archive); err != nil {
+			return false, err
+		}
+	}
+
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return false, err
+	}
+	if cfg.DefaultProject != oldID {
+		return false, nil
+	}
+	if err := cw.SetConfigValue("default_project", newID); err != nil {
+		return false, fmt.Errorf("project renamed but failed to update default_project: %w", err)
+	}
+	return true, nil
+}
+
+// CopyOptions controls how CopyProject duplicates a project.
+type CopyOptions struct {
+	ID          string // new project ID; derived from the prefix if empty
+	Prefix      string // new project prefix
+	Name        string // new project name; the source's name if empty
+	ResetStatus bool   // reopen done and dropped items and clear how they closed
+}
+
+// CopyProject duplicates the project with prefix srcPrefix, including its
+// tasks, waits, and dependencies, into a new active project. Items keep
+// their numbers under the new prefix (BY-07 becomes BZ-07), and blockers
+// within the project are rewired to the copies. Blockers outside it, such as
+// whole-project references, are kept as they are. Copied items are stamped
+// as created now.
+func CopyProject(s Store, srcPrefix string, opts CopyOptions) (*model.ProjectFile, error) {
+	src, err := s.LoadProject(srcPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = src.Name
+	}
+	if err := CreateProject(s, opts.ID, opts.Prefix, name, src.Description); err != nil {
+		return nil, err
+	}
+	prefix := strings.ToUpper(opts.Prefix)
+	pf, err := s.LoadProject(prefix)
+	if err != nil {
+		s.DeleteProject(prefix)
+		return nil, err
+	}
+	pf.DefaultAssignee = src.DefaultAssignee
+	pf.Notes = src.Notes
+	pf.NextID = src.NextID
+
+	// Map every old ID to its copy before rewiring, since blockers can point
+	// at items later in the file
+	idMap := make(map[string]string)
+	maxID := src.NextID - 1
+	for _, t := range src.Tasks {
+		idMap[t.ID] = model.FormatTaskID(pf.Prefix, model.ExtractNumber(t.ID), maxID)
+	}
+	for _, w := range src.Waits {
+		idMap[w.ID] = model.FormatWaitID(pf.Prefix, model.ExtractNumber(w.ID), maxID)
+	}
+
+	now := time.Now()
+	for _, t := range src.Tasks {
+		t.ID = idMap[t.ID]
+		t.BlockedBy = updateBlockerRefs(t.BlockedBy, idMap)
+		t.BlockReasons = updateBlockReasons(t.BlockReasons, idMap)
+		t.Tags = append([]string(nil), t.Tags...)
+		t.Created, t.Updated = now, now
+		if opts.ResetStatus {
+			t.Status = model.TaskStatusOpen
+			t.DoneAt, t.DroppedAt, t.DropReason = nil, nil, ""
+			t.SnoozedUntil = nil
+		}
+		pf.Tasks = append(pf.Tasks, t)
+	}
+	for _, w := range src.Waits {
+		w.ID = idMap[w.ID]
+		w.BlockedBy = updateBlockerRefs(w.BlockedBy, idMap)
+		w.BlockReasons = updateBlockReasons(w.BlockReasons, idMap)
+		w.Created = now
+		if opts.ResetStatus {
+			w.Status = model.WaitStatusOpen
+			w.DoneAt, w.DroppedAt, w.DropReason = nil, nil, ""
+			w.Resolution = ""
+		}
+		pf.Waits = append(pf.Waits, w)
+	}
+
+	if err := s.SaveProject(pf); err != nil {
+		// Don't leave the empty project behind
+		s.DeleteProject(prefix)
+		return nil, err
+	}
+	return pf, nil
 }
 
 // updateBlockerRefs updates blocker references using the provided ID mapping.
@@ -198,3 +604,184 @@ func updateBlockerRefs(blockedBy []string, idMap map[string]string) []string {
 	}
 	return result
 }
+
+// updateBlockReasons re-keys blocker reasons using the provided ID mapping.
+func updateBlockReasons(reasons map[string]string, idMap map[string]string) map[string]string {
+	if len(reasons) == 0 {
+		return reasons
+	}
+
+	result := make(map[string]string, len(reasons))
+	for id, reason := range reasons {
+		if newID, ok := idMap[id]; ok {
+			id = newID
+		}
+		result[id] = reason
+	}
+	return result
+}
+
+// ExportProject returns a copy of the project identified by ref (ID or
+// prefix) for writing to a bundle. When portable is set the copy is prepared
+// for import into another tk directory: per-user snooze state is stripped,
+// and an error is returned if any blocker reference points outside the
+// project, since it could not be resolved on import.
+func ExportProject(s Store, ref string, portable bool) (*model.ProjectFile, error) {
+	pf, err := ResolveProject(s, ref)
+	if err != nil {
+		return nil, err
+	}
+	if !portable {
+		return pf, nil
+	}
+
+	if refs := externalReferences(pf); len(refs) > 0 {
+		return nil, fmt.Errorf("project %s is not self-contained; references outside it: %s", pf.Prefix, strings.Join(refs, ", "))
+	}
+	for i := range pf.Tasks {
+		pf.Tasks[i].SnoozedUntil = nil
+	}
+	return pf, nil
+}
+
+// ImportProject saves a project read from an export bundle as a new project.
+// The prefix and ID must not already be in use, every item must carry the
+// project's prefix, and blocker references must resolve inside the bundle.
+// The bundle must also pass the checks tk validate runs, so duplicate or
+// malformed IDs, cycles, and missing fields are refused before anything is
+// written. Every task is marked as imported.
+func ImportProject(s Store, pf *model.ProjectFile) error {
+	pf.Prefix = strings.ToUpper(pf.Prefix)
+	if err := validatePrefix(pf.Prefix); err != nil {
+		return err
+	}
+	if s.ProjectExists(pf.Prefix) {
+		return fmt.Errorf("project with prefix %q already exists", pf.Prefix)
+	}
+
+	if pf.ID == "" {
+		pf.ID = strings.ToLower(pf.Prefix)
+	}
+	pf.ID = strings.ToLower(pf.ID)
+	if _, err := s.LoadProjectByID(pf.ID); err == nil {
+		return fmt.Errorf("project with ID %q already exists", pf.ID)
+	}
+	if pf.Status == "" {
+		pf.Status = model.ProjectStatusActive
+	}
+
+	// Malformed IDs are left to validation below, which names the problem
+	for i := range pf.Tasks {
+		t := &pf.Tasks[i]
+		if model.IsTaskID(t.ID) && model.ExtractPrefix(t.ID) != pf.Prefix {
+			return fmt.Errorf("task %s does not belong to project %s", t.ID, pf.Prefix)
+		}
+		t.Source = model.TaskSourceImport
+	}
+	for _, w := range pf.Waits {
+		if model.IsWaitID(w.ID) && model.ExtractPrefix(w.ID) != pf.Prefix {
+			return fmt.Errorf("wait %s does not belong to project %s", w.ID, pf.Prefix)
+		}
+	}
+	if refs := externalReferences(pf); len(refs) > 0 {
+		return fmt.Errorf("bundle is not self-contained; references outside it: %s", strings.Join(refs, ", "))
+	}
+
+	// Never hand out an ID that the bundle already uses
+	if maxNum := maxItemNumber(pf); pf.NextID <= maxNum {
+		pf.NextID = maxNum + 1
+	}
+
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return err
+	}
+	var problems []string
+	for _, e := range validateProjectFile(s, pf, cfg.DroppedUnblocks) {
+		// A time wait that passed while the bundle sat around is resolved
+		// by the next tk check; it isn't a defect in the bundle
+		if e.Type != ValidationErrorStaleWait {
+			problems = append(problems, e.Error())
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("bundle failed validation: %s", strings.Join(problems, "; "))
+	}
+
+	return s.SaveProject(pf)
+}
+
+// externalReferences lists blocker references in pf that do not resolve to
+// one of its own tasks or waits, formatted as "ITEM -> BLOCKER".
+func externalReferences(pf *model.ProjectFile) []string {
+	ids := make(map[string]bool)
+	for _, t := range pf.Tasks {
+		ids[strings.ToUpper(t.ID)] = true
+	}
+	for _, w := range pf.Waits {
+		ids[strings.ToUpper(w.ID)] = true
+	}
+
+	var refs []string
+	check := func(itemID string, blockedBy []string) {
+		for _, b := range blockedBy {
+			if !ids[strings.ToUpper(b)] {
+				refs = append(refs, itemID+" -> "+b)
+			}
+		}
+	}
+	for _, t := range pf.Tasks {
+		check(t.ID, t.BlockedBy)
+	}
+	for _, w := range pf.Waits {
+		check(w.ID, w.BlockedBy)
+	}
+	return refs
+}
+
+// ProjectDependency is an edge in the project-level dependency graph:
+// Count items in Project are blocked by items in DependsOn.
+type ProjectDependency struct {
+	Project   string
+	DependsOn string
+	Count     int
+}
+
+// ProjectDependencies aggregates the cross-project blocker references in
+// projects, including whole-project references like @BY, into project-level
+// edges sorted by project then dependency. Blockers inside the same project
+// are ignored.
+func ProjectDependencies(projects []*model.ProjectFile) []ProjectDependency {
+	counts := make(map[[2]string]int)
+	add := func(pf *model.ProjectFile, blockedBy []string) {
+		for _, b := range blockedBy {
+			prefix := model.ExtractPrefix(b)
+			if ref, ok := model.ParseProjectRef(b); ok {
+				prefix = ref
+			}
+			if prefix != "" && prefix != pf.Prefix {
+				counts[[2]string{pf.Prefix, prefix}]++
+			}
+		}
+	}
+	for _, pf := range projects {
+		for _, t := range pf.Tasks {
+			add(pf, t.BlockedBy)
+		}
+		for _, w := range pf.Waits {
+			add(pf, w.BlockedBy)
+		}
+	}
+
+	deps := make([]ProjectDependency, 0, len(counts))
+	for edge, n := range counts {
+		deps = append(deps, ProjectDependency{Project: edge[0], DependsOn: edge[1], Count: n})
+	}
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].Project != deps[j].Project {
+			return deps[i].Project < deps[j].Project
+		}
+		return deps[i].DependsOn < deps[j].DependsOn
+	})
+	return deps
+}
@@ -1,7 +1,9 @@
 package ops
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -22,19 +24,73 @@ func ResolveProject(s Store, ref string) (*model.ProjectFile, error) {
 		}
 		pf, err := s.LoadProjectByID(cfg.DefaultProject)
 		if err != nil {
-			return nil, fmt.Errorf("default project %q not found", cfg.DefaultProject)
+			return nil, fmt.Errorf("default_project in config: %w", &NotFoundError{Kind: "project", ItemID: cfg.DefaultProject})
 		}
 		return pf, nil
 	}
 
+	// Fall back to the ID only when nothing was found, so a project that
+	// exists but fails to load reports why
+	var notFound *NotFoundError
 	pf, err := s.LoadProject(ref)
-	if err != nil {
+	if errors.As(err, &notFound) {
 		pf, err = s.LoadProjectByID(ref)
-		if err != nil {
-			return nil, fmt.Errorf("project %q not found", ref)
+	}
+	return pf, err
+}
+
+// LookupProject is ResolveProject for read-only commands: if no project
+// matches exactly, a partial ID or name is accepted when it identifies a
+// single project. Anything that changes or writes out a project uses
+// ResolveProject, so a loose reference can't pick the wrong one.
+func LookupProject(s Store, ref string) (*model.ProjectFile, error) {
+	pf, err := ResolveProject(s, ref)
+	var notFound *NotFoundError
+	if ref != "" && errors.As(err, &notFound) {
+		return fuzzyResolveProject(s, ref)
+	}
+	return pf, err
+}
+
+// fuzzyResolveProject matches a partial reference against project IDs and
+// names, case-insensitively. Prefix matches on the ID win over substring
+// matches on the ID or name. Exactly one match is required; otherwise the
+// error lists the candidates.
+func fuzzyResolveProject(s Store, ref string) (*model.ProjectFile, error) {
+	projects, err := loadProjects(s, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	needle := strings.ToLower(ref)
+	var prefixMatches, substringMatches []*model.ProjectFile
+	for _, pf := range projects {
+		id := strings.ToLower(pf.ID)
+		switch {
+		case strings.HasPrefix(id, needle):
+			prefixMatches = append(prefixMatches, pf)
+		case strings.Contains(id, needle), strings.Contains(strings.ToLower(pf.Name), needle):
+			substringMatches = append(substringMatches, pf)
 		}
 	}
-	return pf, nil
+
+	matches := prefixMatches
+	if len(matches) == 0 {
+		matches = substringMatches
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, &NotFoundError{Kind: "project", ItemID: ref}
+	case 1:
+		return matches[0], nil
+	default:
+		candidates := make([]string, len(matches))
+		for i, pf := range matches {
+			candidates[i] = fmt.Sprintf("%s (%s)", pf.ID, pf.Prefix)
+		}
+		return nil, fmt.Errorf("project %q is ambiguous: matches %s", ref, strings.Join(candidates, ", "))
+	}
 }
 
 // AutoCheck runs auto-resolution if configured, silently ignoring errors.
@@ -49,8 +105,19 @@ func AutoCheck(s Store) {
 }
 
 // LoadActiveProjects returns all project files for active projects.
-// If includeAll is true, includes paused and done projects too.
+// If includeAll is true, includes paused and done projects too. Projects
+// listed in the ignored_projects config are always left out.
 func LoadActiveProjects(s Store, includeAll bool) ([]*model.ProjectFile, error) {
+	return loadProjects(s, includeAll, true)
+}
+
+// loadProjects returns project files, skipping non-active projects unless
+// includeAll is set and ignored projects when skipIgnored is set.
+func loadProjects(s Store, includeAll, skipIgnored bool) ([]*model.ProjectFile, error) {
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
 	prefixes, err := s.ListProjects()
 	if err != nil {
 		return nil, err
@@ -64,6 +131,9 @@ func LoadActiveProjects(s Store, includeAll bool) ([]*model.ProjectFile, error)
 		if !includeAll && pf.Status != model.ProjectStatusActive {
 			continue
 		}
+		if skipIgnored && cfg.IsProjectIgnored(pf.Prefix, pf.ID) {
+			continue
+		}
 		projects = append(projects, pf)
 	}
 	return projects, nil
@@ -71,19 +141,49 @@ func LoadActiveProjects(s Store, includeAll bool) ([]*model.ProjectFile, error)
 
 // TaskFilter specifies filtering criteria for listing tasks.
 type TaskFilter struct {
-	Project  string           // Limit to a specific project (prefix or ID). Empty = all active.
-	State    *model.TaskState // Filter by derived state. Nil = open tasks only.
-	All      bool             // Show all tasks regardless of status.
-	Priority int              // Filter by priority (0 = any).
-	Tags     []string         // Require all specified tags (AND logic).
-	Overdue  bool             // Only tasks with due date in the past.
+	Project  string            // Limit to a specific project (prefix or ID). Empty = all active.
+	State    *model.TaskState  // Filter by derived state. Nil = open tasks only.
+	Exclude  []model.TaskState // Drop tasks in any of these derived states.
+	All      bool              // Show all tasks regardless of status.
+	Priority int               // Filter by priority (0 = any).
+	Tags     []string          // Require all specified tags (AND logic).
+	Overdue  bool              // Only tasks with due date before today.
+
+	Assignee   string // Only tasks assigned to this person, case-insensitively (empty = any).
+	Unassigned bool   // Only tasks with no assignee.
+
+	MinPriority int // Only priorities numbered at least this (0 = no bound).
+	MaxPriority int // Only priorities numbered at most this (0 = no bound).
+
+	BlockedBy string // Only tasks downstream of this task or wait ID.
+	Direct    bool   // With BlockedBy, only tasks blocked directly (one level).
+	WaitingOn string // Only waiting tasks directly blocked by this wait ID.
+	Agenda    bool   // Only tasks that are overdue, due today, or inside their reminder window.
+
+	DueWithin *time.Duration // Only tasks due on or before the day now+duration falls on, overdue included.
+
+	CreatedAfter  *time.Time // Only tasks created at or after this time.
+	CreatedBefore *time.Time // Only tasks created before this time.
+	DoneAfter     *time.Time // Only tasks completed at or after this time.
+
+	HideSnoozed bool // Leave out tasks snoozed past now (ignored with All); set by list and ready.
+
+	// ReadySoon widens a ready State filter to waiting tasks whose only open
+	// blockers are waits due within this window (see waitResolvesSoon).
+	// Such tasks keep their waiting state in the results.
+	ReadySoon time.Duration
+}
+
+// isSnoozed reports whether a task is soft-deferred past now.
+func isSnoozed(t *model.Task, now time.Time) bool {
+	return t.SnoozedUntil != nil && now.Before(*t.SnoozedUntil)
 }
 
 // TaskResult is a single task with its computed state.
 type TaskResult struct {
-	Task    model.Task
-	State   model.TaskState
-	Project string // project prefix
+	Task    model.Task      `json:"task"`
+	State   model.TaskState `json:"state"`
+	Project string          `json:"project"` // project prefix
 }
 
 // ListTasks returns tasks matching the given filter across projects.
@@ -93,14 +193,50 @@ func ListTasks(s Store, filter TaskFilter) ([]TaskResult, error) {
 		return nil, err
 	}
 
+	var downstream map[string]bool
+	if filter.BlockedBy != "" {
+		downstream, err = downstreamOf(s, filter.BlockedBy, filter.Direct)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var waitDependents map[string]bool
+	if filter.WaitingOn != "" {
+		if !model.IsWaitID(filter.WaitingOn) {
+			return nil, fmt.Errorf("%s is not a wait ID", filter.WaitingOn)
+		}
+		waitDependents, err = downstreamOf(s, filter.WaitingOn, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	var results []TaskResult
 
+	widenReady := filter.ReadySoon > 0 && filter.State != nil && *filter.State == model.TaskStateReady
+
 	for _, pf := range projects {
-		blockerStates := ComputeBlockerStates(pf)
+		blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
 		for _, t := range pf.Tasks {
+			if downstream != nil && !downstream[t.ID] {
+				continue
+			}
 			state := model.ComputeTaskState(&t, blockerStates)
-			if !matchesTaskFilter(&t, state, blockerStates, filter, now) {
+			if waitDependents != nil && (!waitDependents[t.ID] || state != model.TaskStateWaiting) {
+				continue
+			}
+			matchState := state
+			if widenReady && state == model.TaskStateWaiting && waitsResolveSoon(pf, &t, blockerStates, now, filter.ReadySoon) {
+				matchState = model.TaskStateReady
+			}
+			if !matchesTaskFilter(&t, matchState, blockerStates, filter, now) {
 				continue
 			}
 			results = append(results, TaskResult{
@@ -114,7 +250,186 @@ func ListTasks(s Store, filter TaskFilter) ([]TaskResult, error) {
 	return results, nil
 }
 
+// SortByUrgency orders task results for planning: priority first (1 is
+// highest), then earliest due date (tasks without one last), then oldest
+// creation time, with project and ID as final tie-breakers so the order is
+// stable across runs.
+func SortByUrgency(results []TaskResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i].Task, results[j].Task
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		if (a.DueDate == nil) != (b.DueDate == nil) {
+			return a.DueDate != nil
+		}
+		if a.DueDate != nil && !a.DueDate.Equal(*b.DueDate) {
+			return a.DueDate.Before(*b.DueDate)
+		}
+		if !a.Created.Equal(b.Created) {
+			return a.Created.Before(b.Created)
+		}
+		if results[i].Project != results[j].Project {
+			return results[i].Project < results[j].Project
+		}
+		return model.ExtractNumber(a.ID) < model.ExtractNumber(b.ID)
+	})
+}
+
+// SortByID orders task results by project prefix, then task number.
+func SortByID(results []TaskResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Project != results[j].Project {
+			return results[i].Project < results[j].Project
+		}
+		return model.ExtractNumber(results[i].Task.ID) < model.ExtractNumber(results[j].Task.ID)
+	})
+}
+
+// NextTask returns the single ready task to work on next: the first row of
+// `tk ready --sort=score`, ranked by SortByScore with the configured
+// weights. Like tk ready, an empty projectRef considers all active projects
+// and ready_includes_soon widens the ready set. It returns nil if no task is
+// ready.
+func NextTask(s Store, projectRef string) (*TaskResult, error) {
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	ready := model.TaskStateReady
+	results, err := ListTasks(s, TaskFilter{
+		Project:     projectRef,
+		State:       &ready,
+		ReadySoon:   time.Duration(cfg.ReadyIncludesSoon) * 24 * time.Hour,
+		HideSnoozed: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+	if err := SortByScore(s, results, cfg.ScoreWeights, time.Now()); err != nil {
+		return nil, err
+	}
+	return &results[0], nil
+}
+
+// TaskSortKeys are the fields SortTasks can order by.
+var TaskSortKeys = []string{"priority", "due", "created", "updated", "id"}
+
+// SortTasks orders task results by the given key: priority (1 first), due
+// (soonest first, tasks without a due date last), created or updated
+// (oldest first), or id. Ties keep ID order. reverse flips the key's order,
+// but tasks without a due date still sort last.
+func SortTasks(results []TaskResult, key string, reverse bool) error {
+	var less func(a, b *model.Task) bool
+	switch key {
+	case "priority":
+		less = func(a, b *model.Task) bool { return a.Priority < b.Priority }
+	case "due":
+		less = func(a, b *model.Task) bool {
+			return a.DueDate != nil && b.DueDate != nil && a.DueDate.Before(*b.DueDate)
+		}
+	case "created":
+		less = func(a, b *model.Task) bool { return a.Created.Before(b.Created) }
+	case "updated":
+		less = func(a, b *model.Task) bool { return a.Updated.Before(b.Updated) }
+	case "id":
+		SortByID(results)
+		if reverse {
+			for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+				results[i], results[j] = results[j], results[i]
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid sort: %s (expected %s)", key, strings.Join(TaskSortKeys, "/"))
+	}
+
+	SortByID(results)
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := &results[i].Task, &results[j].Task
+		if key == "due" && (a.DueDate == nil) != (b.DueDate == nil) {
+			return a.DueDate != nil
+		}
+		if reverse {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+	return nil
+}
+
+// waitsResolveSoon reports whether every open blocker of a waiting task is a
+// wait that resolves within window of now: a time wait whose 'after' falls in
+// the window, or a manual wait whose check_after does. Dormant waits never
+// count, since they cannot resolve until their own blockers do.
+func waitsResolveSoon(pf *model.ProjectFile, t *model.Task, blockerStates model.BlockerStatus, now time.Time, window time.Duration) bool {
+	horizon := now.Add(window)
+	for _, id := range t.BlockedBy {
+		if blockerStates[id] {
+			continue
+		}
+		w := findWait(pf, id)
+		if w == nil || model.ComputeWaitState(w, blockerStates, now) == model.WaitStateDormant {
+			return false
+		}
+		var due *time.Time
+		switch w.ResolutionCriteria.Type {
+		case model.ResolutionTypeTime:
+			due = w.ResolutionCriteria.After
+		case model.ResolutionTypeManual:
+			due = w.ResolutionCriteria.CheckAfter
+		}
+		if due == nil || due.After(horizon) {
+			return false
+		}
+	}
+	return true
+}
+
+// downstreamOf returns the set of item IDs blocked by the given item,
+// either directly or transitively.
+func downstreamOf(s Store, id string, direct bool) (map[string]bool, error) {
+	prefix := model.ExtractPrefix(id)
+	if prefix == "" {
+		return nil, fmt.Errorf("invalid ID: %s", id)
+	}
+
+	pf, err := s.LoadProject(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	item := findItem(pf, id)
+	if item == nil {
+		return nil, &NotFoundError{Kind: "item", ItemID: id}
+	}
+
+	g := graph.BuildGraph(pf)
+	var ids []string
+	if direct {
+		ids = g.Blocking(item.id)
+	} else {
+		ids = g.TransitiveBlocking(item.id)
+	}
+
+	set := make(map[string]bool, len(ids))
+	for _, depID := range ids {
+		set[depID] = true
+	}
+	return set, nil
+}
+
 func matchesTaskFilter(t *model.Task, state model.TaskState, blockerStates model.BlockerStatus, f TaskFilter, now time.Time) bool {
+	// Snoozed tasks are hidden only where asked for, so dumps, exports and
+	// blocker lookups still see them
+	if !f.All && f.HideSnoozed && isSnoozed(t, now) {
+		return false
+	}
+
 	// Status/state filter
 	if f.State != nil {
 		if state != *f.State {
@@ -127,10 +442,23 @@ func matchesTaskFilter(t *model.Task, state model.TaskState, blockerStates model
 		}
 	}
 
+	// State exclusions combine with each other and with the filter above
+	for _, excluded := range f.Exclude {
+		if state == excluded {
+			return false
+		}
+	}
+
 	// Priority filter
 	if f.Priority > 0 && t.Priority != f.Priority {
 		return false
 	}
+	if f.MinPriority > 0 && t.Priority < f.MinPriority {
+		return false
+	}
+	if f.MaxPriority > 0 && t.Priority > f.MaxPriority {
+		return false
+	}
 
 	// Tag filter (AND logic)
 	if len(f.Tags) > 0 {
@@ -145,16 +473,58 @@ func matchesTaskFilter(t *model.Task, state model.TaskState, blockerStates model
 		}
 	}
 
+	// Assignee filter
+	if f.Assignee != "" && !strings.EqualFold(t.Assignee, f.Assignee) {
+		return false
+	}
+	if f.Unassigned && t.Assignee != "" {
+		return false
+	}
+
 	// Overdue filter
 	if f.Overdue {
-		if t.DueDate == nil || !t.DueDate.Before(now) {
+		if t.DueDate == nil || model.DueDay(*t.DueDate) >= model.Day(now) {
 			return false
 		}
 	}
 
+	// Agenda filter
+	if f.Agenda && !onAgenda(t, now) {
+		return false
+	}
+
+	// Due-soon filter
+	if f.DueWithin != nil {
+		if t.DueDate == nil || model.DueDay(*t.DueDate) > model.Day(now.Add(*f.DueWithin)) {
+			return false
+		}
+	}
+
+	// Creation date filters
+	if f.CreatedAfter != nil && t.Created.Before(*f.CreatedAfter) {
+		return false
+	}
+	if f.CreatedBefore != nil && !t.Created.Before(*f.CreatedBefore) {
+		return false
+	}
+	if f.DoneAfter != nil && (t.DoneAt == nil || t.DoneAt.Before(*f.DoneAfter)) {
+		return false
+	}
+
 	return true
 }
 
+// onAgenda reports whether a task with a due date should be surfaced now:
+// its due date minus its RemindBefore lead time (in days) is today or earlier.
+// Dates are compared as calendar days so time zones don't shift the window.
+func onAgenda(t *model.Task, now time.Time) bool {
+	if t.DueDate == nil {
+		return false
+	}
+	remindFrom := model.DueDay(t.DueDate.AddDate(0, 0, -t.RemindBefore))
+	return remindFrom <= model.Day(now)
+}
+
 // WaitFilter specifies filtering criteria for listing waits.
 type WaitFilter struct {
 	Project string           // Limit to a specific project (prefix or ID). Empty = all active.
@@ -176,11 +546,16 @@ func ListWaits(s Store, filter WaitFilter) ([]WaitResult, error) {
 		return nil, err
 	}
 
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	var results []WaitResult
 
 	for _, pf := range projects {
-		blockerStates := ComputeBlockerStates(pf)
+		blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
 		for _, w := range pf.Waits {
 			state := model.ComputeWaitState(&w, blockerStates, now)
 			if !matchesWaitFilter(&w, state, filter) {
@@ -215,15 +590,19 @@ func ShowTask(s Store, taskID string) (*TaskResult, *model.ProjectFile, error) {
 		return nil, nil, fmt.Errorf("invalid task ID: %s", taskID)
 	}
 
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
 	pf, err := s.LoadProject(prefix)
 	if err != nil {
-		return nil, nil, fmt.Errorf("project with prefix %q not found", prefix)
+		return nil, nil, err
 	}
 
 	normalizedID := strings.ToUpper(taskID)
 	for i := range pf.Tasks {
 		if strings.ToUpper(pf.Tasks[i].ID) == normalizedID {
-			blockerStates := ComputeBlockerStates(pf)
+			blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
 			state := model.ComputeTaskState(&pf.Tasks[i], blockerStates)
 			return &TaskResult{Task: pf.Tasks[i], State: state, Project: pf.Prefix}, pf, nil
 		}
@@ -232,14 +611,32 @@ func ShowTask(s Store, taskID string) (*TaskResult, *model.ProjectFile, error) {
 		if err2 == nil {
 			_, searchNum, err3 := model.ParseTaskID(taskID)
 			if err3 == nil && num == searchNum {
-				blockerStates := ComputeBlockerStates(pf)
+				blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
 				state := model.ComputeTaskState(&pf.Tasks[i], blockerStates)
 				return &TaskResult{Task: pf.Tasks[i], State: state, Project: pf.Prefix}, pf, nil
 			}
 		}
 	}
 
-	return nil, nil, fmt.Errorf("task %s not found", taskID)
+	return nil, nil, &NotFoundError{Kind: "task", ItemID: taskID}
+}
+
+// DormantUntil returns the open blockers keeping a wait dormant, in the
+// order they are listed on the wait. It returns nil for a wait that is
+// closed or has no open blockers. droppedUnblocks is the dropped_unblocks
+// setting.
+func DormantUntil(pf *model.ProjectFile, w *model.Wait, droppedUnblocks bool) []string {
+	if w.Status != model.WaitStatusOpen {
+		return nil
+	}
+	blockerStates := ComputeBlockerStates(pf, droppedUnblocks)
+	var open []string
+	for _, blockerID := range w.BlockedBy {
+		if !blockerStates[blockerID] {
+			open = append(open, blockerID)
+		}
+	}
+	return open
 }
 
 // ShowWait loads a single wait by ID with its computed state.
@@ -249,16 +646,20 @@ func ShowWait(s Store, waitID string) (*WaitResult, *model.ProjectFile, error) {
 		return nil, nil, fmt.Errorf("invalid wait ID: %s", waitID)
 	}
 
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
 	pf, err := s.LoadProject(prefix)
 	if err != nil {
-		return nil, nil, fmt.Errorf("project with prefix %q not found", prefix)
+		return nil, nil, err
 	}
 
 	now := time.Now()
 	normalizedID := strings.ToUpper(waitID)
 	for i := range pf.Waits {
 		if strings.ToUpper(pf.Waits[i].ID) == normalizedID {
-			blockerStates := ComputeBlockerStates(pf)
+			blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
 			state := model.ComputeWaitState(&pf.Waits[i], blockerStates, now)
 			return &WaitResult{Wait: pf.Waits[i], State: state, Project: pf.Prefix}, pf, nil
 		}
@@ -267,14 +668,14 @@ func ShowWait(s Store, waitID string) (*WaitResult, *model.ProjectFile, error) {
 		if err2 == nil {
 			_, searchNum, err3 := model.ParseWaitID(waitID)
 			if err3 == nil && num == searchNum {
-				blockerStates := ComputeBlockerStates(pf)
+				blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
 				state := model.ComputeWaitState(&pf.Waits[i], blockerStates, now)
 				return &WaitResult{Wait: pf.Waits[i], State: state, Project: pf.Prefix}, pf, nil
 			}
 		}
 	}
 
-	return nil, nil, fmt.Errorf("wait %s not found", waitID)
+	return nil, nil, &NotFoundError{Kind: "wait", ItemID: waitID}
 }
 
 // BlockerInfo describes a blocker item for display purposes.
@@ -288,6 +689,17 @@ type BlockerInfo struct {
 func GetBlockerInfo(pf *model.ProjectFile, blockerID string) BlockerInfo {
 	normalizedID := strings.ToUpper(blockerID)
 
+	if prefix, ok := model.ParseProjectRef(blockerID); ok {
+		info := BlockerInfo{ID: normalizedID, Status: "unknown", DisplayText: "all of project " + prefix}
+		if resolved, known := pf.ProjectBlockers[normalizedID]; known {
+			info.Status = string(model.TaskStatusOpen)
+			if resolved {
+				info.Status = string(model.TaskStatusDone)
+			}
+		}
+		return info
+	}
+
 	for _, t := range pf.Tasks {
 		if strings.ToUpper(t.ID) == normalizedID {
 			return BlockerInfo{ID: t.ID, Status: string(t.Status), DisplayText: t.Title}
@@ -302,49 +714,127 @@ func GetBlockerInfo(pf *model.ProjectFile, blockerID string) BlockerInfo {
 	return BlockerInfo{ID: blockerID, Status: "unknown", DisplayText: ""}
 }
 
-// GetBlockers returns the blockers for an item (task or wait) by ID.
-func GetBlockers(s Store, id string) ([]string, error) {
-	prefix := model.ExtractPrefix(id)
-	if prefix == "" {
-		return nil, fmt.Errorf("invalid ID format: %s", id)
+// BlockerSummary counts how many of an item's blockers are resolved.
+type BlockerSummary struct {
+	Total      int
+	Resolved   int
+	Unresolved []string // IDs of blockers that are still open (or missing)
+}
+
+// SummarizeBlockers computes a BlockerSummary for the given blocker IDs.
+// Done blockers count as resolved, as do dropped ones when droppedUnblocks
+// (the dropped_unblocks setting) is true.
+func SummarizeBlockers(pf *model.ProjectFile, blockedBy []string, droppedUnblocks bool) BlockerSummary {
+	blockerStates := ComputeBlockerStates(pf, droppedUnblocks)
+	summary := BlockerSummary{Total: len(blockedBy)}
+	for _, blockerID := range blockedBy {
+		if blockerStates[blockerID] {
+			summary.Resolved++
+		} else {
+			summary.Unresolved = append(summary.Unresolved, blockerID)
+		}
 	}
+	return summary
+}
 
-	pf, err := s.LoadProject(prefix)
+// DependencyProgress summarizes every transitive blocker of an item, so a
+// milestone's progress counts the whole subtree rather than direct blockers.
+func DependencyProgress(pf *model.ProjectFile, id string, droppedUnblocks bool) BlockerSummary {
+	g := graph.BuildGraph(pf)
+	return SummarizeBlockers(pf, g.TransitiveBlockedBy(id), droppedUnblocks)
+}
+
+// Dependents returns the items directly blocked by the given item, ordered by
+// ID number. Like every other dependent listing it is derived from a graph
+// built from the project as loaded, so it reflects blocker changes
+// immediately; nothing about dependents is stored on the blocker itself.
+func Dependents(pf *model.ProjectFile, id string) []string {
+	g := graph.BuildGraph(pf)
+	dependents := g.Blocking(id)
+	sort.SliceStable(dependents, func(i, j int) bool {
+		return model.ExtractNumber(dependents[i]) < model.ExtractNumber(dependents[j])
+	})
+	return dependents
+}
+
+// GetBlockers returns the blockers for an item (task or wait) by ID.
+func GetBlockers(s Store, id string) ([]string, error) {
+	pf, err := loadItemProject(s, id)
 	if err != nil {
 		return nil, err
 	}
 
 	if model.IsWaitID(id) {
-		for _, w := range pf.Waits {
-			if strings.EqualFold(w.ID, id) {
-				return w.BlockedBy, nil
-			}
-		}
-	} else {
-		for _, t := range pf.Tasks {
-			if strings.EqualFold(t.ID, id) {
-				return t.BlockedBy, nil
-			}
+		w := findWait(pf, id)
+		if w == nil {
+			return nil, &NotFoundError{Kind: "wait", ItemID: id}
 		}
+		return w.BlockedBy, nil
 	}
-
-	return nil, nil
+	t := findTask(pf, id)
+	if t == nil {
+		return nil, &NotFoundError{Kind: "task", ItemID: id}
+	}
+	return t.BlockedBy, nil
 }
 
-// GetBlocking returns the items that an item is blocking (direct dependents).
+// GetBlocking returns the items that an item (task or wait) is blocking
+// (direct dependents), ordered by ID number. For a wait these are the tasks
+// and waits waiting on it.
 func GetBlocking(s Store, id string) ([]string, error) {
-	prefix := model.ExtractPrefix(id)
-	if prefix == "" {
-		return nil, fmt.Errorf("invalid ID format: %s", id)
+	pf, err := loadItemProject(s, id)
+	if err != nil {
+		return nil, err
 	}
 
-	pf, err := s.LoadProject(prefix)
+	if model.IsWaitID(id) {
+		w := findWait(pf, id)
+		if w == nil {
+			return nil, &NotFoundError{Kind: "wait", ItemID: id}
+		}
+		return Dependents(pf, w.ID), nil
+	}
+	t := findTask(pf, id)
+	if t == nil {
+		return nil, &NotFoundError{Kind: "task", ItemID: id}
+	}
+	return Dependents(pf, t.ID), nil
+}
+
+// AutoCompleteManualWaits returns the open manual waits among an
+// auto-complete task's direct blockers. While any of them is open, finishing
+// the task's other blockers won't auto-complete it, which is easy to
+// overlook. Returns nil if the task isn't auto-complete.
+func AutoCompleteManualWaits(s Store, taskID string) ([]string, error) {
+	pf, err := loadItemProject(s, taskID)
 	if err != nil {
 		return nil, err
 	}
+	t := findTask(pf, taskID)
+	if t == nil {
+		return nil, &NotFoundError{Kind: "task", ItemID: taskID}
+	}
+	if !t.AutoComplete {
+		return nil, nil
+	}
 
-	g := graph.BuildGraph(pf)
-	return g.Blocking(id), nil
+	var waits []string
+	for _, blockerID := range t.BlockedBy {
+		w := findWait(pf, blockerID)
+		if w != nil && w.Status == model.WaitStatusOpen && w.ResolutionCriteria.Type == model.ResolutionTypeManual {
+			waits = append(waits, w.ID)
+		}
+	}
+	return waits, nil
+}
+
+// loadItemProject loads the project that owns the task or wait id.
+func loadItemProject(s Store, id string) (*model.ProjectFile, error) {
+	prefix := model.ExtractPrefix(id)
+	if prefix == "" {
+		return nil, fmt.Errorf("invalid ID format: %s", id)
+	}
+	return s.LoadProject(prefix)
 }
 
 // FindResult holds a search match.
@@ -354,15 +844,25 @@ type FindResult struct {
 }
 
 // FindItems searches tasks and waits by keyword across projects.
-func FindItems(s Store, query string, projectRef string) (*FindResult, error) {
+// If projectRefs is non-empty, only those projects are searched (duplicates are
+// ignored); otherwise all active projects are searched.
+// Results are ordered by project prefix, then by ID number.
+func FindItems(s Store, query string, projectRefs []string) (*FindResult, error) {
 	var projects []*model.ProjectFile
 
-	if projectRef != "" {
-		pf, err := ResolveProject(s, projectRef)
-		if err != nil {
-			return nil, err
+	if len(projectRefs) > 0 {
+		seen := make(map[string]bool)
+		for _, ref := range projectRefs {
+			pf, err := LookupProject(s, ref)
+			if err != nil {
+				return nil, err
+			}
+			if seen[pf.Prefix] {
+				continue
+			}
+			seen[pf.Prefix] = true
+			projects = append(projects, pf)
 		}
-		projects = append(projects, pf)
 	} else {
 		var err error
 		projects, err = LoadActiveProjects(s, false)
@@ -371,12 +871,17 @@ func FindItems(s Store, query string, projectRef string) (*FindResult, error) {
 		}
 	}
 
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	queryLower := strings.ToLower(query)
 	now := time.Now()
 	result := &FindResult{}
 
 	for _, pf := range projects {
-		blockerStates := ComputeBlockerStates(pf)
+		blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
 
 		for _, t := range pf.Tasks {
 			if strings.Contains(strings.ToLower(t.Title), queryLower) ||
@@ -396,6 +901,21 @@ func FindItems(s Store, query string, projectRef string) (*FindResult, error) {
 		}
 	}
 
+	sort.SliceStable(result.Tasks, func(i, j int) bool {
+		a, b := result.Tasks[i], result.Tasks[j]
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		return model.ExtractNumber(a.Task.ID) < model.ExtractNumber(b.Task.ID)
+	})
+	sort.SliceStable(result.Waits, func(i, j int) bool {
+		a, b := result.Waits[i], result.Waits[j]
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		return model.ExtractNumber(a.Wait.ID) < model.ExtractNumber(b.Wait.ID)
+	})
+
 	return result, nil
 }
 
@@ -419,8 +939,12 @@ func GetProjectSummary(s Store, projectRef string) (*ProjectSummary, error) {
 	if err != nil {
 		return nil, err
 	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
 
-	blockerStates := ComputeBlockerStates(pf)
+	blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
 	summary := &ProjectSummary{Project: pf.Project}
 
 	for _, t := range pf.Tasks {
@@ -504,10 +1028,13 @@ func AddTag(s Store, taskID string, tag string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return false, err
+	}
 
 	task := findTask(pf, taskID)
 	if task == nil {
-		return false, fmt.Errorf("task %s not found", taskID)
+		return false, &NotFoundError{Kind: "task", ItemID: taskID}
 	}
 
 	// Check if tag already exists
@@ -540,10 +1067,13 @@ func RemoveTag(s Store, taskID string, tag string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return false, err
+	}
 
 	task := findTask(pf, taskID)
 	if task == nil {
-		return false, fmt.Errorf("task %s not found", taskID)
+		return false, &NotFoundError{Kind: "task", ItemID: taskID}
 	}
 
 	found := false
@@ -567,6 +1097,51 @@ func RemoveTag(s Store, taskID string, tag string) (bool, error) {
 	return true, nil
 }
 
+// RemoveTagsWithPrefix removes every tag beginning with prefix from a task
+// and returns the removed tags (nil if none matched).
+func RemoveTagsWithPrefix(s Store, taskID string, prefix string) ([]string, error) {
+	if prefix == "" {
+		return nil, fmt.Errorf("tag prefix cannot be empty")
+	}
+
+	projectPrefix := model.ExtractPrefix(taskID)
+	if projectPrefix == "" {
+		return nil, fmt.Errorf("invalid task ID: %s", taskID)
+	}
+
+	pf, err := s.LoadProject(projectPrefix)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return nil, err
+	}
+
+	task := findTask(pf, taskID)
+	if task == nil {
+		return nil, &NotFoundError{Kind: "task", ItemID: taskID}
+	}
+
+	var removed, newTags []string
+	for _, t := range task.Tags {
+		if strings.HasPrefix(strings.ToLower(t), strings.ToLower(prefix)) {
+			removed = append(removed, t)
+		} else {
+			newTags = append(newTags, t)
+		}
+	}
+
+	if len(removed) == 0 {
+		return nil, nil
+	}
+
+	changes := TaskChanges{Tags: &newTags}
+	if err := EditTask(s, taskID, changes); err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
+
 // AppendNote appends text to a task's notes field.
 func AppendNote(s Store, taskID string, text string) error {
 	if strings.TrimSpace(text) == "" {
@@ -582,10 +1157,13 @@ func AppendNote(s Store, taskID string, text string) error {
 	if err != nil {
 		return err
 	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return err
+	}
 
 	task := findTask(pf, taskID)
 	if task == nil {
-		return fmt.Errorf("task %s not found", taskID)
+		return &NotFoundError{Kind: "task", ItemID: taskID}
 	}
 
 	var newNotes string
@@ -600,9 +1178,10 @@ func AppendNote(s Store, taskID string, text string) error {
 }
 
 // resolveProjectsForFilter loads the projects applicable to a query filter.
+// An explicit project reference bypasses ignored_projects.
 func resolveProjectsForFilter(s Store, projectRef string, includeAll bool) ([]*model.ProjectFile, error) {
 	if projectRef != "" {
-		pf, err := ResolveProject(s, projectRef)
+		pf, err := LookupProject(s, projectRef)
 		if err != nil {
 			return nil, err
 		}
@@ -610,3 +1189,194 @@ func resolveProjectsForFilter(s Store, projectRef string, includeAll bool) ([]*m
 	}
 	return LoadActiveProjects(s, includeAll)
 }
+
+// BlockerNode is one item in a blocker tree, with its computed state and the
+// items blocking it.
+type BlockerNode struct {
+	ID       string
+	Title    string
+	State    string // task or wait state; for a project blocker or unknown ID, its BlockerInfo status
+	Seen     bool   // already shown earlier in the tree, so its blockers are left out
+	Blockers []*BlockerNode
+}
+
+// BlockerTree returns an item's full blocker chain as a tree: the item's
+// direct blockers, their blockers, and so on, covering the same items as
+// graph.TransitiveBlockedBy. An item reached a second time, as happens with
+// diamond dependencies, is marked Seen and not expanded again.
+func BlockerTree(s Store, id string) (*BlockerNode, error) {
+	pf, err := loadItemProject(s, id)
+	if err != nil {
+		return nil, err
+	}
+	item := findItem(pf, id)
+	if item == nil {
+		kind := "task"
+		if model.IsWaitID(id) {
+			kind = "wait"
+		}
+		return nil, &NotFoundError{Kind: kind, ItemID: id}
+	}
+
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	g := graph.BuildGraph(pf)
+	blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
+	now := time.Now()
+	seen := make(map[string]bool)
+
+	var build func(id string) *BlockerNode
+	build = func(id string) *BlockerNode {
+		node := describeNode(pf, id, blockerStates, now)
+		if seen[id] {
+			node.Seen = true
+			return node
+		}
+		seen[id] = true
+		for _, blockerID := range g.BlockedBy(id) {
+			node.Blockers = append(node.Blockers, build(blockerID))
+		}
+		return node
+	}
+	return build(item.id), nil
+}
+
+// describeNode returns a BlockerNode for an item, without its blockers.
+func describeNode(pf *model.ProjectFile, id string, blockerStates model.BlockerStatus, now time.Time) *BlockerNode {
+	node := &BlockerNode{ID: id}
+	if t := findTask(pf, id); t != nil {
+		node.Title = t.Title
+		node.State = string(model.ComputeTaskState(t, blockerStates))
+	} else if w := findWait(pf, id); w != nil {
+		node.Title = w.DisplayText()
+		node.State = string(model.ComputeWaitState(w, blockerStates, now))
+	} else {
+		info := GetBlockerInfo(pf, id)
+		node.ID, node.Title, node.State = info.ID, info.DisplayText, info.Status
+	}
+	return node
+}
+
+// CriticalPath returns the longest chain of open items in a project linked
+// by blockers, in the order they have to be done, as BlockerNodes without
+// their blockers. Done and dropped items no longer hold anything up, so
+// they are left out. It returns nil if the project has no open items.
+// droppedUnblocks is the dropped_unblocks setting.
+func CriticalPath(pf *model.ProjectFile, droppedUnblocks bool) []*BlockerNode {
+	open := &model.ProjectFile{Project: pf.Project}
+	for _, t := range pf.Tasks {
+		if t.Status == model.TaskStatusOpen {
+			open.Tasks = append(open.Tasks, t)
+		}
+	}
+	for _, w := range pf.Waits {
+		if w.Status == model.WaitStatusOpen {
+			open.Waits = append(open.Waits, w)
+		}
+	}
+
+	blockerStates := ComputeBlockerStates(pf, droppedUnblocks)
+	now := time.Now()
+	var path []*BlockerNode
+	for _, id := range graph.BuildGraph(open).LongestPath() {
+		path = append(path, describeNode(pf, id, blockerStates, now))
+	}
+	return path
+}
+
+// PlanTasks returns a project's open tasks in an order that can be worked
+// through top to bottom: every task comes after the tasks blocking it. Among
+// tasks that could go next, higher priority comes first, then lower ID. Open
+// waits are ordered along with the tasks, so a task blocked by a wait comes
+// after the wait's own blockers, but only tasks are returned. Closed items
+// don't constrain the order, since there is nothing left to do for them. An
+// empty projectRef uses default_project from config.
+func PlanTasks(s Store, projectRef string) ([]TaskResult, error) {
+	pf, err := LookupProject(s, projectRef)
+	if err != nil {
+		return nil, err
+	}
+
+	open := &model.ProjectFile{Project: pf.Project}
+	tasks := make(map[string]*model.Task)
+	for i, t := range pf.Tasks {
+		if t.Status == model.TaskStatusOpen {
+			open.Tasks = append(open.Tasks, t)
+			tasks[t.ID] = &pf.Tasks[i]
+		}
+	}
+	for _, w := range pf.Waits {
+		if w.Status == model.WaitStatusOpen {
+			open.Waits = append(open.Waits, w)
+		}
+	}
+
+	// Waits sort ahead of tasks: they take no work, and placing them as
+	// early as possible never delays a task
+	rank := func(id string) int {
+		if t := tasks[id]; t != nil {
+			return t.Priority
+		}
+		return 0
+	}
+	order, err := graph.BuildGraph(open).TopoSort(func(a, b string) bool {
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		return model.ExtractNumber(a) < model.ExtractNumber(b)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
+	results := make([]TaskResult, 0, len(order))
+	for _, id := range order {
+		t := tasks[id]
+		if t == nil {
+			continue
+		}
+		results = append(results, TaskResult{
+			Task:    *t,
+			State:   model.ComputeTaskState(t, blockerStates),
+			Project: pf.Prefix,
+		})
+	}
+	return results, nil
+}
+
+// DependencyPath returns the chain of blockers leading from one item to
+// another (from, its blocker, ..., to), or nil if from doesn't depend on to.
+// Both items must be in the same project, since blockers never cross
+// projects.
+func DependencyPath(s Store, from, to string) ([]string, error) {
+	if !strings.EqualFold(model.ExtractPrefix(from), model.ExtractPrefix(to)) {
+		return nil, fmt.Errorf("%s and %s are in different projects", from, to)
+	}
+	pf, err := loadItemProject(s, from)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids [2]string
+	for i, id := range []string{from, to} {
+		item := findItem(pf, id)
+		if item == nil {
+			return nil, &NotFoundError{Kind: "item", ItemID: id}
+		}
+		ids[i] = item.id
+	}
+	if ids[0] == ids[1] {
+		return nil, fmt.Errorf("%s and %s are the same item", from, to)
+	}
+
+	return graph.BuildGraph(pf).Path(ids[0], ids[1]), nil
+}
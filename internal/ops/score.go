@@ -0,0 +1,77 @@
+package ops
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jacksmith/tk/internal/graph"
+	"github.com/jacksmith/tk/internal/model"
+	"github.com/jacksmith/tk/internal/storage"
+)
+
+// dueRampDays is how far ahead of its due date a task starts gaining due
+// urgency; the component reaches its maximum on the due date.
+const dueRampDays = 14
+
+// ScoreTask rates how pressing a task is by combining its priority, due-date
+// urgency, and downstream impact (the number of open items it transitively
+// blocks). Each component is normalized to 0..1 and multiplied by its weight,
+// so higher scores should be done first.
+func ScoreTask(t *model.Task, dependents int, w storage.ScoreWeights, now time.Time) float64 {
+	priority := float64(MaxPriority+1-t.Priority) / MaxPriority
+
+	due := 0.0
+	if t.DueDate != nil {
+		days := t.DueDate.Sub(now).Hours() / 24
+		due = 1 - days/dueRampDays
+		due = min(max(due, 0), 1)
+	}
+
+	// 0 for none, 1/2 for one, 2/3 for two, ... approaching 1
+	impact := 1 - 1/float64(dependents+1)
+
+	return w.Priority*priority + w.Due*due + w.Impact*impact
+}
+
+// SortByScore orders task results by ScoreTask, highest first. Ties fall
+// back to SortByUrgency order. Impact is counted from each task's project
+// graph, ignoring dependents that are already done or dropped.
+func SortByScore(s Store, results []TaskResult, w storage.ScoreWeights, now time.Time) error {
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return err
+	}
+	impact := make(map[string]int)
+	loaded := make(map[string]bool)
+	for _, r := range results {
+		if loaded[r.Project] {
+			continue
+		}
+		loaded[r.Project] = true
+
+		pf, err := s.LoadProject(r.Project)
+		if err != nil {
+			return err
+		}
+		blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
+		g := graph.BuildGraph(pf)
+		for _, t := range pf.Tasks {
+			for _, id := range g.TransitiveBlocking(t.ID) {
+				if !blockerStates[id] {
+					impact[t.ID]++
+				}
+			}
+		}
+	}
+
+	scores := make(map[string]float64, len(results))
+	for _, r := range results {
+		scores[r.Task.ID] = ScoreTask(&r.Task, impact[r.Task.ID], w, now)
+	}
+
+	SortByUrgency(results)
+	sort.SliceStable(results, func(i, j int) bool {
+		return scores[results[i].Task.ID] > scores[results[j].Task.ID]
+	})
+	return nil
+}
@@ -0,0 +1,140 @@
+package ops
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/jacksmith/tk/internal/model"
+)
+
+// SeedOptions controls the synthetic data generated by Seed.
+type SeedOptions struct {
+	Tasks int   // number of tasks to create
+	Seed  int64 // random seed; the same seed produces the same items
+}
+
+// SeedResult reports what Seed added to the project.
+type SeedResult struct {
+	Tasks    int
+	Waits    int
+	Blockers int
+}
+
+var (
+	seedVerbs = []string{"Fix", "Write", "Review", "Plan", "Order", "Clean", "Paint", "Call", "Update", "Install", "Measure", "Book"}
+	seedNouns = []string{"fence", "report", "budget", "garage", "invoice", "gutters", "website", "roof", "contract", "shelves", "flights", "taxes"}
+	seedTags  = []string{"weekend", "urgent", "errand", "call", "outside"}
+)
+
+// Seed adds opts.Tasks plausible tasks to the project, with roughly one wait
+// per five tasks and blockers pointing back at earlier items so the graph
+// stays acyclic. About a quarter of the tasks whose blockers are all done are
+// completed, and some time waits are already past due for tk check. Items are
+// drawn from a generator seeded with opts.Seed, so the same seed yields the
+// same titles, priorities, and dependencies. The project is saved once.
+func Seed(s Store, prefix string, opts SeedOptions) (*SeedResult, error) {
+	if opts.Tasks <= 0 {
+		return nil, fmt.Errorf("task count must be positive, got %d", opts.Tasks)
+	}
+
+	pf, err := s.LoadProject(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(s, pf, "seed"); err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	now := time.Now()
+	result := &SeedResult{}
+
+	// done tracks which seeded items count as resolved blockers
+	done := make(map[string]bool)
+	var ids []string
+
+	pickBlockers := func(max int) []string {
+		if len(ids) == 0 {
+			return nil
+		}
+		var blockers []string
+		for n := rng.Intn(max + 1); n > 0; n-- {
+			// Favor recent items so chains form rather than one wide fan-in
+			window := min(len(ids), 10)
+			id := ids[len(ids)-1-rng.Intn(window)]
+			if !containsFold(blockers, id) {
+				blockers = append(blockers, id)
+			}
+		}
+		return blockers
+	}
+
+	for i := 0; i < opts.Tasks; i++ {
+		if i > 0 && i%5 == 0 {
+			num := claimNextID(pf)
+			w := model.Wait{
+				ID:      model.FormatWaitID(pf.Prefix, num, num),
+				Status:  model.WaitStatusOpen,
+				Created: now,
+			}
+			noun := seedNouns[rng.Intn(len(seedNouns))]
+			if rng.Intn(2) == 0 {
+				w.ResolutionCriteria = model.ResolutionCriteria{
+					Type:     model.ResolutionTypeManual,
+					Question: fmt.Sprintf("Did the %s quote come back?", noun),
+				}
+			} else {
+				// Between 3 days ago and 4 weeks out
+				after := now.AddDate(0, 0, rng.Intn(31)-3)
+				w.Title = fmt.Sprintf("Wait for %s window", noun)
+				w.ResolutionCriteria = model.ResolutionCriteria{
+					Type:  model.ResolutionTypeTime,
+					After: &after,
+				}
+			}
+			pf.Waits = append(pf.Waits, w)
+			ids = append(ids, w.ID)
+			result.Waits++
+		}
+
+		blockers := pickBlockers(2)
+		num := claimNextID(pf)
+		t := model.Task{
+			ID:        model.FormatTaskID(pf.Prefix, num, num),
+			Title:     fmt.Sprintf("%s %s", seedVerbs[rng.Intn(len(seedVerbs))], seedNouns[rng.Intn(len(seedNouns))]),
+			Status:    model.TaskStatusOpen,
+			Priority:  rng.Intn(MaxPriority) + 1,
+			BlockedBy: blockers,
+			Created:   now,
+			Updated:   now,
+		}
+		if rng.Intn(3) == 0 {
+			t.Tags = []string{seedTags[rng.Intn(len(seedTags))]}
+		}
+		if rng.Intn(4) == 0 {
+			due := now.AddDate(0, 0, rng.Intn(60)-7)
+			t.DueDate = &due
+		}
+
+		resolved := true
+		for _, b := range blockers {
+			resolved = resolved && done[b]
+		}
+		if resolved && rng.Intn(4) == 0 {
+			t.Status = model.TaskStatusDone
+			t.DoneAt = &now
+			done[t.ID] = true
+		}
+
+		pf.Tasks = append(pf.Tasks, t)
+		ids = append(ids, t.ID)
+		result.Tasks++
+		result.Blockers += len(blockers)
+	}
+
+	if err := s.SaveProject(pf); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -1,6 +1,8 @@
 package ops
 
 import (
+	"fmt"
+
 	"github.com/jacksmith/tk/internal/model"
 	"github.com/jacksmith/tk/internal/storage"
 )
@@ -15,5 +17,138 @@ type Store interface {
 	ListProjects() ([]string, error)
 	DeleteProject(prefix string) error
 	ProjectExists(prefix string) bool
+	LoadArchive(prefix string) (*model.ProjectFile, error)
+	SaveArchive(p *model.ProjectFile) error
 	LoadConfig() (*storage.Config, error)
 }
+
+// ConfigWriter edits single keys in .tkconfig.yaml. It is not part of Store:
+// the config is the user's file, and only the operations that keep one of
+// its references in sync take a ConfigWriter, so callers opt in explicitly.
+// storage.Storage implements it.
+type ConfigWriter interface {
+	SetConfigValue(key, value string) error
+}
+
+// NotFoundError indicates that a task, wait, or project lookup found
+// nothing. It is the same type storage returns for a missing project, so
+// errors.As matches either.
+type NotFoundError = model.NotFoundError
+
+// ProjectStatusError indicates that an item change was refused because its
+// project is paused or done.
+type ProjectStatusError = model.ProjectStatusError
+
+// StoreOptions are policies applied on top of a Store's persistence.
+type StoreOptions struct {
+	// StrictLoad validates each project as it is loaded and returns a
+	// *CorruptProjectError instead of a project with duplicate IDs,
+	// malformed IDs, or a next_id that would reuse an ID. Other validation
+	// issues, such as orphan blockers, don't stop the load.
+	StrictLoad bool
+
+	// AllowInactive permits changes to items in paused and done projects.
+	// Without it, such changes fail with a *ProjectStatusError.
+	AllowInactive bool
+}
+
+// WithOptions returns a Store that applies opts. Options already applied to
+// s are replaced, not combined.
+func WithOptions(s Store, opts StoreOptions) Store {
+	if o, ok := s.(optionsStore); ok {
+		s = o.Store
+	}
+	if opts == (StoreOptions{}) {
+		return s
+	}
+	return optionsStore{Store: s, opts: opts}
+}
+
+// optionsStore is a Store with StoreOptions applied.
+type optionsStore struct {
+	Store
+	opts StoreOptions
+}
+
+// storeOptions returns the options applied to s; a plain Store has none.
+func storeOptions(s Store) StoreOptions {
+	if o, ok := s.(optionsStore); ok {
+		return o.opts
+	}
+	return StoreOptions{}
+}
+
+// checkProjectWritable enforces the project status policy for item changes:
+// only active projects can be changed unless the store allows inactive ones.
+// Project management (edit, rename, delete), tk check, and validate --fix are
+// not subject to it.
+func checkProjectWritable(s Store, pf *model.ProjectFile, operation string) error {
+	if pf.Status == model.ProjectStatusActive {
+		return nil
+	}
+	if storeOptions(s).AllowInactive {
+		return nil
+	}
+	return &ProjectStatusError{Operation: operation, Project: pf.Name, Status: string(pf.Status)}
+}
+
+// CorruptProjectError indicates a project was refused on load by a strict
+// store because its file has structural problems.
+type CorruptProjectError struct {
+	Project  string // project prefix
+	Problems []ValidationError
+}
+
+func (e *CorruptProjectError) Error() string {
+	msg := fmt.Sprintf("project %s failed validation: %s", e.Project, e.Problems[0])
+	if more := len(e.Problems) - 1; more > 0 {
+		msg += fmt.Sprintf(" (and %d more)", more)
+	}
+	return msg + "; run 'tk validate' for details and 'tk validate --fix' to repair what it can"
+}
+
+// structuralProblems are the validation errors that make IDs ambiguous or
+// let new items reuse existing IDs. Operating on such a file can change the
+// wrong item, so a strict store refuses to load it.
+var structuralProblems = map[ValidationErrorType]bool{
+	ValidationErrorDuplicateID: true,
+	ValidationErrorInvalidID:   true,
+	ValidationErrorNextID:      true,
+}
+
+func (s optionsStore) LoadProject(prefix string) (*model.ProjectFile, error) {
+	pf, err := s.Store.LoadProject(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return s.check(pf)
+}
+
+func (s optionsStore) LoadProjectByID(id string) (*model.ProjectFile, error) {
+	pf, err := s.Store.LoadProjectByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.check(pf)
+}
+
+// check refuses a structurally invalid project when StrictLoad is set.
+func (s optionsStore) check(pf *model.ProjectFile) (*model.ProjectFile, error) {
+	if !s.opts.StrictLoad {
+		return pf, nil
+	}
+	cfg, err := s.Store.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	var problems []ValidationError
+	for _, e := range validateProjectFile(s.Store, pf, cfg.DroppedUnblocks) {
+		if structuralProblems[e.Type] {
+			problems = append(problems, e)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &CorruptProjectError{Project: pf.Prefix, Problems: problems}
+	}
+	return pf, nil
+}
@@ -2,6 +2,7 @@ package ops
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -62,6 +63,23 @@ func ValidateTags(tags []string) error {
 	return nil
 }
 
+// normalizeTags lowercases and trims tags and drops duplicates, keeping the
+// first occurrence. Tags are always stored in this form so the same tag
+// added through different commands is stored identically.
+func normalizeTags(tags []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	return result
+}
+
 // TaskOptions contains options for creating a new task.
 type TaskOptions struct {
 	Priority     int
@@ -69,8 +87,11 @@ type TaskOptions struct {
 	Notes        string
 	Assignee     string
 	DueDate      *time.Time
+	RemindBefore int
 	AutoComplete bool
 	BlockedBy    []string
+	After        bool // also block on the project's most recently created task
+	Source       model.TaskSource
 }
 
 // TaskChanges represents fields that can be updated on a task.
@@ -81,6 +102,7 @@ type TaskChanges struct {
 	Notes        *string
 	Assignee     *string
 	DueDate      **time.Time // pointer to pointer to allow setting to nil
+	RemindBefore *int
 	AutoComplete *bool
 	BlockedBy    *[]string
 }
@@ -99,6 +121,34 @@ func (e *IncompleteBlockersError) Error() string {
 		strings.Join(e.Blockers, ", "))
 }
 
+// CascadeLimitError indicates that completing a task would auto-complete more
+// tasks than the configured max_auto_cascade allows. Nothing is saved; the
+// AutoCompleted list previews what would have been completed.
+type CascadeLimitError struct {
+	TaskID        string
+	AutoCompleted []string
+	Limit         int
+}
+
+func (e *CascadeLimitError) Error() string {
+	return fmt.Sprintf("completing %s would auto-complete %d tasks (limit %d)",
+		e.TaskID, len(e.AutoCompleted), e.Limit)
+}
+
+// CompleteOptions controls how a task is completed.
+type CompleteOptions struct {
+	// Force removes incomplete blockers instead of failing.
+	Force bool
+	// KeepBlockers, with Force, completes the task without removing its
+	// incomplete blockers, keeping a record of what it depended on.
+	KeepBlockers bool
+	// ForceCascade applies auto-complete cascades that exceed max_auto_cascade.
+	ForceCascade bool
+	// ResolveTimeWaits resolves the task's open time-wait blockers early when
+	// they are its only open blockers, for work finished ahead of schedule.
+	ResolveTimeWaits bool
+}
+
 // CompletionResult contains the results of completing a task.
 type CompletionResult struct {
 	// Unblocked lists tasks/waits that are now unblocked.
@@ -107,6 +157,8 @@ type CompletionResult struct {
 	Activated []string
 	// AutoCompleted lists tasks that were auto-completed as a cascade.
 	AutoCompleted []string
+	// ResolvedEarly lists time waits resolved early by ResolveTimeWaits.
+	ResolvedEarly []string
 }
 
 // AddTask creates a new task in the given project.
@@ -117,8 +169,8 @@ func AddTask(s Store, prefix string, title string, opts TaskOptions) (*model.Tas
 	}
 
 	// Validate project is active
-	if pf.Status != model.ProjectStatusActive {
-		return nil, fmt.Errorf("cannot add task to %s project %q", pf.Status, pf.Name)
+	if err := checkProjectWritable(s, pf, "add task to"); err != nil {
+		return nil, err
 	}
 
 	// Validate title
@@ -136,6 +188,20 @@ func AddTask(s Store, prefix string, title string, opts TaskOptions) (*model.Tas
 		return nil, err
 	}
 
+	if opts.RemindBefore < 0 {
+		return nil, fmt.Errorf("invalid remind_before %d: must not be negative", opts.RemindBefore)
+	}
+
+	if opts.After {
+		last := lastTask(pf)
+		if last == nil {
+			return nil, fmt.Errorf("project %s has no tasks to add after", pf.Prefix)
+		}
+		if !containsFold(opts.BlockedBy, last.ID) {
+			opts.BlockedBy = append(append([]string{}, opts.BlockedBy...), last.ID)
+		}
+	}
+
 	// Validate blockers if provided
 	if len(opts.BlockedBy) > 0 {
 		if err := validateBlockers(pf, opts.BlockedBy); err != nil {
@@ -149,31 +215,47 @@ func AddTask(s Store, prefix string, title string, opts TaskOptions) (*model.Tas
 		priority = 3 // Default priority
 	}
 
+	// Fall back to a tag routing rule, then the project's default assignee
+	assignee := opts.Assignee
+	if assignee == "" && len(opts.Tags) > 0 {
+		cfg, err := s.LoadConfig()
+		if err != nil {
+			return nil, err
+		}
+		assignee = cfg.AssigneeForTags(opts.Tags)
+	}
+	if assignee == "" {
+		assignee = pf.DefaultAssignee
+	}
+
 	// Create task with next ID
 	now := time.Now()
-	taskID := model.FormatTaskID(pf.Prefix, pf.NextID, pf.NextID)
+	num := claimNextID(pf)
+	taskID := model.FormatTaskID(pf.Prefix, num, num)
 	task := model.Task{
 		ID:           taskID,
 		Title:        title,
 		Status:       model.TaskStatusOpen,
 		Priority:     priority,
-		BlockedBy:    normalizeBlockerIDs(opts.BlockedBy, pf.NextID),
-		Tags:         opts.Tags,
+		BlockedBy:    normalizeBlockerIDs(opts.BlockedBy, num),
+		Tags:         normalizeTags(opts.Tags),
 		Notes:        opts.Notes,
-		Assignee:     opts.Assignee,
+		Assignee:     assignee,
 		DueDate:      opts.DueDate,
+		RemindBefore: opts.RemindBefore,
 		AutoComplete: opts.AutoComplete,
+		Source:       opts.Source,
 		Created:      now,
 		Updated:      now,
 	}
 
 	pf.Tasks = append(pf.Tasks, task)
-	pf.NextID++
 
 	if err := s.SaveProject(pf); err != nil {
 		return nil, err
 	}
 
+	runHooks(s, HookEventTaskAdd, task.ID)
 	return &task, nil
 }
 
@@ -188,10 +270,68 @@ func EditTask(s Store, taskID string, changes TaskChanges) error {
 	if err != nil {
 		return err
 	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return err
+	}
+
+	if err := editInProject(pf, taskID, changes); err != nil {
+		return err
+	}
+	return s.SaveProject(pf)
+}
+
+// TaskEdit is one task's changes in an EditTasks batch.
+type TaskEdit struct {
+	TaskID  string
+	Changes TaskChanges
+}
+
+// EditTasks applies several task edits, in order, as one batch. Every edit
+// is checked against the projects with the earlier edits applied before
+// anything is saved, so an invalid edit leaves all tasks unchanged. Errors
+// name the task whose edit failed.
+func EditTasks(s Store, edits []TaskEdit) error {
+	projects := make(map[string]*model.ProjectFile)
+	var order []*model.ProjectFile
+	for _, e := range edits {
+		prefix := strings.ToUpper(model.ExtractPrefix(e.TaskID))
+		if prefix == "" {
+			return fmt.Errorf("invalid task ID: %s", e.TaskID)
+		}
+
+		pf := projects[prefix]
+		if pf == nil {
+			var err error
+			pf, err = s.LoadProject(prefix)
+			if err != nil {
+				return err
+			}
+			if err := checkProjectWritable(s, pf, "modify"); err != nil {
+				return err
+			}
+			projects[prefix] = pf
+			order = append(order, pf)
+		}
+
+		if err := editInProject(pf, e.TaskID, e.Changes); err != nil {
+			return fmt.Errorf("%s: %w", e.TaskID, err)
+		}
+	}
+
+	for _, pf := range order {
+		if err := s.SaveProject(pf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+// editInProject validates and applies changes to a task in the loaded
+// project. It only modifies pf in memory; callers decide whether to save.
+func editInProject(pf *model.ProjectFile, taskID string, changes TaskChanges) error {
 	task := findTask(pf, taskID)
 	if task == nil {
-		return fmt.Errorf("task %s not found", taskID)
+		return &NotFoundError{Kind: "task", ItemID: taskID}
 	}
 
 	// Validate title if being changed
@@ -216,6 +356,10 @@ func EditTask(s Store, taskID string, changes TaskChanges) error {
 		}
 	}
 
+	if changes.RemindBefore != nil && *changes.RemindBefore < 0 {
+		return fmt.Errorf("invalid remind_before %d: must not be negative", *changes.RemindBefore)
+	}
+
 	// Validate new blockers if being changed
 	if changes.BlockedBy != nil {
 		if err := validateBlockers(pf, *changes.BlockedBy); err != nil {
@@ -238,7 +382,7 @@ func EditTask(s Store, taskID string, changes TaskChanges) error {
 		task.Priority = *changes.Priority
 	}
 	if changes.Tags != nil {
-		task.Tags = *changes.Tags
+		task.Tags = normalizeTags(*changes.Tags)
 	}
 	if changes.Notes != nil {
 		task.Notes = *changes.Notes
@@ -249,22 +393,25 @@ func EditTask(s Store, taskID string, changes TaskChanges) error {
 	if changes.DueDate != nil {
 		task.DueDate = *changes.DueDate
 	}
+	if changes.RemindBefore != nil {
+		task.RemindBefore = *changes.RemindBefore
+	}
 	if changes.AutoComplete != nil {
 		task.AutoComplete = *changes.AutoComplete
 	}
 	if changes.BlockedBy != nil {
 		task.BlockedBy = normalizeBlockerIDs(*changes.BlockedBy, pf.NextID-1)
+		sortBlockerIDs(task.BlockedBy)
 	}
 
 	task.Updated = time.Now()
-
-	return s.SaveProject(pf)
+	return nil
 }
 
 // CompleteTask marks a task as done.
 // If force is false and the task has incomplete blockers, returns an error.
 // Returns information about cascading effects (unblocked items, auto-completed tasks).
-func CompleteTask(s Store, taskID string, force bool) (*CompletionResult, error) {
+func CompleteTask(s Store, taskID string, opts CompleteOptions) (*CompletionResult, error) {
 	prefix := model.ExtractPrefix(taskID)
 	if prefix == "" {
 		return nil, fmt.Errorf("invalid task ID: %s", taskID)
@@ -274,18 +421,89 @@ func CompleteTask(s Store, taskID string, force bool) (*CompletionResult, error)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return nil, err
+	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	task, result, err := completeInProject(pf, taskID, opts, cfg.DroppedUnblocks)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refuse to apply an oversized cascade unless explicitly forced
+	if !opts.ForceCascade && cfg.MaxAutoCascade > 0 && len(result.AutoCompleted) > cfg.MaxAutoCascade {
+		return result, &CascadeLimitError{
+			TaskID:        taskID,
+			AutoCompleted: result.AutoCompleted,
+			Limit:         cfg.MaxAutoCascade,
+		}
+	}
+
+	if err := s.SaveProject(pf); err != nil {
+		return nil, err
+	}
+
+	for _, id := range result.ResolvedEarly {
+		runResolveHooks(s, id, earlyResolution(task.ID))
+	}
+	runHooks(s, HookEventTaskDone, task.ID)
+	for _, id := range result.AutoCompleted {
+		runHooks(s, HookEventTaskDone, id)
+	}
+
+	return result, nil
+}
 
+// PreviewCompleteTask computes the effects CompleteTask would have without
+// saving anything or running hooks. The cascade limit is not enforced.
+func PreviewCompleteTask(s Store, taskID string, opts CompleteOptions) (*CompletionResult, error) {
+	prefix := model.ExtractPrefix(taskID)
+	if prefix == "" {
+		return nil, fmt.Errorf("invalid task ID: %s", taskID)
+	}
+
+	pf, err := s.LoadProject(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return nil, err
+	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	_, result, err := completeInProject(pf, taskID, opts, cfg.DroppedUnblocks)
+	return result, err
+}
+
+// completeInProject marks a task done in the loaded project and computes the
+// cascading effects. It only modifies pf in memory; callers decide whether
+// to save. droppedUnblocks is the dropped_unblocks setting.
+func completeInProject(pf *model.ProjectFile, taskID string, opts CompleteOptions, droppedUnblocks bool) (*model.Task, *CompletionResult, error) {
 	task := findTask(pf, taskID)
 	if task == nil {
-		return nil, fmt.Errorf("task %s not found", taskID)
+		return nil, nil, &NotFoundError{Kind: "task", ItemID: taskID}
 	}
 
 	if task.Status != model.TaskStatusOpen {
-		return nil, fmt.Errorf("task %s is not open (status: %s)", taskID, task.Status)
+		return nil, nil, fmt.Errorf("task %s is not open (status: %s)", taskID, task.Status)
+	}
+
+	now := time.Now()
+	blockerStates := ComputeBlockerStates(pf, droppedUnblocks)
+
+	var resolvedEarly []string
+	if opts.ResolveTimeWaits {
+		resolvedEarly = resolveTimeWaitBlockers(pf, task, blockerStates, now)
 	}
 
 	// Check for incomplete blockers
-	blockerStates := ComputeBlockerStates(pf)
 	incompleteBlockers := []string{}
 	for _, blockerID := range task.BlockedBy {
 		if resolved, ok := blockerStates[blockerID]; !ok || !resolved {
@@ -294,24 +512,25 @@ func CompleteTask(s Store, taskID string, force bool) (*CompletionResult, error)
 	}
 
 	if len(incompleteBlockers) > 0 {
-		if !force {
-			return nil, &IncompleteBlockersError{
+		if !opts.Force {
+			return nil, nil, &IncompleteBlockersError{
 				TaskID:   taskID,
 				Blockers: incompleteBlockers,
 			}
 		}
-		// Remove unfulfilled blockers when forcing
-		task.BlockedBy = removeBlockers(task.BlockedBy, incompleteBlockers)
+		// Remove unfulfilled blockers when forcing, unless asked to keep them
+		if !opts.KeepBlockers {
+			task.BlockedBy = removeBlockers(task.BlockedBy, incompleteBlockers)
+		}
 	}
 
 	// Mark as done
-	now := time.Now()
 	task.Status = model.TaskStatusDone
 	task.DoneAt = &now
 	task.Updated = now
 
 	// Calculate cascading effects
-	result := &CompletionResult{}
+	result := &CompletionResult{ResolvedEarly: resolvedEarly}
 
 	// Update blocker states with this task now done
 	blockerStates[taskID] = true
@@ -372,11 +591,7 @@ func CompleteTask(s Store, taskID string, force bool) (*CompletionResult, error)
 	// Handle auto-complete cascade
 	result.AutoCompleted = processAutoComplete(pf, blockerStates)
 
-	if err := s.SaveProject(pf); err != nil {
-		return nil, err
-	}
-
-	return result, nil
+	return task, result, nil
 }
 
 // processAutoComplete handles cascading auto-completion of tasks.
@@ -418,27 +633,88 @@ func processAutoComplete(pf *model.ProjectFile, blockerStates model.BlockerStatu
 	return autoCompleted
 }
 
+// DropResult describes the effect a drop had on the dropped task's dependents.
+type DropResult struct {
+	// Dependents lists the open items directly blocked by the task.
+	Dependents []string
+	// Dropped lists dependents dropped along with it (--drop-deps).
+	Dropped []string
+	// Unlinked lists dependents the task was removed from (--remove-deps).
+	Unlinked []string
+	// Unblocked lists unlinked dependents left with no open blockers, which
+	// are now ready (tasks) or actionable (waits).
+	Unblocked []string
+}
+
 // DropTask marks a task as dropped.
 // If dropDeps is true, dependent items are also dropped recursively.
 // If removeDeps is true, this task is removed from dependents' blocked_by lists.
-func DropTask(s Store, taskID string, reason string, dropDeps, removeDeps bool) error {
+func DropTask(s Store, taskID string, reason string, dropDeps, removeDeps bool) (*DropResult, error) {
 	prefix := model.ExtractPrefix(taskID)
 	if prefix == "" {
-		return fmt.Errorf("invalid task ID: %s", taskID)
+		return nil, fmt.Errorf("invalid task ID: %s", taskID)
 	}
 
 	pf, err := s.LoadProject(prefix)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return nil, err
+	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
 	}
 
+	result, err := dropInProject(pf, taskID, reason, dropDeps, removeDeps, true, cfg.DroppedUnblocks)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.SaveProject(pf); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PreviewDropTask computes the effects DropTask would have without saving
+// anything. Unlike DropTask it does not require dropDeps or removeDeps when
+// the task has dependents; the result then just lists them.
+func PreviewDropTask(s Store, taskID string, dropDeps, removeDeps bool) (*DropResult, error) {
+	prefix := model.ExtractPrefix(taskID)
+	if prefix == "" {
+		return nil, fmt.Errorf("invalid task ID: %s", taskID)
+	}
+
+	pf, err := s.LoadProject(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return nil, err
+	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return dropInProject(pf, taskID, "", dropDeps, removeDeps, false, cfg.DroppedUnblocks)
+}
+
+// dropInProject drops a task in the loaded project and reports what happened
+// to its dependents. It only modifies pf in memory; callers decide whether to
+// save. With requireChoice, open dependents are an error unless dropDeps or
+// removeDeps says how to handle them. droppedUnblocks is the dropped_unblocks
+// setting.
+func dropInProject(pf *model.ProjectFile, taskID, reason string, dropDeps, removeDeps, requireChoice, droppedUnblocks bool) (*DropResult, error) {
 	task := findTask(pf, taskID)
 	if task == nil {
-		return fmt.Errorf("task %s not found", taskID)
+		return nil, &NotFoundError{Kind: "task", ItemID: taskID}
 	}
+	taskID = task.ID
 
 	if task.Status != model.TaskStatusOpen {
-		return fmt.Errorf("task %s is not open (status: %s)", taskID, task.Status)
+		return nil, fmt.Errorf("task %s is not open (status: %s)", taskID, task.Status)
 	}
 
 	// Check for dependents
@@ -446,31 +722,26 @@ func DropTask(s Store, taskID string, reason string, dropDeps, removeDeps bool)
 	dependents := g.Blocking(taskID)
 
 	// Filter to only open dependents
-	openDependents := []string{}
+	result := &DropResult{Dependents: []string{}}
 	for _, depID := range dependents {
-		item := findItem(pf, depID)
-		if item != nil && item.status == model.TaskStatusOpen {
-			openDependents = append(openDependents, depID)
+		if isOpenItem(pf, depID) {
+			result.Dependents = append(result.Dependents, depID)
 		}
 	}
 
-	if len(openDependents) > 0 && !dropDeps && !removeDeps {
-		return fmt.Errorf("task has dependents: %s (use --drop-deps or --remove-deps)",
-			strings.Join(openDependents, ", "))
+	if requireChoice && len(result.Dependents) > 0 && !dropDeps && !removeDeps {
+		return nil, fmt.Errorf("task has dependents: %s (use --drop-deps or --remove-deps)",
+			strings.Join(result.Dependents, ", "))
 	}
 
 	if dropDeps {
 		// Drop all dependents recursively
+		result.Dropped = openItems(pf, g.TransitiveBlocking(taskID))
 		if err := dropDependents(pf, taskID, reason); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	if removeDeps {
-		// Remove this task from all dependents' blocked_by lists
-		removeSelfFromDependents(pf, taskID)
-	}
-
 	// Drop the task
 	now := time.Now()
 	task.Status = model.TaskStatusDropped
@@ -478,7 +749,46 @@ func DropTask(s Store, taskID string, reason string, dropDeps, removeDeps bool)
 	task.DropReason = reason
 	task.Updated = now
 
-	return s.SaveProject(pf)
+	if removeDeps {
+		// Remove this task from all dependents' blocked_by lists
+		removeSelfFromDependents(pf, taskID)
+		result.Unlinked = result.Dependents
+
+		blockerStates := ComputeBlockerStates(pf, droppedUnblocks)
+		for _, depID := range result.Unlinked {
+			if !hasOpenBlockers(pf, depID, blockerStates) {
+				result.Unblocked = append(result.Unblocked, depID)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// openItems returns the IDs among ids whose task or wait is still open.
+func openItems(pf *model.ProjectFile, ids []string) []string {
+	var open []string
+	for _, id := range ids {
+		if isOpenItem(pf, id) {
+			open = append(open, id)
+		}
+	}
+	return open
+}
+
+// hasOpenBlockers reports whether the item with the given ID is still blocked
+// by anything unresolved.
+func hasOpenBlockers(pf *model.ProjectFile, id string, blockerStates model.BlockerStatus) bool {
+	item := findItem(pf, id)
+	if item == nil {
+		return false
+	}
+	for _, blockerID := range item.blockedBy {
+		if !blockerStates[blockerID] {
+			return true
+		}
+	}
+	return false
 }
 
 // dropDependents recursively drops all items that depend on the given ID.
@@ -519,25 +829,35 @@ func removeSelfFromDependents(pf *model.ProjectFile, id string) {
 	}
 }
 
+// ReopenResult contains the results of reopening a task.
+type ReopenResult struct {
+	// InconsistentDependents lists done items that are blocked by the
+	// reopened task, i.e. were completed assuming it was finished.
+	InconsistentDependents []string
+}
+
 // ReopenTask reopens a done or dropped task.
-func ReopenTask(s Store, taskID string) error {
+func ReopenTask(s Store, taskID string) (*ReopenResult, error) {
 	prefix := model.ExtractPrefix(taskID)
 	if prefix == "" {
-		return fmt.Errorf("invalid task ID: %s", taskID)
+		return nil, fmt.Errorf("invalid task ID: %s", taskID)
 	}
 
 	pf, err := s.LoadProject(prefix)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return nil, err
 	}
 
 	task := findTask(pf, taskID)
 	if task == nil {
-		return fmt.Errorf("task %s not found", taskID)
+		return nil, &NotFoundError{Kind: "task", ItemID: taskID}
 	}
 
 	if task.Status == model.TaskStatusOpen {
-		return fmt.Errorf("task %s is already open", taskID)
+		return nil, fmt.Errorf("task %s is already open", taskID)
 	}
 
 	task.Status = model.TaskStatusOpen
@@ -546,7 +866,22 @@ func ReopenTask(s Store, taskID string) error {
 	task.DropReason = ""
 	task.Updated = time.Now()
 
-	return s.SaveProject(pf)
+	// Dependents that were completed while this task was closed now depend
+	// on an open task. Report them so the caller can warn about it.
+	result := &ReopenResult{}
+	g := graph.BuildGraph(pf)
+	for _, dependentID := range g.Blocking(task.ID) {
+		if isDoneItem(pf, dependentID) {
+			result.InconsistentDependents = append(result.InconsistentDependents, dependentID)
+		}
+	}
+	sort.Strings(result.InconsistentDependents)
+
+	if err := s.SaveProject(pf); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
 // DeferTask defers a task by creating a time-based wait.
@@ -561,10 +896,13 @@ func DeferTask(s Store, taskID string, until time.Time) (*model.Wait, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return nil, err
+	}
 
 	task := findTask(pf, taskID)
 	if task == nil {
-		return nil, fmt.Errorf("task %s not found", taskID)
+		return nil, &NotFoundError{Kind: "task", ItemID: taskID}
 	}
 
 	if task.Status != model.TaskStatusOpen {
@@ -583,7 +921,8 @@ func DeferTask(s Store, taskID string, until time.Time) (*model.Wait, error) {
 
 	// Create a time-based wait
 	now := time.Now()
-	waitID := model.FormatWaitID(pf.Prefix, pf.NextID, pf.NextID)
+	num := claimNextID(pf)
+	waitID := model.FormatWaitID(pf.Prefix, num, num)
 	wait := model.Wait{
 		ID:     waitID,
 		Status: model.WaitStatusOpen,
@@ -595,7 +934,6 @@ func DeferTask(s Store, taskID string, until time.Time) (*model.Wait, error) {
 	}
 
 	pf.Waits = append(pf.Waits, wait)
-	pf.NextID++
 
 	// Add wait to task's blocked_by
 	task.BlockedBy = append(task.BlockedBy, waitID)
@@ -608,8 +946,42 @@ func DeferTask(s Store, taskID string, until time.Time) (*model.Wait, error) {
 	return &wait, nil
 }
 
+// SnoozeTask hides an open task from default listings until the given time
+// without creating a wait. Unlike DeferTask, nothing is added to the task's
+// blockers, so the task keeps its state and reappears on its own.
+func SnoozeTask(s Store, taskID string, until time.Time) error {
+	prefix := model.ExtractPrefix(taskID)
+	if prefix == "" {
+		return fmt.Errorf("invalid task ID: %s", taskID)
+	}
+
+	pf, err := s.LoadProject(prefix)
+	if err != nil {
+		return err
+	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return err
+	}
+
+	task := findTask(pf, taskID)
+	if task == nil {
+		return &NotFoundError{Kind: "task", ItemID: taskID}
+	}
+
+	if task.Status != model.TaskStatusOpen {
+		return fmt.Errorf("task %s is not open (status: %s)", taskID, task.Status)
+	}
+
+	task.SnoozedUntil = &until
+	task.Updated = time.Now()
+
+	return s.SaveProject(pf)
+}
+
 // MoveTask moves a task to a different project.
-func MoveTask(s Store, taskID string, toPrefix string) error {
+// If keepID is true, the task keeps its numeric ID in the destination project,
+// and an error is returned if that number is already used by a task or wait there.
+func MoveTask(s Store, taskID string, toPrefix string, keepID bool) error {
 	fromPrefix := model.ExtractPrefix(taskID)
 	if fromPrefix == "" {
 		return fmt.Errorf("invalid task ID: %s", taskID)
@@ -625,25 +997,32 @@ func MoveTask(s Store, taskID string, toPrefix string) error {
 	if err != nil {
 		return err
 	}
+	if err := checkProjectWritable(s, srcPf, "move task out of"); err != nil {
+		return err
+	}
 
 	// Load destination project
 	dstPf, err := s.LoadProject(toPrefix)
 	if err != nil {
 		return err
 	}
+	if err := checkProjectWritable(s, dstPf, "move task to"); err != nil {
+		return err
+	}
 
 	// Find and remove task from source
 	var task *model.Task
 	for i, t := range srcPf.Tasks {
 		if strings.EqualFold(t.ID, taskID) {
-			task = &srcPf.Tasks[i]
+			moved := t
+			task = &moved
 			srcPf.Tasks = append(srcPf.Tasks[:i], srcPf.Tasks[i+1:]...)
 			break
 		}
 	}
 
 	if task == nil {
-		return fmt.Errorf("task %s not found", taskID)
+		return &NotFoundError{Kind: "task", ItemID: taskID}
 	}
 
 	// Check if task has blockers in the source project
@@ -662,7 +1041,20 @@ func MoveTask(s Store, taskID string, toPrefix string) error {
 	}
 
 	// Assign new ID in destination project
-	newID := model.FormatTaskID(dstPf.Prefix, dstPf.NextID, dstPf.NextID)
+	var newID string
+	if keepID {
+		num := model.ExtractNumber(taskID)
+		if idNumberInUse(dstPf, num) {
+			return fmt.Errorf("ID number %d is already in use in project %s", num, dstPf.Prefix)
+		}
+		newID = model.FormatTaskID(dstPf.Prefix, num, num)
+		if num >= dstPf.NextID {
+			dstPf.NextID = num + 1
+		}
+	} else {
+		num := claimNextID(dstPf)
+		newID = model.FormatTaskID(dstPf.Prefix, num, num)
+	}
 	task.ID = newID
 	task.Updated = time.Now()
 
@@ -672,12 +1064,13 @@ func MoveTask(s Store, taskID string, toPrefix string) error {
 		blockerPrefix := model.ExtractPrefix(blockerID)
 		if blockerPrefix != fromPrefix {
 			newBlockers = append(newBlockers, blockerID)
+		} else {
+			delete(task.BlockReasons, blockerID)
 		}
 	}
 	task.BlockedBy = newBlockers
 
 	dstPf.Tasks = append(dstPf.Tasks, *task)
-	dstPf.NextID++
 
 	// Save both projects
 	if err := s.SaveProject(srcPf); err != nil {
@@ -686,8 +1079,212 @@ func MoveTask(s Store, taskID string, toPrefix string) error {
 	return s.SaveProject(dstPf)
 }
 
-// AddBlocker adds a blocker to a task.
-func AddBlocker(s Store, taskID, blockerID string) error {
+// MergeTasks merges the source task into the target task. Tags are unioned,
+// the source's notes are appended to the target's, blockers are unioned, and
+// items blocked by the source are rewired to be blocked by the target. The
+// source task is then dropped with a "merged into" reason. Both tasks must be
+// open and in the same project. Nothing is saved if the merge would create a
+// dependency cycle.
+func MergeTasks(s Store, targetID, sourceID string) error {
+	prefix := model.ExtractPrefix(targetID)
+	if prefix == "" {
+		return fmt.Errorf("invalid task ID: %s", targetID)
+	}
+	if model.ExtractPrefix(sourceID) == "" {
+		return fmt.Errorf("invalid task ID: %s", sourceID)
+	}
+	if model.ExtractPrefix(sourceID) != prefix {
+		return fmt.Errorf("cannot merge tasks from different projects")
+	}
+
+	pf, err := s.LoadProject(prefix)
+	if err != nil {
+		return err
+	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return err
+	}
+
+	target := findTask(pf, targetID)
+	if target == nil {
+		return &NotFoundError{Kind: "task", ItemID: targetID}
+	}
+	source := findTask(pf, sourceID)
+	if source == nil {
+		return &NotFoundError{Kind: "task", ItemID: sourceID}
+	}
+	if target.ID == source.ID {
+		return fmt.Errorf("cannot merge a task into itself")
+	}
+	for _, t := range []*model.Task{target, source} {
+		if t.Status != model.TaskStatusOpen {
+			return fmt.Errorf("task %s is not open (status: %s)", t.ID, t.Status)
+		}
+	}
+
+	// Union blockers, dropping any edge between the two tasks
+	blockers := []string{}
+	for _, id := range append(append([]string{}, target.BlockedBy...), source.BlockedBy...) {
+		if strings.EqualFold(id, target.ID) || strings.EqualFold(id, source.ID) {
+			continue
+		}
+		if !containsFold(blockers, id) {
+			blockers = append(blockers, id)
+		}
+	}
+	target.BlockedBy = blockers
+
+	// Carry over the source's blocker reasons, keeping the target's own
+	reasons := map[string]string{}
+	for _, t := range []*model.Task{source, target} {
+		for id, reason := range t.BlockReasons {
+			if containsFold(blockers, id) {
+				reasons[id] = reason
+			}
+		}
+	}
+	if len(reasons) == 0 {
+		reasons = nil
+	}
+	target.BlockReasons = reasons
+
+	// Union tags
+	target.Tags = normalizeTags(append(append([]string{}, target.Tags...), source.Tags...))
+
+	// Append notes
+	if source.Notes != "" {
+		if target.Notes != "" {
+			target.Notes += "\n\n" + source.Notes
+		} else {
+			target.Notes = source.Notes
+		}
+	}
+
+	// Rewire dependents of the source to the target
+	now := time.Now()
+	for i := range pf.Tasks {
+		t := &pf.Tasks[i]
+		if t.ID == source.ID || !containsFold(t.BlockedBy, source.ID) {
+			continue
+		}
+		t.BlockedBy = rewireBlocker(t.BlockedBy, source.ID, target.ID, t.ID == target.ID)
+		t.BlockReasons = rewireBlockReason(t.BlockReasons, source.ID, target.ID, t.ID == target.ID)
+		t.Updated = now
+	}
+	for i := range pf.Waits {
+		w := &pf.Waits[i]
+		if containsFold(w.BlockedBy, source.ID) {
+			w.BlockedBy = rewireBlocker(w.BlockedBy, source.ID, target.ID, false)
+			w.BlockReasons = rewireBlockReason(w.BlockReasons, source.ID, target.ID, false)
+		}
+	}
+
+	// All new edges touch the target, so any new cycle passes through it
+	g := graph.BuildGraph(pf)
+	for _, blockerID := range target.BlockedBy {
+		if containsFold(g.TransitiveBlockedBy(blockerID), target.ID) {
+			return fmt.Errorf("merging %s into %s would create a dependency cycle", source.ID, target.ID)
+		}
+	}
+
+	target.Updated = now
+	source.Status = model.TaskStatusDropped
+	source.DroppedAt = &now
+	source.DropReason = fmt.Sprintf("merged into %s", target.ID)
+	source.Updated = now
+
+	return s.SaveProject(pf)
+}
+
+// rewireBlocker replaces oldID with newID in a blocker list without creating
+// duplicates. If isNew is true (the item is newID itself), oldID is removed.
+func rewireBlocker(blockers []string, oldID, newID string, isNew bool) []string {
+	result := []string{}
+	for _, id := range blockers {
+		if strings.EqualFold(id, oldID) {
+			if isNew {
+				continue
+			}
+			id = newID
+		}
+		if !containsFold(result, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// rewireBlockReason moves the reason keyed by oldID over to newID, the way
+// rewireBlocker moves the blocker itself. An existing reason for newID wins.
+// If isNew is true, the reason is dropped along with the blocker.
+func rewireBlockReason(reasons map[string]string, oldID, newID string, isNew bool) map[string]string {
+	for id, reason := range reasons {
+		if !strings.EqualFold(id, oldID) {
+			continue
+		}
+		delete(reasons, id)
+		if _, ok := reasons[newID]; !ok && !isNew {
+			reasons[newID] = reason
+		}
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+	return reasons
+}
+
+// containsFold reports whether slice contains s, ignoring case.
+func containsFold(slice []string, s string) bool {
+	for _, v := range slice {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// idNumberInUse reports whether any task or wait in the project uses the given numeric ID.
+func idNumberInUse(pf *model.ProjectFile, num int) bool {
+	for _, t := range pf.Tasks {
+		if model.ExtractNumber(t.ID) == num {
+			return true
+		}
+	}
+	for _, w := range pf.Waits {
+		if model.ExtractNumber(w.ID) == num {
+			return true
+		}
+	}
+	return false
+}
+
+// claimNextID returns the number for a new item in the project and advances
+// next_id. If a hand-edit left next_id at or below a number already in use,
+// it is raised first so an existing ID is never handed out again.
+func claimNextID(pf *model.ProjectFile) int {
+	if maxNum := maxItemNumber(pf); pf.NextID <= maxNum {
+		pf.NextID = maxNum + 1
+	}
+	num := pf.NextID
+	pf.NextID++
+	return num
+}
+
+// lastTask returns the project's most recently created task, the one with
+// the highest ID number, or nil if the project has no tasks.
+func lastTask(pf *model.ProjectFile) *model.Task {
+	var last *model.Task
+	for i := range pf.Tasks {
+		if last == nil || model.ExtractNumber(pf.Tasks[i].ID) > model.ExtractNumber(last.ID) {
+			last = &pf.Tasks[i]
+		}
+	}
+	return last
+}
+
+// AddBlocker adds a blocker to a task. A non-empty reason is stored with
+// the relationship to record why the task depends on the blocker.
+func AddBlocker(s Store, taskID, blockerID, reason string) error {
 	prefix := model.ExtractPrefix(taskID)
 	if prefix == "" {
 		return fmt.Errorf("invalid task ID: %s", taskID)
@@ -697,10 +1294,13 @@ func AddBlocker(s Store, taskID, blockerID string) error {
 	if err != nil {
 		return err
 	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return err
+	}
 
 	task := findTask(pf, taskID)
 	if task == nil {
-		return fmt.Errorf("task %s not found", taskID)
+		return &NotFoundError{Kind: "task", ItemID: taskID}
 	}
 
 	// Validate blocker exists
@@ -721,12 +1321,79 @@ func AddBlocker(s Store, taskID, blockerID string) error {
 		return fmt.Errorf("adding blocker would create cycle: %s", strings.Join(cycle, " -> "))
 	}
 
-	task.BlockedBy = append(task.BlockedBy, model.NormalizeID(blockerID, pf.NextID-1))
+	blockerID = model.NormalizeID(blockerID, pf.NextID-1)
+	task.BlockedBy = append(task.BlockedBy, blockerID)
+	if reason = strings.TrimSpace(reason); reason != "" {
+		if task.BlockReasons == nil {
+			task.BlockReasons = make(map[string]string)
+		}
+		task.BlockReasons[blockerID] = reason
+	}
 	task.Updated = time.Now()
 
 	return s.SaveProject(pf)
 }
 
+// AddProjectBlocker blocks a task on the completion of a whole project: the
+// task stays blocked until every task and wait in that project is done or
+// dropped. The dependency is stored as a blocker reference like @BY. A
+// non-empty reason is stored as with AddBlocker. projectRef must name the
+// project exactly (prefix or ID); its prefix is returned.
+func AddProjectBlocker(s Store, taskID, projectRef, reason string) (string, error) {
+	prefix := model.ExtractPrefix(taskID)
+	if prefix == "" {
+		return "", fmt.Errorf("invalid task ID: %s", taskID)
+	}
+
+	pf, err := s.LoadProject(prefix)
+	if err != nil {
+		return "", err
+	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return "", err
+	}
+
+	task := findTask(pf, taskID)
+	if task == nil {
+		return "", &NotFoundError{Kind: "task", ItemID: taskID}
+	}
+
+	target, err := ResolveProject(s, projectRef)
+	if err != nil {
+		return "", err
+	}
+	if target.Prefix == pf.Prefix {
+		return "", fmt.Errorf("task %s cannot be blocked by its own project", task.ID)
+	}
+
+	ref := model.FormatProjectRef(target.Prefix)
+	if containsFold(task.BlockedBy, ref) {
+		return "", fmt.Errorf("blocker %s already exists on task %s", ref, task.ID)
+	}
+
+	// The target project can't finish while it waits on ours, directly or
+	// through other projects
+	projects, err := loadProjects(s, true, false)
+	if err != nil {
+		return "", err
+	}
+	ownRef := model.FormatProjectRef(pf.Prefix)
+	if cycle := graph.BuildProjectGraph(projects).CheckCycle(ownRef, ref); cycle != nil {
+		return "", fmt.Errorf("adding blocker would create cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	task.BlockedBy = append(task.BlockedBy, ref)
+	if reason = strings.TrimSpace(reason); reason != "" {
+		if task.BlockReasons == nil {
+			task.BlockReasons = make(map[string]string)
+		}
+		task.BlockReasons[ref] = reason
+	}
+	task.Updated = time.Now()
+
+	return target.Prefix, s.SaveProject(pf)
+}
+
 // RemoveBlocker removes a blocker from a task.
 func RemoveBlocker(s Store, taskID, blockerID string) error {
 	prefix := model.ExtractPrefix(taskID)
@@ -738,10 +1405,13 @@ func RemoveBlocker(s Store, taskID, blockerID string) error {
 	if err != nil {
 		return err
 	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return err
+	}
 
 	task := findTask(pf, taskID)
 	if task == nil {
-		return fmt.Errorf("task %s not found", taskID)
+		return &NotFoundError{Kind: "task", ItemID: taskID}
 	}
 
 	// Find and remove blocker
@@ -749,6 +1419,7 @@ func RemoveBlocker(s Store, taskID, blockerID string) error {
 	for i, bid := range task.BlockedBy {
 		if strings.EqualFold(bid, blockerID) {
 			task.BlockedBy = append(task.BlockedBy[:i], task.BlockedBy[i+1:]...)
+			delete(task.BlockReasons, bid)
 			found = true
 			break
 		}
@@ -816,16 +1487,38 @@ func findItem(pf *model.ProjectFile, id string) *itemInfo {
 	}
 }
 
+// isOpenItem reports whether id names an open task or wait in the project.
+func isOpenItem(pf *model.ProjectFile, id string) bool {
+	if w := findWait(pf, id); w != nil {
+		return w.Status == model.WaitStatusOpen
+	}
+	if t := findTask(pf, id); t != nil {
+		return t.Status == model.TaskStatusOpen
+	}
+	return false
+}
+
+// isDoneItem reports whether id names a done task or wait in the project.
+func isDoneItem(pf *model.ProjectFile, id string) bool {
+	if w := findWait(pf, id); w != nil {
+		return w.Status == model.WaitStatusDone
+	}
+	if t := findTask(pf, id); t != nil {
+		return t.Status == model.TaskStatusDone
+	}
+	return false
+}
+
 // validateBlockers checks that all blocker IDs exist in the project.
 func validateBlockers(pf *model.ProjectFile, blockerIDs []string) error {
 	for _, id := range blockerIDs {
 		if model.IsWaitID(id) {
 			if findWait(pf, id) == nil {
-				return fmt.Errorf("blocker %s not found", id)
+				return &NotFoundError{Kind: "blocker", ItemID: id}
 			}
 		} else if model.IsTaskID(id) {
 			if findTask(pf, id) == nil {
-				return fmt.Errorf("blocker %s not found", id)
+				return &NotFoundError{Kind: "blocker", ItemID: id}
 			}
 		} else {
 			return fmt.Errorf("invalid blocker ID: %s", id)
@@ -846,14 +1539,36 @@ func normalizeBlockerIDs(ids []string, maxID int) []string {
 	return result
 }
 
-// ComputeBlockerStates builds a map of ID -> resolved status for all items.
-func ComputeBlockerStates(pf *model.ProjectFile) model.BlockerStatus {
+// sortBlockerIDs sorts blocker IDs by prefix, then ID number, so edits that
+// rebuild a blocker list produce a stable order in the project file.
+func sortBlockerIDs(ids []string) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		pi, pj := model.ExtractPrefix(ids[i]), model.ExtractPrefix(ids[j])
+		if pi != pj {
+			return pi < pj
+		}
+		ni, nj := model.ExtractNumber(ids[i]), model.ExtractNumber(ids[j])
+		if ni != nj {
+			return ni < nj
+		}
+		return ids[i] < ids[j]
+	})
+}
+
+// ComputeBlockerStates builds a map of ID -> resolved status for all items,
+// plus any whole-project blocker references resolved when pf was loaded.
+// Done items are resolved, and so are dropped ones when droppedUnblocks (the
+// dropped_unblocks setting) is true.
+func ComputeBlockerStates(pf *model.ProjectFile, droppedUnblocks bool) model.BlockerStatus {
 	states := make(model.BlockerStatus)
+	for ref, resolved := range pf.ProjectBlockers {
+		states[ref] = resolved
+	}
 	for _, t := range pf.Tasks {
-		states[t.ID] = t.Status == model.TaskStatusDone || t.Status == model.TaskStatusDropped
+		states[t.ID] = t.Status == model.TaskStatusDone || (droppedUnblocks && t.Status == model.TaskStatusDropped)
 	}
 	for _, w := range pf.Waits {
-		states[w.ID] = w.Status == model.WaitStatusDone || w.Status == model.WaitStatusDropped
+		states[w.ID] = w.Status == model.WaitStatusDone || (droppedUnblocks && w.Status == model.WaitStatusDropped)
 	}
 	return states
 }
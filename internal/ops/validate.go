@@ -3,6 +3,7 @@ package ops
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jacksmith/tk/internal/graph"
 	"github.com/jacksmith/tk/internal/model"
@@ -18,8 +19,16 @@ const (
 	ValidationErrorInvalidID       ValidationErrorType = "invalid_id"
 	ValidationErrorMissingRequired ValidationErrorType = "missing_required"
 	ValidationErrorInvalidPriority ValidationErrorType = "invalid_priority"
+	ValidationErrorTagCase         ValidationErrorType = "tag_case"
+	ValidationErrorStaleWait       ValidationErrorType = "stale_wait"
+	ValidationErrorNextID          ValidationErrorType = "next_id"
+	ValidationErrorConfig          ValidationErrorType = "config"
 )
 
+// StaleTimeWaitDays is how long past its 'after' time an actionable time
+// wait may stay open before validate reports it as never checked.
+const StaleTimeWaitDays = 7
+
 // ValidationError represents a data integrity issue.
 type ValidationError struct {
 	Type    ValidationErrorType
@@ -61,11 +70,21 @@ func Validate(s Store) ([]ValidationError, error) {
 
 // validateProject validates a single project.
 func validateProject(s Store, prefix string) ([]ValidationError, error) {
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
 	pf, err := s.LoadProject(prefix)
 	if err != nil {
 		return nil, err
 	}
+	return validateProjectFile(s, pf, cfg.DroppedUnblocks), nil
+}
 
+// validateProjectFile validates an already loaded project. s is used only to
+// check whether projects referenced by @ blockers exist, and droppedUnblocks
+// is the dropped_unblocks setting.
+func validateProjectFile(s Store, pf *model.ProjectFile, droppedUnblocks bool) []ValidationError {
 	var errors []ValidationError
 
 	// Build set of valid IDs
@@ -76,6 +95,7 @@ func validateProject(s Store, prefix string) ([]ValidationError, error) {
 	for _, w := range pf.Waits {
 		validIDs[w.ID] = true
 	}
+	addProjectRefs(s, pf, validIDs)
 
 	// Check for duplicate IDs
 	seenIDs := make(map[string]bool)
@@ -122,6 +142,17 @@ func validateProject(s Store, prefix string) ([]ValidationError, error) {
 		}
 	}
 
+	// Check that next_id is past every number in use. Numbers are never
+	// reused, even after items are moved out, since old IDs may still be
+	// referenced outside tk.
+	if maxNum := maxItemNumber(pf); pf.NextID <= maxNum {
+		errors = append(errors, ValidationError{
+			Type:    ValidationErrorNextID,
+			ItemID:  pf.Prefix,
+			Message: fmt.Sprintf("next_id %d is not greater than highest item number %d; new items would reuse IDs", pf.NextID, maxNum),
+		})
+	}
+
 	// Check for orphan blockers (references to non-existent items)
 	for _, t := range pf.Tasks {
 		for _, blockerID := range t.BlockedBy {
@@ -166,6 +197,20 @@ func validateProject(s Store, prefix string) ([]ValidationError, error) {
 		}
 	}
 
+	// Check that tags are stored lowercase (tags written before
+	// normalization may not be; --fix lowercases them)
+	for _, t := range pf.Tasks {
+		for _, tag := range t.Tags {
+			if tag != strings.ToLower(tag) {
+				errors = append(errors, ValidationError{
+					Type:    ValidationErrorTagCase,
+					ItemID:  t.ID,
+					Message: fmt.Sprintf("tag %q is not lowercase", tag),
+				})
+			}
+		}
+	}
+
 	// Check for missing required fields
 	for _, t := range pf.Tasks {
 		if t.Title == "" {
@@ -201,7 +246,30 @@ func validateProject(s Store, prefix string) ([]ValidationError, error) {
 		}
 	}
 
-	return errors, nil
+	// Check for time waits that should long since have been resolved by
+	// `tk check` (e.g. with autocheck disabled). Dormant waits are skipped
+	// since check leaves them open until their blockers resolve.
+	blockerStates := ComputeBlockerStates(pf, droppedUnblocks)
+	now := time.Now()
+	staleBefore := now.AddDate(0, 0, -StaleTimeWaitDays)
+	for _, w := range pf.Waits {
+		after := w.ResolutionCriteria.After
+		if w.Status != model.WaitStatusOpen || w.ResolutionCriteria.Type != model.ResolutionTypeTime ||
+			after == nil || !after.Before(staleBefore) {
+			continue
+		}
+		if model.ComputeWaitState(&w, blockerStates, now) == model.WaitStateDormant {
+			continue
+		}
+		days := int(now.Sub(*after).Hours() / 24)
+		errors = append(errors, ValidationError{
+			Type:    ValidationErrorStaleWait,
+			ItemID:  w.ID,
+			Message: fmt.Sprintf("time wait passed %d days ago (%s) but is still open; run 'tk check' to resolve it", days, model.FormatDate(*after)),
+		})
+	}
+
+	return errors
 }
 
 // detectCycles finds all cycles in the dependency graph.
@@ -272,6 +340,111 @@ func detectCycles(pf *model.ProjectFile, g *graph.Graph) []ValidationError {
 	return errors
 }
 
+// CycleBreak is a proposed fix for a dependency cycle: removing the single
+// edge "Item is blocked by Blocker" breaks the cycle.
+type CycleBreak struct {
+	Project string   // project prefix
+	Cycle   []string // cycle path as reported by validate, first ID repeated at the end
+	Item    string
+	Blocker string
+}
+
+// SuggestCycleBreaks proposes one edge to remove for each dependency cycle
+// that Validate reports. Blocker edges carry no timestamp, so the edge
+// chosen is the one whose blocked item changed most recently (a task's
+// updated time, a wait's created time), the best available stand-in for the
+// most recently added blocker.
+func SuggestCycleBreaks(s Store) ([]CycleBreak, error) {
+	prefixes, err := s.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	var breaks []CycleBreak
+	for _, prefix := range prefixes {
+		pf, err := s.LoadProject(prefix)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range detectCycles(pf, graph.BuildGraph(pf)) {
+			if b, ok := suggestCycleBreak(pf, e.Details); ok {
+				breaks = append(breaks, b)
+			}
+		}
+	}
+	return breaks, nil
+}
+
+// suggestCycleBreak picks the edge to remove from a cycle path, in which
+// each ID is blocked by the next.
+func suggestCycleBreak(pf *model.ProjectFile, cycle []string) (CycleBreak, bool) {
+	var best CycleBreak
+	var bestTime time.Time
+	found := false
+	for i := 0; i+1 < len(cycle); i++ {
+		changed, ok := itemChangedAt(pf, cycle[i])
+		if !ok {
+			continue
+		}
+		if !found || !changed.Before(bestTime) {
+			best = CycleBreak{Project: pf.Prefix, Cycle: cycle, Item: cycle[i], Blocker: cycle[i+1]}
+			bestTime = changed
+			found = true
+		}
+	}
+	return best, found
+}
+
+// itemChangedAt returns when a task was last updated or a wait created.
+func itemChangedAt(pf *model.ProjectFile, id string) (time.Time, bool) {
+	if t := findTask(pf, id); t != nil {
+		return t.Updated, true
+	}
+	if w := findWait(pf, id); w != nil {
+		return w.Created, true
+	}
+	return time.Time{}, false
+}
+
+// BreakCycle removes the blocker edge proposed by a CycleBreak. Like the
+// fixes applied by ValidateAndFix it is a data repair, so it works in
+// projects of any status.
+func BreakCycle(s Store, b CycleBreak) error {
+	pf, err := s.LoadProject(b.Project)
+	if err != nil {
+		return err
+	}
+
+	removed := false
+	if t := findTask(pf, b.Item); t != nil {
+		t.BlockedBy, removed = removeBlockerRef(t.BlockedBy, b.Blocker)
+		if removed {
+			delete(t.BlockReasons, b.Blocker)
+			t.Updated = time.Now()
+		}
+	} else if w := findWait(pf, b.Item); w != nil {
+		w.BlockedBy, removed = removeBlockerRef(w.BlockedBy, b.Blocker)
+		if removed {
+			delete(w.BlockReasons, b.Blocker)
+		}
+	}
+	if !removed {
+		return fmt.Errorf("blocker %s not found on %s", b.Blocker, b.Item)
+	}
+
+	return s.SaveProject(pf)
+}
+
+// removeBlockerRef removes id from blockedBy, reporting whether it was there.
+func removeBlockerRef(blockedBy []string, id string) ([]string, bool) {
+	for i, bid := range blockedBy {
+		if strings.EqualFold(bid, id) {
+			return append(blockedBy[:i], blockedBy[i+1:]...), true
+		}
+	}
+	return blockedBy, false
+}
+
 // ValidateAndFix validates all projects and auto-repairs fixable issues.
 // Returns a list of fixes that were applied.
 func ValidateAndFix(s Store) ([]ValidationFix, error) {
@@ -310,6 +483,7 @@ func validateAndFixProject(s Store, prefix string) ([]ValidationFix, error) {
 	for _, w := range pf.Waits {
 		validIDs[strings.ToUpper(w.ID)] = true
 	}
+	addProjectRefs(s, pf, validIDs)
 
 	// Fix orphan blockers by removing them
 	for i := range pf.Tasks {
@@ -350,6 +524,32 @@ func validateAndFixProject(s Store, prefix string) ([]ValidationFix, error) {
 		w.BlockedBy = cleanBlockers
 	}
 
+	// Bump next_id past every number in use
+	if maxNum := maxItemNumber(pf); pf.NextID <= maxNum {
+		fixes = append(fixes, ValidationFix{
+			Type:        ValidationErrorNextID,
+			ItemID:      pf.Prefix,
+			Description: fmt.Sprintf("raised next_id from %d to %d", pf.NextID, maxNum+1),
+		})
+		pf.NextID = maxNum + 1
+		modified = true
+	}
+
+	// Lowercase tags
+	for i := range pf.Tasks {
+		t := &pf.Tasks[i]
+		normalized := normalizeTags(t.Tags)
+		if !stringSlicesEqual(normalized, t.Tags) {
+			fixes = append(fixes, ValidationFix{
+				Type:        ValidationErrorTagCase,
+				ItemID:      t.ID,
+				Description: fmt.Sprintf("lowercased tags: %s", strings.Join(normalized, ", ")),
+			})
+			t.Tags = normalized
+			modified = true
+		}
+	}
+
 	if modified {
 		if err := s.SaveProject(pf); err != nil {
 			return nil, err
@@ -359,7 +559,45 @@ func validateAndFixProject(s Store, prefix string) ([]ValidationFix, error) {
 	return fixes, nil
 }
 
+// addProjectRefs marks the whole-project blocker references in pf (@BY) as
+// valid when the referenced project exists.
+func addProjectRefs(s Store, pf *model.ProjectFile, validIDs map[string]bool) {
+	for _, ref := range pf.ProjectRefs() {
+		prefix, _ := model.ParseProjectRef(ref)
+		if s.ProjectExists(prefix) {
+			validIDs[ref] = true
+		}
+	}
+}
+
 // ValidateProject validates a single project by prefix.
 func ValidateProject(s Store, prefix string) ([]ValidationError, error) {
 	return validateProject(s, prefix)
 }
+
+// maxItemNumber returns the highest numeric ID used by any task or wait in
+// the project, or 0 if it has none.
+func maxItemNumber(pf *model.ProjectFile) int {
+	maxNum := 0
+	for _, t := range pf.Tasks {
+		maxNum = max(maxNum, model.ExtractNumber(t.ID))
+	}
+	for _, w := range pf.Waits {
+		maxNum = max(maxNum, model.ExtractNumber(w.ID))
+	}
+	return maxNum
+}
+
+// stringSlicesEqual reports whether two string slices have the same elements
+// in the same order.
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
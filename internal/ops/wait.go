@@ -2,6 +2,8 @@ package ops
 
 import (
 	"fmt"
+	"net/url"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,6 +19,8 @@ type WaitOptions struct {
 	After      *time.Time // For time waits
 	CheckAfter *time.Time // For manual waits (optional)
 	Notes      string
+	Tracking   string // Tracking number or other external identifier
+	Link       string // URL for following the wait externally
 	BlockedBy  []string
 }
 
@@ -27,6 +31,8 @@ type WaitChanges struct {
 	After      **time.Time
 	CheckAfter **time.Time
 	Notes      *string
+	Tracking   *string
+	Link       *string
 	BlockedBy  *[]string
 }
 
@@ -38,8 +44,8 @@ func AddWait(s Store, prefix string, opts WaitOptions) (*model.Wait, error) {
 	}
 
 	// Validate project is active
-	if pf.Status != model.ProjectStatusActive {
-		return nil, fmt.Errorf("cannot add wait to %s project %q", pf.Status, pf.Name)
+	if err := checkProjectWritable(s, pf, "add wait to"); err != nil {
+		return nil, err
 	}
 
 	// Validate resolution type and required fields
@@ -62,10 +68,14 @@ func AddWait(s Store, prefix string, opts WaitOptions) (*model.Wait, error) {
 			return nil, err
 		}
 	}
+	if err := validateLink(opts.Link); err != nil {
+		return nil, err
+	}
 
 	// Create wait with next ID
 	now := time.Now()
-	waitID := model.FormatWaitID(pf.Prefix, pf.NextID, pf.NextID)
+	num := claimNextID(pf)
+	waitID := model.FormatWaitID(pf.Prefix, num, num)
 	wait := model.Wait{
 		ID:     waitID,
 		Title:  opts.Title,
@@ -76,13 +86,14 @@ func AddWait(s Store, prefix string, opts WaitOptions) (*model.Wait, error) {
 			After:      opts.After,
 			CheckAfter: opts.CheckAfter,
 		},
-		BlockedBy: normalizeBlockerIDs(opts.BlockedBy, pf.NextID),
+		BlockedBy: normalizeBlockerIDs(opts.BlockedBy, num),
 		Notes:     opts.Notes,
+		Tracking:  strings.TrimSpace(opts.Tracking),
+		Link:      strings.TrimSpace(opts.Link),
 		Created:   now,
 	}
 
 	pf.Waits = append(pf.Waits, wait)
-	pf.NextID++
 
 	if err := s.SaveProject(pf); err != nil {
 		return nil, err
@@ -102,10 +113,13 @@ func EditWait(s Store, waitID string, changes WaitChanges) error {
 	if err != nil {
 		return err
 	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return err
+	}
 
 	wait := findWait(pf, waitID)
 	if wait == nil {
-		return fmt.Errorf("wait %s not found", waitID)
+		return &NotFoundError{Kind: "wait", ItemID: waitID}
 	}
 
 	// Validate new blockers if being changed
@@ -122,6 +136,12 @@ func EditWait(s Store, waitID string, changes WaitChanges) error {
 		}
 	}
 
+	if changes.Link != nil {
+		if err := validateLink(*changes.Link); err != nil {
+			return err
+		}
+	}
+
 	// Apply changes
 	if changes.Title != nil {
 		wait.Title = *changes.Title
@@ -138,8 +158,15 @@ func EditWait(s Store, waitID string, changes WaitChanges) error {
 	if changes.Notes != nil {
 		wait.Notes = *changes.Notes
 	}
+	if changes.Tracking != nil {
+		wait.Tracking = strings.TrimSpace(*changes.Tracking)
+	}
+	if changes.Link != nil {
+		wait.Link = strings.TrimSpace(*changes.Link)
+	}
 	if changes.BlockedBy != nil {
 		wait.BlockedBy = normalizeBlockerIDs(*changes.BlockedBy, pf.NextID-1)
+		sortBlockerIDs(wait.BlockedBy)
 	}
 
 	return s.SaveProject(pf)
@@ -158,10 +185,17 @@ func ResolveWait(s Store, waitID string, resolution string) error {
 	if err != nil {
 		return err
 	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return err
+	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return err
+	}
 
 	wait := findWait(pf, waitID)
 	if wait == nil {
-		return fmt.Errorf("wait %s not found", waitID)
+		return &NotFoundError{Kind: "wait", ItemID: waitID}
 	}
 
 	if wait.Status != model.WaitStatusOpen {
@@ -169,27 +203,148 @@ func ResolveWait(s Store, waitID string, resolution string) error {
 	}
 
 	// Check if wait is dormant (has unresolved blockers)
-	blockerStates := ComputeBlockerStates(pf)
+	blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
 	for _, blockerID := range wait.BlockedBy {
 		if resolved, ok := blockerStates[blockerID]; !ok || !resolved {
 			return fmt.Errorf("wait is dormant (blocked by %s)", blockerID)
 		}
 	}
 
-	// For time waits that haven't passed yet, update 'after' to now
+	markWaitResolved(wait, resolution, time.Now())
+
+	if err := s.SaveProject(pf); err != nil {
+		return err
+	}
+
+	runResolveHooks(s, wait.ID, resolution)
+	return nil
+}
+
+// ResolveWaitChain resolves a wait together with the open waits upstream of
+// it, for when one real-world event clears a nested set of dormant waits.
+// The chain is resolved leaves first, all with the same resolution. Only
+// waits are resolved: if an open task or an item outside the project stands
+// in the chain, nothing is changed. It returns the resolved IDs in order,
+// ending with waitID.
+func ResolveWaitChain(s Store, waitID string, resolution string) ([]string, error) {
+	prefix := model.ExtractPrefix(waitID)
+	if prefix == "" {
+		return nil, fmt.Errorf("invalid wait ID: %s", waitID)
+	}
+
+	pf, err := s.LoadProject(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return nil, err
+	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	wait := findWait(pf, waitID)
+	if wait == nil {
+		return nil, &NotFoundError{Kind: "wait", ItemID: waitID}
+	}
+	if wait.Status != model.WaitStatusOpen {
+		return nil, fmt.Errorf("wait %s is not open (status: %s)", waitID, wait.Status)
+	}
+
+	// The chain is every unresolved item upstream of the wait
+	blockerStates := ComputeBlockerStates(pf, cfg.DroppedUnblocks)
+	chain := &model.ProjectFile{Waits: []model.Wait{*wait}}
+	var others []string
+	for _, id := range graph.BuildGraph(pf).TransitiveBlockedBy(wait.ID) {
+		if blockerStates[id] {
+			continue
+		}
+		if bw := findWait(pf, id); bw != nil && bw.Status == model.WaitStatusOpen {
+			chain.Waits = append(chain.Waits, *bw)
+		} else {
+			others = append(others, id)
+		}
+	}
+
+	if len(others) > 0 {
+		return nil, fmt.Errorf("cannot resolve the wait chain for %s: it also depends on items that aren't open waits in this project: %s",
+			waitID, strings.Join(others, ", "))
+	}
+
+	// Each wait comes after its own blockers, so the order runs from the
+	// leaves inward and ends with the wait itself
+	order, err := graph.BuildGraph(chain).TopoSort(nil)
+	if err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
+	for _, id := range order {
+		markWaitResolved(findWait(pf, id), resolution, now)
+	}
+
+	if err := s.SaveProject(pf); err != nil {
+		return nil, err
+	}
+
+	for _, id := range order {
+		runResolveHooks(s, id, resolution)
+	}
+	return order, nil
+}
+
+// markWaitResolved marks an open wait done. A time wait resolved before its
+// date has the date moved up to now.
+func markWaitResolved(wait *model.Wait, resolution string, now time.Time) {
 	if wait.ResolutionCriteria.Type == model.ResolutionTypeTime {
 		if wait.ResolutionCriteria.After != nil && wait.ResolutionCriteria.After.After(now) {
 			wait.ResolutionCriteria.After = &now
 		}
 	}
 
-	// Mark as done
 	wait.Status = model.WaitStatusDone
 	wait.DoneAt = &now
 	wait.Resolution = resolution
+}
 
-	return s.SaveProject(pf)
+// resolveTimeWaitBlockers resolves a task's open time-wait blockers early,
+// for work finished ahead of schedule, and marks them resolved in
+// blockerStates. Nothing is resolved unless those waits are the task's only
+// open blockers and none of them is dormant, since the task couldn't be
+// completed afterwards anyway. It only modifies pf in memory and returns the
+// IDs of the resolved waits.
+func resolveTimeWaitBlockers(pf *model.ProjectFile, task *model.Task, blockerStates model.BlockerStatus, now time.Time) []string {
+	var waits []*model.Wait
+	for _, blockerID := range task.BlockedBy {
+		if blockerStates[blockerID] {
+			continue
+		}
+		w := findWait(pf, blockerID)
+		if w == nil || w.ResolutionCriteria.Type != model.ResolutionTypeTime {
+			return nil
+		}
+		for _, id := range w.BlockedBy {
+			if !blockerStates[id] {
+				return nil
+			}
+		}
+		waits = append(waits, w)
+	}
+
+	var waitIDs []string
+	for _, w := range waits {
+		markWaitResolved(w, earlyResolution(task.ID), now)
+		blockerStates[w.ID] = true
+		waitIDs = append(waitIDs, w.ID)
+	}
+	return waitIDs
+}
+
+// earlyResolution is the resolution recorded on time waits resolved early
+// by completing taskID.
+func earlyResolution(taskID string) string {
+	return "resolved early by completing " + taskID
 }
 
 // DropWait marks a wait as dropped.
@@ -205,10 +360,13 @@ func DropWait(s Store, waitID string, reason string, dropDeps, removeDeps bool)
 	if err != nil {
 		return err
 	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return err
+	}
 
 	wait := findWait(pf, waitID)
 	if wait == nil {
-		return fmt.Errorf("wait %s not found", waitID)
+		return &NotFoundError{Kind: "wait", ItemID: waitID}
 	}
 
 	if wait.Status != model.WaitStatusOpen {
@@ -267,10 +425,13 @@ func DeferWait(s Store, waitID string, until time.Time) error {
 	if err != nil {
 		return err
 	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return err
+	}
 
 	wait := findWait(pf, waitID)
 	if wait == nil {
-		return fmt.Errorf("wait %s not found", waitID)
+		return &NotFoundError{Kind: "wait", ItemID: waitID}
 	}
 
 	if wait.Status != model.WaitStatusOpen {
@@ -289,6 +450,155 @@ func DeferWait(s Store, waitID string, until time.Time) error {
 	return s.SaveProject(pf)
 }
 
+// ConvertOptions describes the resolution criteria a wait is converted to.
+type ConvertOptions struct {
+	Type       model.ResolutionType
+	After      *time.Time // Required when converting to a time wait
+	Question   string     // For manual waits; required if the wait has no title
+	CheckAfter *time.Time // For manual waits (optional)
+}
+
+// ConvertWaitType changes an open wait's resolution type, replacing its
+// resolution criteria. The new type's requirements are validated as in
+// AddWait, and fields that only applied to the old type are cleared.
+func ConvertWaitType(s Store, waitID string, opts ConvertOptions) error {
+	prefix := model.ExtractPrefix(waitID)
+	if prefix == "" {
+		return fmt.Errorf("invalid wait ID: %s", waitID)
+	}
+
+	pf, err := s.LoadProject(prefix)
+	if err != nil {
+		return err
+	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return err
+	}
+
+	wait := findWait(pf, waitID)
+	if wait == nil {
+		return &NotFoundError{Kind: "wait", ItemID: waitID}
+	}
+	if wait.Status != model.WaitStatusOpen {
+		return fmt.Errorf("wait %s is not open (status: %s)", waitID, wait.Status)
+	}
+	if wait.ResolutionCriteria.Type == opts.Type {
+		return fmt.Errorf("wait %s is already a %s wait", waitID, opts.Type)
+	}
+
+	var criteria model.ResolutionCriteria
+	switch opts.Type {
+	case model.ResolutionTypeTime:
+		if opts.After == nil {
+			return fmt.Errorf("time waits require 'after' date")
+		}
+		if opts.Question != "" || opts.CheckAfter != nil {
+			return fmt.Errorf("time waits don't have a question or check-after date")
+		}
+		criteria = model.ResolutionCriteria{Type: opts.Type, After: opts.After}
+	case model.ResolutionTypeManual:
+		if opts.After != nil {
+			return fmt.Errorf("manual waits don't have an 'after' date; use check-after")
+		}
+		if opts.Question == "" && wait.Title == "" {
+			return fmt.Errorf("manual waits require 'question' or 'title'")
+		}
+		criteria = model.ResolutionCriteria{Type: opts.Type, Question: opts.Question, CheckAfter: opts.CheckAfter}
+	default:
+		return fmt.Errorf("invalid resolution type: %s", opts.Type)
+	}
+
+	wait.ResolutionCriteria = criteria
+	return s.SaveProject(pf)
+}
+
+// ReopenWait reopens a resolved or dropped wait, clearing done_at,
+// dropped_at, drop_reason, and its resolution. Like ReopenTask, it reports
+// done items that depend on the wait.
+func ReopenWait(s Store, waitID string) (*ReopenResult, error) {
+	prefix := model.ExtractPrefix(waitID)
+	if prefix == "" {
+		return nil, fmt.Errorf("invalid wait ID: %s", waitID)
+	}
+
+	pf, err := s.LoadProject(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return nil, err
+	}
+
+	wait := findWait(pf, waitID)
+	if wait == nil {
+		return nil, &NotFoundError{Kind: "wait", ItemID: waitID}
+	}
+	if wait.Status == model.WaitStatusOpen {
+		return nil, fmt.Errorf("wait %s is already open", waitID)
+	}
+
+	wait.Status = model.WaitStatusOpen
+	wait.DoneAt = nil
+	wait.DroppedAt = nil
+	wait.DropReason = ""
+	wait.Resolution = ""
+
+	result := &ReopenResult{}
+	g := graph.BuildGraph(pf)
+	for _, dependentID := range g.Blocking(wait.ID) {
+		if isDoneItem(pf, dependentID) {
+			result.InconsistentDependents = append(result.InconsistentDependents, dependentID)
+		}
+	}
+	sort.Strings(result.InconsistentDependents)
+
+	if err := s.SaveProject(pf); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UncheckWait reverts a resolved time wait whose date passed without the
+// thing it stood for happening: the wait is reopened with ReopenWait and its
+// date pushed to until with DeferWait. until must be in the future so tk
+// check doesn't resolve it again.
+func UncheckWait(s Store, waitID string, until time.Time) (*ReopenResult, error) {
+	prefix := model.ExtractPrefix(waitID)
+	if prefix == "" {
+		return nil, fmt.Errorf("invalid wait ID: %s", waitID)
+	}
+
+	pf, err := s.LoadProject(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	wait := findWait(pf, waitID)
+	if wait == nil {
+		return nil, &NotFoundError{Kind: "wait", ItemID: waitID}
+	}
+	if wait.ResolutionCriteria.Type != model.ResolutionTypeTime {
+		return nil, fmt.Errorf("wait %s is a %s wait; only time waits are resolved by tk check", waitID, wait.ResolutionCriteria.Type)
+	}
+	if wait.Status != model.WaitStatusDone {
+		return nil, fmt.Errorf("wait %s is not resolved (status: %s)", waitID, wait.Status)
+	}
+	if !until.After(time.Now()) {
+		return nil, fmt.Errorf("new date %s has already passed", model.FormatDate(until))
+	}
+
+	// Everything DeferWait checks is settled above, so it only fails if the
+	// save does
+	result, err := ReopenWait(s, wait.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := DeferWait(s, wait.ID, until); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // AddWaitBlocker adds a blocker to a wait.
 func AddWaitBlocker(s Store, waitID, blockerID string) error {
 	prefix := model.ExtractPrefix(waitID)
@@ -300,10 +610,13 @@ func AddWaitBlocker(s Store, waitID, blockerID string) error {
 	if err != nil {
 		return err
 	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return err
+	}
 
 	wait := findWait(pf, waitID)
 	if wait == nil {
-		return fmt.Errorf("wait %s not found", waitID)
+		return &NotFoundError{Kind: "wait", ItemID: waitID}
 	}
 
 	// Validate blocker exists
@@ -340,10 +653,13 @@ func RemoveWaitBlocker(s Store, waitID, blockerID string) error {
 	if err != nil {
 		return err
 	}
+	if err := checkProjectWritable(s, pf, "modify"); err != nil {
+		return err
+	}
 
 	wait := findWait(pf, waitID)
 	if wait == nil {
-		return fmt.Errorf("wait %s not found", waitID)
+		return &NotFoundError{Kind: "wait", ItemID: waitID}
 	}
 
 	// Find and remove blocker
@@ -351,6 +667,7 @@ func RemoveWaitBlocker(s Store, waitID, blockerID string) error {
 	for i, bid := range wait.BlockedBy {
 		if strings.EqualFold(bid, blockerID) {
 			wait.BlockedBy = append(wait.BlockedBy[:i], wait.BlockedBy[i+1:]...)
+			delete(wait.BlockReasons, bid)
 			found = true
 			break
 		}
@@ -362,3 +679,16 @@ func RemoveWaitBlocker(s Store, waitID, blockerID string) error {
 
 	return s.SaveProject(pf)
 }
+
+// validateLink checks that a wait link, if set, is an absolute URL.
+func validateLink(link string) error {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return nil
+	}
+	u, err := url.Parse(link)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid link %q: must be an absolute URL", link)
+	}
+	return nil
+}
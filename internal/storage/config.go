@@ -1,9 +1,11 @@
 package storage
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -16,10 +18,19 @@ const (
 	DefaultAutoCheck       = false
 	DefaultDefaultProject  = "default"
 	DefaultDefaultPriority = 3
+	DefaultMaxAutoCascade  = 0
+	DefaultDroppedUnblocks = true
+
+	// Default weights for task scoring (see ScoreWeights)
+	DefaultScorePriorityWeight = 3.0
+	DefaultScoreDueWeight      = 2.0
+	DefaultScoreImpactWeight   = 1.0
 )
 
 // Config represents user configuration from .tkconfig.yaml.
-// This file is user-managed and never written by tk.
+// This file is user-managed. tk only writes to it through SetConfigValue, to
+// keep default_project in sync when the project it names is renamed, set up
+// by init --from, or removed.
 type Config struct {
 	// AutoCheck enables auto-running `tk check` on read commands.
 	AutoCheck bool `yaml:"autocheck"`
@@ -29,6 +40,79 @@ type Config struct {
 
 	// DefaultPriority is the default priority for new tasks (1-4).
 	DefaultPriority int `yaml:"default_priority"`
+
+	// MaxAutoCascade is the largest number of tasks a single completion may
+	// auto-complete without confirmation. 0 means no limit.
+	MaxAutoCascade int `yaml:"max_auto_cascade"`
+
+	// OnResolveHook is a command run whenever a wait is resolved.
+	// It receives the wait ID and resolution as arguments.
+	OnResolveHook string `yaml:"on_resolve_hook"`
+
+	// DateFormat is the Go time layout used to display dates (e.g.
+	// "Jan 2, 2006"). Empty uses YYYY-MM-DD.
+	DateFormat string `yaml:"date_format"`
+
+	// Timezone is the IANA zone (e.g. "America/New_York") in which dates
+	// without a time are interpreted: when a due date becomes overdue and
+	// when a date-only defer or wait ends. Empty uses the machine's zone.
+	Timezone string `yaml:"timezone"`
+
+	// PriorityLabels maps priorities (1-4) to display labels, e.g.
+	// {1: critical, 4: low}. Priorities are still stored as integers.
+	PriorityLabels map[int]string `yaml:"priority_labels"`
+
+	// ReadyIncludesSoon is a lookahead window in days. When set, `tk ready`
+	// also lists waiting tasks whose only open blockers are time waits (or
+	// manual waits with a check_after date) falling within the window.
+	// 0 disables it.
+	ReadyIncludesSoon int `yaml:"ready_includes_soon"`
+
+	// ScoreWeights weighs the components of a task's score, used by
+	// `tk next` and `tk ready --sort=score`.
+	ScoreWeights ScoreWeights `yaml:"score_weights"`
+
+	// ProjectsDir is where project files are stored, e.g. a synced folder
+	// shared between machines. Relative paths are relative to the directory
+	// containing .tk/. Empty uses .tk/projects.
+	ProjectsDir string `yaml:"projects_dir"`
+
+	// TagAssignees routes new tasks to an owner by tag, e.g.
+	// {billing: alice}. A rule applies when `tk add` is given no assignee.
+	TagAssignees map[string]string `yaml:"tag_assignees"`
+
+	// IgnoredProjects lists project prefixes or IDs left out of aggregate
+	// views (cross-project list, ready, find, graph, check). The projects
+	// remain reachable with an explicit --project.
+	IgnoredProjects []string `yaml:"ignored_projects"`
+
+	// StrictLoad refuses to operate on a project whose file has structural
+	// problems (duplicate IDs, malformed IDs, a next_id that would reuse an
+	// ID) until they are repaired.
+	StrictLoad bool `yaml:"strict_load"`
+
+	// DroppedUnblocks is whether dropping a blocker releases the items it
+	// blocks. When false, a dropped blocker keeps its dependents blocked
+	// until it is removed from them. Defaults to true.
+	DroppedUnblocks bool `yaml:"dropped_unblocks"`
+
+	// Hooks are commands run after mutating operations, keyed by event
+	// (task_add, task_done, wait_resolve).
+	Hooks []HookConfig `yaml:"hooks"`
+}
+
+// ScoreWeights are the multipliers applied to each scoring component. Each
+// component is normalized to 0..1 before weighting.
+type ScoreWeights struct {
+	Priority float64 `yaml:"priority"` // P1 scores 1, P4 scores 0.25
+	Due      float64 `yaml:"due"`      // ramps from 0 two weeks out to 1 when due or overdue
+	Impact   float64 `yaml:"impact"`   // grows with the number of open items transitively blocked
+}
+
+// HookConfig is a command to run when a given event occurs.
+type HookConfig struct {
+	Event   string `yaml:"event"`
+	Command string `yaml:"command"`
 }
 
 // DefaultConfig returns a Config with default values.
@@ -37,9 +121,40 @@ func DefaultConfig() *Config {
 		AutoCheck:       DefaultAutoCheck,
 		DefaultProject:  DefaultDefaultProject,
 		DefaultPriority: DefaultDefaultPriority,
+		MaxAutoCascade:  DefaultMaxAutoCascade,
+		DroppedUnblocks: DefaultDroppedUnblocks,
+		ScoreWeights: ScoreWeights{
+			Priority: DefaultScorePriorityWeight,
+			Due:      DefaultScoreDueWeight,
+			Impact:   DefaultScoreImpactWeight,
+		},
 	}
 }
 
+// AssigneeForTags returns the assignee mapped by TagAssignees to the first
+// of tags that has a rule, or "" if none does. Tags match case-insensitively.
+func (c *Config) AssigneeForTags(tags []string) string {
+	for _, tag := range tags {
+		for ruleTag, assignee := range c.TagAssignees {
+			if strings.EqualFold(tag, ruleTag) && assignee != "" {
+				return assignee
+			}
+		}
+	}
+	return ""
+}
+
+// IsProjectIgnored reports whether the project with the given prefix and ID
+// is listed in IgnoredProjects. Entries match case-insensitively.
+func (c *Config) IsProjectIgnored(prefix, id string) bool {
+	for _, ignored := range c.IgnoredProjects {
+		if strings.EqualFold(ignored, prefix) || strings.EqualFold(ignored, id) {
+			return true
+		}
+	}
+	return false
+}
+
 // LoadConfig loads .tkconfig.yaml if it exists, otherwise returns defaults.
 // The config file is a sibling to .tk/ (in the same directory).
 // Partial config files are merged with defaults.
@@ -70,3 +185,61 @@ func (s *Storage) LoadConfig() (*Config, error) {
 func (s *Storage) ConfigPath() string {
 	return filepath.Join(s.root, userConfigFile)
 }
+
+// SetConfigValue sets a single top-level string key in .tkconfig.yaml,
+// creating the file if needed. The file is edited as a YAML node tree so
+// comments and the order of other keys are preserved.
+func (s *Storage) SetConfigValue(key, value string) error {
+	configPath := filepath.Join(s.root, userConfigFile)
+
+	var doc yaml.Node
+	data, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", userConfigFile, err)
+	}
+	if len(data) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", userConfigFile, err)
+		}
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to update %s: top level is not a mapping", userConfigFile)
+	}
+
+	updated := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			valueNode := root.Content[i+1]
+			valueNode.Kind = yaml.ScalarNode
+			valueNode.Tag = "!!str"
+			valueNode.Style = 0
+			valueNode.Value = value
+			valueNode.Content = nil
+			updated = true
+			break
+		}
+	}
+	if !updated {
+		root.Content = append(root.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value},
+		)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", userConfigFile, err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", userConfigFile, err)
+	}
+
+	return os.WriteFile(configPath, buf.Bytes(), 0644)
+}
@@ -32,6 +32,7 @@ func TestLoadConfig(t *testing.T) {
 		configContent := `autocheck: true
 default_project: backyard
 default_priority: 1
+max_auto_cascade: 5
 `
 		configPath := filepath.Join(dir, ".tkconfig.yaml")
 		err = os.WriteFile(configPath, []byte(configContent), 0644)
@@ -43,6 +44,7 @@ default_priority: 1
 		assert.True(t, cfg.AutoCheck)
 		assert.Equal(t, "backyard", cfg.DefaultProject)
 		assert.Equal(t, 1, cfg.DefaultPriority)
+		assert.Equal(t, 5, cfg.MaxAutoCascade)
 	})
 
 	t.Run("partial .tkconfig.yaml merges with defaults", func(t *testing.T) {
@@ -103,6 +105,26 @@ default_priority: 1
 		assert.Equal(t, 2, cfg.DefaultPriority)
 	})
 
+	t.Run("partial score_weights keeps other default weights", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := Init(dir, "", "")
+		require.NoError(t, err)
+
+		configContent := `score_weights:
+  impact: 4
+`
+		configPath := filepath.Join(dir, ".tkconfig.yaml")
+		err = os.WriteFile(configPath, []byte(configContent), 0644)
+		require.NoError(t, err)
+
+		cfg, err := s.LoadConfig()
+		require.NoError(t, err)
+
+		assert.Equal(t, 4.0, cfg.ScoreWeights.Impact)
+		assert.Equal(t, DefaultScorePriorityWeight, cfg.ScoreWeights.Priority)
+		assert.Equal(t, DefaultScoreDueWeight, cfg.ScoreWeights.Due)
+	})
+
 	t.Run("invalid YAML returns error with filename", func(t *testing.T) {
 		dir := t.TempDir()
 		s, err := Init(dir, "", "")
@@ -147,6 +169,7 @@ func TestDefaultConfig(t *testing.T) {
 		assert.False(t, cfg.AutoCheck)
 		assert.Equal(t, "default", cfg.DefaultProject)
 		assert.Equal(t, 3, cfg.DefaultPriority)
+		assert.Equal(t, 0, cfg.MaxAutoCascade)
 	})
 }
 
@@ -160,3 +183,43 @@ func TestConfigPath(t *testing.T) {
 		assert.Equal(t, expected, s.ConfigPath())
 	})
 }
+
+func TestSetConfigValue(t *testing.T) {
+	t.Run("updates existing key and preserves comments", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := Init(dir, "", "")
+		require.NoError(t, err)
+
+		configContent := `# my settings
+autocheck: true
+default_project: old # project used by tk add
+default_priority: 2
+`
+		require.NoError(t, os.WriteFile(s.ConfigPath(), []byte(configContent), 0644))
+
+		require.NoError(t, s.SetConfigValue("default_project", "new"))
+
+		data, err := os.ReadFile(s.ConfigPath())
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "# my settings")
+		assert.Contains(t, string(data), "# project used by tk add")
+
+		cfg, err := s.LoadConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "new", cfg.DefaultProject)
+		assert.True(t, cfg.AutoCheck)
+		assert.Equal(t, 2, cfg.DefaultPriority)
+	})
+
+	t.Run("creates file when missing", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := Init(dir, "", "")
+		require.NoError(t, err)
+
+		require.NoError(t, s.SetConfigValue("default_project", "home"))
+
+		cfg, err := s.LoadConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "home", cfg.DefaultProject)
+	})
+}
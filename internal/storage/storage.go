@@ -28,7 +28,8 @@ type StorageConfig struct {
 
 // Storage provides access to a .tk/ directory.
 type Storage struct {
-	root string // path to directory containing .tk/
+	root        string // path to directory containing .tk/
+	projectsDir string // directory holding project files (see ProjectsPath)
 }
 
 // Open returns a Storage for the given directory.
@@ -46,21 +47,43 @@ func Open(dir string) (*Storage, error) {
 		return nil, fmt.Errorf(".tk is not a directory")
 	}
 
-	return &Storage{root: dir}, nil
+	projectsPath, err := ResolveProjectsDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Storage{root: dir, projectsDir: projectsPath}, nil
 }
 
-// Init creates .tk/ directory with a default project.
-// Returns error if .tk/ already exists.
-func Init(dir string, projectName string, prefix string) (*Storage, error) {
-	tkPath := filepath.Join(dir, tkDir)
+// ResolveProjectsDir returns where project files live for the tracker rooted
+// at dir: the projects_dir setting in .tkconfig.yaml if present (relative
+// paths are relative to dir, and a leading ~/ is the home directory),
+// otherwise .tk/projects. It doesn't require dir to be initialized yet.
+func ResolveProjectsDir(dir string) (string, error) {
+	cfg, err := (&Storage{root: dir}).LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	if cfg.ProjectsDir == "" {
+		return filepath.Join(dir, tkDir, projectsDir), nil
+	}
 
-	// Check if .tk/ already exists
-	if _, err := os.Stat(tkPath); err == nil {
-		return nil, fmt.Errorf(".tk/ directory already exists in %s", dir)
-	} else if !os.IsNotExist(err) {
-		return nil, fmt.Errorf("failed to check for .tk/: %w", err)
+	path := cfg.ProjectsDir
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to expand projects_dir: %w", err)
+		}
+		path = filepath.Join(home, rest)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
 	}
+	return path, nil
+}
 
+// Init creates .tk/ directory with a default project.
+// Returns error if .tk/ already exists.
+func Init(dir string, projectName string, prefix string) (*Storage, error) {
 	// Set defaults if not provided
 	if projectName == "" {
 		projectName = "Default"
@@ -72,21 +95,9 @@ func Init(dir string, projectName string, prefix string) (*Storage, error) {
 	// Normalize prefix to uppercase
 	prefix = strings.ToUpper(prefix)
 
-	// Create directory structure
-	projectsPath := filepath.Join(tkPath, projectsDir)
-	if err := os.MkdirAll(projectsPath, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create .tk/projects/: %w", err)
-	}
-
-	// Create config.yaml
-	cfg := StorageConfig{Version: 1}
-	cfgData, err := yaml.Marshal(&cfg)
+	s, err := InitEmpty(dir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal config: %w", err)
-	}
-	cfgPath := filepath.Join(tkPath, configFile)
-	if err := os.WriteFile(cfgPath, cfgData, 0644); err != nil {
-		return nil, fmt.Errorf("failed to write config.yaml: %w", err)
+		return nil, err
 	}
 
 	// Create default project
@@ -102,16 +113,58 @@ func Init(dir string, projectName string, prefix string) (*Storage, error) {
 		},
 	}
 
-	s := &Storage{root: dir}
+	if s.ProjectExists(prefix) {
+		// A shared projects_dir that another tracker already set up
+		return s, nil
+	}
 	if err := s.SaveProject(project); err != nil {
 		// Clean up on failure
-		os.RemoveAll(tkPath)
+		os.RemoveAll(s.TkPath())
 		return nil, fmt.Errorf("failed to create default project: %w", err)
 	}
 
 	return s, nil
 }
 
+// InitEmpty creates the .tk/ directory structure without any projects, for
+// callers that fill it themselves (e.g. tk init --from).
+// Returns error if .tk/ already exists.
+func InitEmpty(dir string) (*Storage, error) {
+	tkPath := filepath.Join(dir, tkDir)
+
+	// Check if .tk/ already exists
+	if _, err := os.Stat(tkPath); err == nil {
+		return nil, fmt.Errorf(".tk/ directory already exists in %s", dir)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to check for .tk/: %w", err)
+	}
+
+	// Create directory structure
+	projectsPath, err := ResolveProjectsDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(tkPath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create .tk/: %w", err)
+	}
+	if err := os.MkdirAll(projectsPath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create projects directory: %w", err)
+	}
+
+	// Create config.yaml
+	cfg := StorageConfig{Version: 1}
+	cfgData, err := yaml.Marshal(&cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	cfgPath := filepath.Join(tkPath, configFile)
+	if err := os.WriteFile(cfgPath, cfgData, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write config.yaml: %w", err)
+	}
+
+	return &Storage{root: dir, projectsDir: projectsPath}, nil
+}
+
 // Root returns the root directory containing .tk/.
 func (s *Storage) Root() string {
 	return s.root
@@ -122,9 +175,27 @@ func (s *Storage) TkPath() string {
 	return filepath.Join(s.root, tkDir)
 }
 
+// ProjectsPath returns the directory holding project files: .tk/projects
+// unless projects_dir in .tkconfig.yaml points elsewhere.
+func (s *Storage) ProjectsPath() string {
+	if s.projectsDir == "" {
+		return filepath.Join(s.root, tkDir, projectsDir)
+	}
+	return s.projectsDir
+}
+
 // projectPath returns the path to a project file by prefix.
 func (s *Storage) projectPath(prefix string) string {
-	return filepath.Join(s.root, tkDir, projectsDir, strings.ToUpper(prefix)+".yaml")
+	return filepath.Join(s.ProjectsPath(), strings.ToUpper(prefix)+".yaml")
+}
+
+// archiveSuffix ends the file name of a project's archive, which sits next
+// to the project file (BY.yaml, BY.archive.yaml).
+const archiveSuffix = ".archive.yaml"
+
+// archivePath returns the path to a project's archive file by prefix.
+func (s *Storage) archivePath(prefix string) string {
+	return filepath.Join(s.ProjectsPath(), strings.ToUpper(prefix)+archiveSuffix)
 }
 
 // LoadProject loads a project by prefix (e.g., "BY").
@@ -135,7 +206,7 @@ func (s *Storage) LoadProject(prefix string) (*model.ProjectFile, error) {
 	// Check if file exists first to give a clearer error message
 	if _, err := os.Stat(path); err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("project with prefix %q not found", strings.ToUpper(prefix))
+			return nil, &model.NotFoundError{Kind: "project", ItemID: strings.ToUpper(prefix), By: "prefix"}
 		}
 		return nil, fmt.Errorf("failed to access project file: %w", err)
 	}
@@ -144,9 +215,30 @@ func (s *Storage) LoadProject(prefix string) (*model.ProjectFile, error) {
 	if err != nil {
 		return nil, err
 	}
+	s.resolveProjectBlockers(pf)
 	return pf, nil
 }
 
+// resolveProjectBlockers fills in pf.ProjectBlockers by checking whether each
+// project referenced as a whole-project blocker (@BY) still has open items.
+// References to missing or unreadable projects are left out, so they keep
+// blocking.
+func (s *Storage) resolveProjectBlockers(pf *model.ProjectFile) {
+	refs := pf.ProjectRefs()
+	if len(refs) == 0 {
+		return
+	}
+	pf.ProjectBlockers = make(model.BlockerStatus)
+	for _, ref := range refs {
+		prefix, _ := model.ParseProjectRef(ref)
+		other, err := model.LoadProject(s.projectPath(prefix))
+		if err != nil {
+			continue
+		}
+		pf.ProjectBlockers[ref] = !other.HasOpenItems()
+	}
+}
+
 // LoadProjectByID loads a project by its ID (e.g., "backyard").
 // This requires scanning all project files to find a match.
 func (s *Storage) LoadProjectByID(id string) (*model.ProjectFile, error) {
@@ -166,21 +258,38 @@ func (s *Storage) LoadProjectByID(id string) (*model.ProjectFile, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("project with id %q not found", id)
+	return nil, &model.NotFoundError{Kind: "project", ItemID: id, By: "id"}
 }
 
 // SaveProject saves a project file.
-// The file is saved to .tk/projects/{PREFIX}.yaml where PREFIX is uppercase.
+// The file is saved to {PREFIX}.yaml in ProjectsPath, where PREFIX is uppercase.
 func (s *Storage) SaveProject(p *model.ProjectFile) error {
 	path := s.projectPath(p.Prefix)
 	return model.SaveProject(path, p)
 }
 
+// LoadArchive loads the archive of closed items moved out of a project by
+// prefix. It returns nil without an error if the project has no archive.
+func (s *Storage) LoadArchive(prefix string) (*model.ProjectFile, error) {
+	path := s.archivePath(prefix)
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to access archive file: %w", err)
+	}
+	return model.LoadProject(path)
+}
+
+// SaveArchive saves a project's archive file.
+func (s *Storage) SaveArchive(p *model.ProjectFile) error {
+	return model.SaveProject(s.archivePath(p.Prefix), p)
+}
+
 // ListProjects returns all project prefixes.
 // Prefixes are returned in uppercase.
 func (s *Storage) ListProjects() ([]string, error) {
-	projectsPath := filepath.Join(s.root, tkDir, projectsDir)
-	entries, err := os.ReadDir(projectsPath)
+	entries, err := os.ReadDir(s.ProjectsPath())
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -194,7 +303,7 @@ func (s *Storage) ListProjects() ([]string, error) {
 			continue
 		}
 		name := entry.Name()
-		if !strings.HasSuffix(name, ".yaml") {
+		if !strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, archiveSuffix) {
 			continue
 		}
 		prefix := strings.TrimSuffix(name, ".yaml")
@@ -204,17 +313,20 @@ func (s *Storage) ListProjects() ([]string, error) {
 	return prefixes, nil
 }
 
-// DeleteProject removes a project file.
+// DeleteProject removes a project file and its archive, if any.
 // Prefix lookup is case-insensitive.
 func (s *Storage) DeleteProject(prefix string) error {
 	path := s.projectPath(prefix)
 	err := os.Remove(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return fmt.Errorf("project with prefix %q not found", strings.ToUpper(prefix))
+			return &model.NotFoundError{Kind: "project", ItemID: strings.ToUpper(prefix), By: "prefix"}
 		}
 		return fmt.Errorf("failed to delete project: %w", err)
 	}
+	if err := os.Remove(s.archivePath(prefix)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete project archive: %w", err)
+	}
 	return nil
 }
 
@@ -185,6 +185,10 @@ func TestLoadProject(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "not found")
 		assert.Contains(t, err.Error(), "XX")
+
+		var notFound *model.NotFoundError
+		require.ErrorAs(t, err, &notFound)
+		assert.Equal(t, "project", notFound.Kind)
 	})
 }
 
@@ -494,7 +498,8 @@ func TestDeleteProject(t *testing.T) {
 
 		err = s.DeleteProject("XX")
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "not found")
+		var notFound *model.NotFoundError
+		assert.ErrorAs(t, err, &notFound)
 	})
 }
 
@@ -526,6 +531,66 @@ func TestProjectExists(t *testing.T) {
 	})
 }
 
+func TestArchive(t *testing.T) {
+	t.Run("load returns nil when there is no archive", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := Init(dir, "", "BY")
+		require.NoError(t, err)
+
+		archive, err := s.LoadArchive("BY")
+		require.NoError(t, err)
+		assert.Nil(t, archive)
+	})
+
+	t.Run("save and load round trip", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := Init(dir, "", "BY")
+		require.NoError(t, err)
+
+		pf, err := s.LoadProject("BY")
+		require.NoError(t, err)
+		pf.Tasks = []model.Task{{ID: "BY-01", Title: "Old task", Status: model.TaskStatusDone}}
+		require.NoError(t, s.SaveArchive(pf))
+
+		_, err = os.Stat(filepath.Join(s.ProjectsPath(), "BY.archive.yaml"))
+		require.NoError(t, err)
+
+		archive, err := s.LoadArchive("by")
+		require.NoError(t, err)
+		require.Len(t, archive.Tasks, 1)
+		assert.Equal(t, "Old task", archive.Tasks[0].Title)
+	})
+
+	t.Run("archives are not listed as projects", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := Init(dir, "", "BY")
+		require.NoError(t, err)
+
+		pf, err := s.LoadProject("BY")
+		require.NoError(t, err)
+		require.NoError(t, s.SaveArchive(pf))
+
+		prefixes, err := s.ListProjects()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"BY"}, prefixes)
+	})
+
+	t.Run("deleting a project deletes its archive", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := Init(dir, "", "BY")
+		require.NoError(t, err)
+
+		pf, err := s.LoadProject("BY")
+		require.NoError(t, err)
+		require.NoError(t, s.SaveArchive(pf))
+		require.NoError(t, s.DeleteProject("BY"))
+
+		archive, err := s.LoadArchive("BY")
+		require.NoError(t, err)
+		assert.Nil(t, archive)
+	})
+}
+
 func TestStoragePaths(t *testing.T) {
 	t.Run("Root returns correct path", func(t *testing.T) {
 		dir := t.TempDir()
@@ -543,4 +608,56 @@ func TestStoragePaths(t *testing.T) {
 		expected := filepath.Join(dir, ".tk")
 		assert.Equal(t, expected, s.TkPath())
 	})
+
+	t.Run("ProjectsPath defaults to .tk/projects", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := Init(dir, "", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, filepath.Join(dir, ".tk", "projects"), s.ProjectsPath())
+	})
+}
+
+func TestProjectsDirConfig(t *testing.T) {
+	t.Run("relative projects_dir is used by init and open", func(t *testing.T) {
+		dir := t.TempDir()
+		config := "projects_dir: shared/tasks\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".tkconfig.yaml"), []byte(config), 0644))
+
+		s, err := Init(dir, "", "")
+		require.NoError(t, err)
+		shared := filepath.Join(dir, "shared", "tasks")
+		assert.Equal(t, shared, s.ProjectsPath())
+		assert.FileExists(t, filepath.Join(shared, "DF.yaml"))
+		assert.NoDirExists(t, filepath.Join(dir, ".tk", "projects"))
+
+		s, err = Open(dir)
+		require.NoError(t, err)
+		prefixes, err := s.ListProjects()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"DF"}, prefixes)
+	})
+
+	t.Run("init on a second machine keeps existing shared projects", func(t *testing.T) {
+		shared := t.TempDir()
+		config := "projects_dir: " + shared + "\n"
+
+		first := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(first, ".tkconfig.yaml"), []byte(config), 0644))
+		s, err := Init(first, "", "")
+		require.NoError(t, err)
+		pf, err := s.LoadProject("DF")
+		require.NoError(t, err)
+		pf.Tasks = append(pf.Tasks, model.Task{ID: "DF-01", Title: "Shared", Status: model.TaskStatusOpen, Priority: 3})
+		pf.NextID = 2
+		require.NoError(t, s.SaveProject(pf))
+
+		second := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(second, ".tkconfig.yaml"), []byte(config), 0644))
+		s, err = Init(second, "", "")
+		require.NoError(t, err)
+		pf, err = s.LoadProject("DF")
+		require.NoError(t, err)
+		assert.Len(t, pf.Tasks, 1)
+	})
 }